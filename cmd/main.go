@@ -4,14 +4,22 @@ import (
 	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkysr90/rizkiplastik-be/internal/config"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricescheduler"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
 )
 
 func main() {
-	ctx := context.Background()
+	// ctx is cancelled on SIGINT or SIGTERM, the server and the price
+	// scheduler stop before the database pool is closed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// -----------------------------------------------------------------------------------------------------------------
 	// LOAD APPLICATION CONFIG FROM ENVIRONMENT VARIABLES
@@ -35,15 +43,36 @@ func main() {
 		log.Fatalf("main: failed to setup database connection: %s", err)
 		return
 	}
-	defer dbpool.Close()
+
+	// Apply scheduled price changes in the background
+	productVariantRepo := pg.NewProductVariant(dbpool)
+	priceScheduler := pricescheduler.NewScheduler(
+		dbpool,
+		productVariantRepo,
+		pg.NewVariantPriceSchedule(dbpool),
+		costing.NewRepackCost(pg.NewRepackRecipe(dbpool), productVariantRepo),
+		cfg.PriceScheduleInterval,
+	)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		priceScheduler.Run(ctx)
+	}()
 
 	// Start the server
 	server := handler.NewServer(dbpool, cfg)
 
 	log.Printf("Server starting on port %s", cfg.ServerPort)
-	if err := server.Run(":" + cfg.ServerPort); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	serverErr := server.Run(ctx, ":"+cfg.ServerPort)
+
+	// Stop the scheduler and let it finish its batch before closing the pool
+	stop()
+	<-schedulerDone
+	dbpool.Close()
+	if serverErr != nil {
+		log.Fatalf("Failed to start server: %v", serverErr)
 	}
+	log.Println("Server stopped")
 }
 
 // setupDatabase initializes and returns a PostgreSQL connection pool
@@ -0,0 +1,68 @@
+// Command migrate-legacy moves the products_old rows to the product and
+// variant model. Each legacy product becomes a SINGLE product with one
+// variant, its shopee names become a SHOPEE channel listing.
+//
+//	go run ./cmd/migrate-legacy -mapping mapping.json -dry-run -report report.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/config"
+)
+
+// maxLengthMigratedBy is the size of the created_by columns
+const maxLengthMigratedBy = 30
+
+func main() {
+	ctx := context.Background()
+
+	mappingPath := flag.String("mapping", "", "path of the mapping json file")
+	dryRun := flag.Bool("dry-run", false, "run every insert then roll it back")
+	reportPath := flag.String("report", "", "optional path of a csv report")
+	migratedBy := flag.String("migrated-by", "legacy-migration",
+		"value written to created_by and updated_by")
+	flag.Parse()
+
+	if *mappingPath == "" {
+		log.Fatal("migrate-legacy: -mapping is required")
+	}
+	if *migratedBy == "" || len(*migratedBy) > maxLengthMigratedBy {
+		log.Fatalf("migrate-legacy: -migrated-by must be 1 to %d characters",
+			maxLengthMigratedBy)
+	}
+	mapping, err := loadMapping(*mappingPath)
+	if err != nil {
+		log.Fatalf("migrate-legacy: failed to load mapping: %s", err)
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		log.Fatalf("migrate-legacy: failed to load and parse config: %s", err)
+	}
+	dbpool, err := pgxpool.New(ctx, cfg.PostgreSQL.GetConnectionString())
+	if err != nil {
+		log.Fatalf("migrate-legacy: failed to setup database connection: %s", err)
+	}
+	defer dbpool.Close()
+
+	rows, err := newMigrator(dbpool, mapping, *dryRun, *migratedBy).run(ctx)
+	if err != nil {
+		log.Fatalf("migrate-legacy: %s", err)
+	}
+	if *dryRun {
+		log.Println("migrate-legacy: dry run, nothing was written")
+	}
+	if err := printReport(os.Stdout, rows); err != nil {
+		log.Fatalf("migrate-legacy: failed to print report: %s", err)
+	}
+	if *reportPath != "" {
+		if err := writeCSVReport(*reportPath, rows); err != nil {
+			log.Fatalf("migrate-legacy: failed to write report: %s", err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+// Mapping decides the category, packaging type and size unit of every
+// legacy product. A product entry wins over the rules, the first rule whose
+// name_contains matches wins over the default.
+//
+//	{
+//	  "default": {"category_code": "PLS", "packaging_type_code": "B", "size_value": 1, "size_unit_code": "PCS"},
+//	  "rules": [
+//	    {"name_contains": "GELAS", "target": {"category_code": "GLS", "packaging_type_code": "B", "size_value": 50, "size_unit_code": "PCS"}}
+//	  ],
+//	  "products": {
+//	    "<products_old id>": {"category_code": "PLS", "packaging_type_code": "K", "size_value": 500, "size_unit_code": "GR", "base_name": "PLASTIK HD"}
+//	  }
+//	}
+type Mapping struct {
+	Default  *MappingTarget           `json:"default"`
+	Rules    []MappingRule            `json:"rules"`
+	Products map[string]MappingTarget `json:"products"`
+}
+
+type MappingRule struct {
+	NameContains string        `json:"name_contains"`
+	Target       MappingTarget `json:"target"`
+}
+
+type MappingTarget struct {
+	CategoryCode      string  `json:"category_code"`
+	PackagingTypeCode string  `json:"packaging_type_code"`
+	SizeValue         float32 `json:"size_value"`
+	SizeUnitCode      string  `json:"size_unit_code"`
+	// BaseName replaces the legacy name, only used by product entries
+	BaseName string `json:"base_name"`
+}
+
+func loadMapping(path string) (*Mapping, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mapping := &Mapping{}
+	if err := json.Unmarshal(content, mapping); err != nil {
+		return nil, fmt.Errorf("invalid mapping file: %w", err)
+	}
+	if err := mapping.validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping file: %w", err)
+	}
+	return mapping, nil
+}
+
+func (m *Mapping) validate() error {
+	if m.Default != nil {
+		if err := m.Default.validate(); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+	for i := range m.Rules {
+		rule := &m.Rules[i]
+		rule.NameContains = strings.TrimSpace(rule.NameContains)
+		if rule.NameContains == "" {
+			return fmt.Errorf("rules[%d]: name_contains is required", i)
+		}
+		if err := rule.Target.validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	for id, target := range m.Products {
+		if err := target.validate(); err != nil {
+			return fmt.Errorf("products[%s]: %w", id, err)
+		}
+		m.Products[id] = target
+	}
+	return nil
+}
+
+func (t *MappingTarget) validate() error {
+	t.CategoryCode = strings.TrimSpace(strings.ToUpper(t.CategoryCode))
+	t.PackagingTypeCode = strings.TrimSpace(strings.ToUpper(t.PackagingTypeCode))
+	t.SizeUnitCode = strings.TrimSpace(strings.ToUpper(t.SizeUnitCode))
+	t.BaseName = strings.TrimSpace(t.BaseName)
+	if t.CategoryCode == "" || t.PackagingTypeCode == "" || t.SizeUnitCode == "" {
+		return errors.New("category_code, packaging_type_code and size_unit_code are required")
+	}
+	if t.SizeValue <= 0 {
+		return errors.New("size_value must be greater than 0")
+	}
+	return nil
+}
+
+// resolve returns the target of a legacy product, nil when nothing matches
+func (m *Mapping) resolve(product *repository.LegacyProductData) *MappingTarget {
+	if target, ok := m.Products[product.ID]; ok {
+		return &target
+	}
+	name := strings.ToUpper(product.Name)
+	for i := range m.Rules {
+		if strings.Contains(name, strings.ToUpper(m.Rules[i].NameContains)) {
+			target := m.Rules[i].Target
+			target.BaseName = ""
+			return &target
+		}
+	}
+	if m.Default != nil {
+		target := *m.Default
+		target.BaseName = ""
+		return &target
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/sku"
+	"github.com/shopspring/decimal"
+)
+
+// maxLengthBaseName is the size of products.base_name
+const maxLengthBaseName = 100
+
+var errDryRun = errors.New("dry run")
+
+type migrator struct {
+	db                              *pgxpool.Pool
+	mapping                         *Mapping
+	dryRun                          bool
+	migratedBy                      string
+	legacyProductRepository         repository.LegacyProduct
+	productRepository               repository.ProductRepository
+	productVariantRepository        repository.ProductVariant
+	variantChannelListingRepository repository.VariantChannelListing
+	// skuBuilder lives for the whole run, so it keeps the SKUs of the rows
+	// migrated earlier reserved even though a dry run rolls them back
+	skuBuilder *sku.Builder
+	// listingKeys holds the shopee names used earlier in the run, a dry run
+	// rolls every row back so the unique index alone cannot catch them
+	listingKeys map[repository.MarketplaceProductKey]string
+}
+
+func newMigrator(db *pgxpool.Pool, mapping *Mapping, dryRun bool, migratedBy string) *migrator {
+	productVariantRepository := pg.NewProductVariant(db)
+	return &migrator{
+		db:                              db,
+		mapping:                         mapping,
+		dryRun:                          dryRun,
+		migratedBy:                      migratedBy,
+		legacyProductRepository:         pg.NewLegacyProduct(db),
+		productRepository:               pg.NewProduct(db),
+		productVariantRepository:        productVariantRepository,
+		variantChannelListingRepository: pg.NewVariantChannelListing(db),
+		skuBuilder:                      sku.NewBuilder(productVariantRepository),
+		listingKeys:                     map[repository.MarketplaceProductKey]string{},
+	}
+}
+
+// run migrates every legacy product in its own transaction so one bad row
+// does not stop the others. In dry run every transaction is rolled back
+// after all inserts succeeded.
+func (m *migrator) run(ctx context.Context) ([]reportRow, error) {
+	legacyProducts, err := m.legacyProductRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load legacy products: %w", err)
+	}
+	migratedIDs, err := m.legacyProductRepository.FindMigratedIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrated legacy products: %w", err)
+	}
+	migrated := make(map[string]bool, len(migratedIDs))
+	for _, id := range migratedIDs {
+		migrated[id] = true
+	}
+	rows := make([]reportRow, 0, len(legacyProducts))
+	for i := range legacyProducts {
+		legacyProduct := &legacyProducts[i]
+		row := reportRow{
+			LegacyProductID: legacyProduct.ID,
+			Name:            legacyProduct.Name,
+		}
+		target := m.mapping.resolve(legacyProduct)
+		switch {
+		case migrated[legacyProduct.ID]:
+			row.Status = statusAlreadyMigrated
+		case target == nil:
+			row.Status = statusUnmapped
+			row.Message = "no mapping matches the product"
+		default:
+			err := m.migrateProduct(ctx, legacyProduct, target, &row)
+			switch {
+			case errors.Is(err, errDryRun):
+				row.Status = statusWouldMigrate
+			case err != nil:
+				row.Status = statusFailed
+				row.Message = err.Error()
+			default:
+				row.Status = statusMigrated
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (m *migrator) migrateProduct(
+	ctx context.Context,
+	legacyProduct *repository.LegacyProductData,
+	target *MappingTarget,
+	row *reportRow,
+) error {
+	tx, err := m.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	mapping, err := m.legacyProductRepository.FindMappingByCodes(
+		ctx, tx, target.CategoryCode, target.PackagingTypeCode, target.SizeUnitCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf(
+				"category %s does not exist or does not allow packaging %s and size unit %s",
+				target.CategoryCode, target.PackagingTypeCode, target.SizeUnitCode)
+		}
+		return err
+	}
+	baseName := strings.ToUpper(strings.TrimSpace(legacyProduct.Name))
+	if target.BaseName != "" {
+		baseName = strings.ToUpper(target.BaseName)
+	}
+	if len(baseName) > maxLengthBaseName {
+		return fmt.Errorf("base name is longer than %d characters", maxLengthBaseName)
+	}
+	product := &repository.ProductData{
+		ID:          uuid.NewString(),
+		BaseName:    baseName,
+		CategoryID:  mapping.CategoryID,
+		ProductType: repository.ProductTypeSingle,
+		CreatedBy:   m.migratedBy,
+		UpdatedBy:   m.migratedBy,
+	}
+	variant := &repository.ProductVariantData{
+		ID:              uuid.NewString(),
+		ProductID:       product.ID,
+		ProductName:     baseName,
+		FullName:        baseName,
+		PackagingTypeID: mapping.PackagingTypeID,
+		SizeValue:       target.SizeValue,
+		SizeUnitID:      mapping.SizeUnitID,
+		CostPrice:       decimal.NullDecimal{Decimal: legacyProduct.CostPrice, Valid: true},
+		SellingPrice:    legacySellingPrice(legacyProduct),
+		IsActive:        true,
+		CreatedBy:       m.migratedBy,
+		UpdatedBy:       m.migratedBy,
+	}
+	variant.SKU, err = m.skuBuilder.Build(ctx, tx, sku.Base(
+		mapping.CategoryCode, mapping.PackagingTypeCode,
+		variant.SizeValue, mapping.SizeUnitCode))
+	if err != nil {
+		return err
+	}
+	migrated := false
+	defer func() {
+		// the SKU of a failed row stays free for the rows after it
+		if !migrated {
+			m.skuBuilder.Release(variant.SKU)
+		}
+	}()
+	if err := m.productRepository.InsertTransaction(ctx, tx, product); err != nil {
+		return fmt.Errorf("failed to insert product: %w", err)
+	}
+	if err := m.productVariantRepository.InsertTransaction(ctx, tx, variant); err != nil {
+		return fmt.Errorf("failed to insert variant: %w", err)
+	}
+	migration := &repository.LegacyProductMigrationData{
+		LegacyProductID: legacyProduct.ID,
+		ProductID:       product.ID,
+		VariantID:       variant.ID,
+		MigratedBy:      m.migratedBy,
+	}
+	listing := m.shopeeListing(legacyProduct, variant.ID)
+	var listingKey repository.MarketplaceProductKey
+	if listing != nil {
+		listingKey = repository.MarketplaceProductKey{
+			ListingName:   strings.ToLower(listing.ListingName),
+			VariationName: strings.ToLower(listing.VariationName.String),
+		}
+		if name, ok := m.listingKeys[listingKey]; ok {
+			return fmt.Errorf("shopee names are already used by legacy product %s", name)
+		}
+		if err := m.variantChannelListingRepository.InsertTransaction(
+			ctx, tx, listing); err != nil {
+			return fmt.Errorf("failed to insert shopee listing: %w", err)
+		}
+		migration.ListingID = sql.NullString{String: listing.ID, Valid: true}
+	}
+	if err := m.legacyProductRepository.InsertMigrationTransaction(
+		ctx, tx, migration); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+	if !m.dryRun {
+		if err := tx.Commit(ctx); err != nil {
+			return err
+		}
+	}
+	row.ProductID = product.ID
+	row.VariantID = variant.ID
+	row.SKU = variant.SKU
+	if listing != nil {
+		m.listingKeys[listingKey] = legacyProduct.Name
+		row.ListingID = listing.ID
+	}
+	migrated = true
+	if m.dryRun {
+		return errDryRun
+	}
+	return nil
+}
+
+// shopeeListing keeps the legacy shopee names so imported shopee orders
+// still match the product after the migration
+func (m *migrator) shopeeListing(
+	legacyProduct *repository.LegacyProductData,
+	variantID string,
+) *repository.VariantChannelListingData {
+	listingName := strings.TrimSpace(legacyProduct.ShopeeName.String)
+	if listingName == "" {
+		return nil
+	}
+	listing := &repository.VariantChannelListingData{
+		ID:          uuid.NewString(),
+		VariantID:   variantID,
+		Channel:     repository.OnlineChannelShopee,
+		ListingName: listingName,
+		IsActive:    true,
+		CreatedBy:   m.migratedBy,
+		UpdatedBy:   m.migratedBy,
+	}
+	if variationName := strings.TrimSpace(
+		legacyProduct.ShopeeVarianName.String); variationName != "" {
+		listing.VariationName = sql.NullString{String: variationName, Valid: true}
+	}
+	if category := strings.TrimSpace(
+		legacyProduct.ShopeeCategory.String); category != "" {
+		listing.FeeCategory = sql.NullString{
+			String: strings.ToUpper(category),
+			Valid:  true,
+		}
+	}
+	return listing
+}
+
+// legacySellingPrice is the cost plus the legacy gross profit percentage,
+// rounded like the legacy pricing did
+func legacySellingPrice(legacyProduct *repository.LegacyProductData) decimal.Decimal {
+	percentage := legacyProduct.GrossProfitPercentage.Decimal
+	return legacyProduct.CostPrice.Add(
+		legacyProduct.CostPrice.Mul(percentage).Div(decimal.NewFromInt(100)),
+	).Round(0)
+}
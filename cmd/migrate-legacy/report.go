@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Status of a legacy product in the report
+const (
+	statusMigrated        = "MIGRATED"
+	statusWouldMigrate    = "WOULD_MIGRATE"
+	statusAlreadyMigrated = "ALREADY_MIGRATED"
+	statusUnmapped        = "UNMAPPED"
+	statusFailed          = "FAILED"
+)
+
+type reportRow struct {
+	LegacyProductID string
+	Name            string
+	Status          string
+	ProductID       string
+	VariantID       string
+	SKU             string
+	ListingID       string
+	Message         string
+}
+
+var reportHeader = []string{
+	"row", "legacy_product_id", "name", "status",
+	"product_id", "variant_id", "sku", "listing_id", "message",
+}
+
+func (r *reportRow) record(index int) []string {
+	return []string{
+		strconv.Itoa(index + 1),
+		r.LegacyProductID,
+		r.Name,
+		r.Status,
+		r.ProductID,
+		r.VariantID,
+		r.SKU,
+		r.ListingID,
+		r.Message,
+	}
+}
+
+func printReport(w io.Writer, rows []reportRow) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ROW\tNAME\tSTATUS\tSKU\tMESSAGE")
+	counts := map[string]int{}
+	for i := range rows {
+		row := &rows[i]
+		counts[row.Status]++
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n",
+			i+1, row.Name, row.Status, row.SKU, row.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\ntotal %d", len(rows))
+	for _, status := range []string{
+		statusMigrated, statusWouldMigrate, statusAlreadyMigrated,
+		statusUnmapped, statusFailed,
+	} {
+		if counts[status] > 0 {
+			fmt.Fprintf(w, ", %s %d", status, counts[status])
+		}
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+func writeCSVReport(path string, rows []reportRow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write(reportHeader); err != nil {
+		return err
+	}
+	for i := range rows {
+		if err := w.Write(rows[i].record(i)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
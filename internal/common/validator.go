@@ -2,6 +2,7 @@ package common
 
 import (
 	"errors"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -50,6 +51,15 @@ func ValidateEquals(str string, allowedWords []string) error {
 	return nil
 }
 
+// ValidateOneOf checks that str is exactly one of the allowed values, use it
+// for enums instead of ValidateEquals which only checks for a substring
+func ValidateOneOf(str string, allowedValues []string) error {
+	if !slices.Contains(allowedValues, str) {
+		return errors.New("must be one of " + strings.Join(allowedValues, ", "))
+	}
+	return nil
+}
+
 func ValidateOnlyAllowedUppercaseLetter(str, fieldName string) error {
 	for _, char := range str {
 		if char < 'A' || char > 'Z' {
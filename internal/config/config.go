@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -17,6 +19,17 @@ type Config struct {
 	LogPath    string
 	PostgreSQL PostgreSQLConfig
 	JWTSecret  string
+	// AccessTokenTTL is the lifetime of the JWT sent on every request
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL is the lifetime of a session without a refresh
+	RefreshTokenTTL time.Duration
+	// SKUUpdatePolicy is either REGENERATE or FREEZE, see sku.UpdatePolicy
+	SKUUpdatePolicy string
+	// PriceScheduleInterval is how often due price schedules are applied
+	PriceScheduleInterval time.Duration
+	// TrustedProxies are the IPs or CIDRs allowed to set X-Forwarded-For, nil
+	// trusts no proxy and the client IP is the remote address
+	TrustedProxies []string
 }
 
 // PostgreSQLConfig holds PostgreSQL database configuration
@@ -49,6 +62,24 @@ func LoadFromEnv() (*Config, error) {
 	pgMaxConnLifetime, _ := strconv.Atoi(getEnv("PG_MAX_CONN_LIFETIME", "1800"))
 	pgMaxConnIdleTime, _ := strconv.Atoi(getEnv("PG_MAX_CONN_IDLE_TIME", "30"))
 	pgPort, _ := strconv.Atoi(getEnv("PG_PORT", "5432"))
+	accessTokenTTL, _ := strconv.Atoi(getEnv("ACCESS_TOKEN_TTL_MINUTES", "15"))
+	refreshTokenTTL, _ := strconv.Atoi(getEnv("REFRESH_TOKEN_TTL_HOURS", "720"))
+	priceScheduleInterval, _ := strconv.Atoi(getEnv("PRICE_SCHEDULE_INTERVAL_SECONDS", "60"))
+	if priceScheduleInterval <= 0 {
+		priceScheduleInterval = 60
+	}
+
+	trustedProxies, err := parseTrustedProxies(getEnv("TRUSTED_PROXIES", ""))
+	if err != nil {
+		return nil, err
+	}
+	skuUpdatePolicy := strings.ToUpper(strings.TrimSpace(
+		getEnv("SKU_UPDATE_POLICY", "REGENERATE")))
+	if skuUpdatePolicy != "REGENERATE" && skuUpdatePolicy != "FREEZE" {
+		return nil, fmt.Errorf(
+			"invalid SKU_UPDATE_POLICY %q, must be REGENERATE or FREEZE",
+			skuUpdatePolicy)
+	}
 
 	config := &Config{
 		AppName:    getEnv("APP_NAME", "RizkiPlastik API"),
@@ -67,12 +98,34 @@ func LoadFromEnv() (*Config, error) {
 			MaxConnLifetime: time.Duration(pgMaxConnLifetime) * time.Second,
 			MaxConnIdleTime: time.Duration(pgMaxConnIdleTime) * time.Second,
 		},
-		JWTSecret: getEnv("JWT_SECRET", ""),
+		JWTSecret:             getEnv("JWT_SECRET", ""),
+		AccessTokenTTL:        time.Duration(accessTokenTTL) * time.Minute,
+		RefreshTokenTTL:       time.Duration(refreshTokenTTL) * time.Hour,
+		SKUUpdatePolicy:       skuUpdatePolicy,
+		PriceScheduleInterval: time.Duration(priceScheduleInterval) * time.Second,
+		TrustedProxies:        trustedProxies,
 	}
 
 	return config, nil
 }
 
+// parseTrustedProxies parses a comma separated list of IPs or CIDRs, an
+// empty list returns nil
+func parseTrustedProxies(value string) ([]string, error) {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q", proxy)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -6,3 +6,8 @@ const (
 	MaxLengthProductBaseName    = 100
 	MaxLengthProductVariantName = 100
 )
+
+const (
+	RoleAdmin = "ADMIN"
+	RoleStaff = "STAFF"
+)
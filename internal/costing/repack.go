@@ -0,0 +1,130 @@
+// Package costing keeps the stored cost price of repacked variants in line
+// with the cost price of the variants they are repacked from.
+package costing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// MaxRepackChainDepth bounds cost propagation so a broken recipe graph can
+// not loop forever
+const MaxRepackChainDepth = 20
+
+var ErrRepackChainTooDeep = errors.New(
+	"repack chain is too deep to recompute cost price")
+
+// ErrVariantNotFound is returned when a variant whose cost is read is deleted
+var ErrVariantNotFound = errors.New("variant not found")
+
+// DeriveRepackCostPrice returns the cost of one repacked unit: the parent
+// cost spread over quantity_ratio plus the repack cost per unit. The result
+// is invalid when the parent has no cost price yet.
+func DeriveRepackCostPrice(
+	parentCostPrice decimal.NullDecimal,
+	quantityRatio float32,
+	repackCostPerUnit decimal.Decimal,
+) decimal.NullDecimal {
+	ratio := decimal.NewFromFloat32(quantityRatio)
+	if !parentCostPrice.Valid || !ratio.IsPositive() {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{
+		Decimal: parentCostPrice.Decimal.Div(ratio).Add(repackCostPerUnit).Round(2),
+		Valid:   true,
+	}
+}
+
+type RepackCost struct {
+	repackRecipeRepository   repository.RepackRecipe
+	productVariantRepository repository.ProductVariant
+}
+
+func NewRepackCost(
+	repackRecipeRepository repository.RepackRecipe,
+	productVariantRepository repository.ProductVariant,
+) *RepackCost {
+	return &RepackCost{
+		repackRecipeRepository:   repackRecipeRepository,
+		productVariantRepository: productVariantRepository,
+	}
+}
+
+// FindCostPrices maps variant id to its current cost price. Inactive
+// variants are included, a deactivated parent still has a cost its repacked
+// children derive from, and ErrVariantNotFound is returned instead of a
+// missing entry so a child cost is never nulled by accident.
+func (r *RepackCost) FindCostPrices(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+) (map[string]decimal.NullDecimal, error) {
+	variants, err := r.productVariantRepository.FindCostPricesByID(
+		ctx, tx, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	costPrices := make(map[string]decimal.NullDecimal, len(variants))
+	for _, variant := range variants {
+		costPrices[variant.ID] = variant.CostPrice
+	}
+	for _, variantID := range variantIDs {
+		if _, ok := costPrices[variantID]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrVariantNotFound, variantID)
+		}
+	}
+	return costPrices, nil
+}
+
+// RecomputeDescendants walks down the repack chain starting from the given
+// variants and stores the derived cost price of every descendant, level by
+// level, so a grandchild always reads the already updated child cost.
+func (r *RepackCost) RecomputeDescendants(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+	userID string,
+) error {
+	change := &repository.PriceChange{
+		Source: repository.VariantPriceChangeSourceRepackCost,
+	}
+	parentIDs := variantIDs
+	for depth := 0; len(parentIDs) > 0; depth++ {
+		if depth >= MaxRepackChainDepth {
+			return ErrRepackChainTooDeep
+		}
+		recipes, err := r.repackRecipeRepository.FindActiveByParentVariantIDs(
+			ctx, tx, parentIDs)
+		if err != nil {
+			return err
+		}
+		if len(recipes) == 0 {
+			return nil
+		}
+		costPrices, err := r.FindCostPrices(ctx, tx, parentIDs)
+		if err != nil {
+			return err
+		}
+		childIDs := make([]string, 0, len(recipes))
+		for _, recipe := range recipes {
+			costPrice := DeriveRepackCostPrice(
+				costPrices[recipe.ParentVariantID],
+				recipe.QuantityRatio,
+				recipe.RepackCostPerUnit,
+			)
+			if err := r.productVariantRepository.UpdateCostPriceTransaction(
+				ctx, tx, recipe.ChildVariantID, costPrice, userID, change,
+			); err != nil {
+				return err
+			}
+			childIDs = append(childIDs, recipe.ChildVariantID)
+		}
+		parentIDs = childIDs
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package costing
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDeriveRepackCostPrice(t *testing.T) {
+	tests := []struct {
+		name              string
+		parentCostPrice   decimal.NullDecimal
+		quantityRatio     float32
+		repackCostPerUnit string
+		want              string
+		wantValid         bool
+	}{
+		{
+			name:              "parent cost spread over the ratio plus repack cost",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(10000)),
+			quantityRatio:     10,
+			repackCostPerUnit: "50",
+			want:              "1050",
+			wantValid:         true,
+		},
+		{
+			name:              "result is rounded to two decimals",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(1000)),
+			quantityRatio:     3,
+			repackCostPerUnit: "0",
+			want:              "333.33",
+			wantValid:         true,
+		},
+		{
+			name:              "repeating fraction is rounded to the nearest cent",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(2)),
+			quantityRatio:     3,
+			repackCostPerUnit: "0.1",
+			want:              "0.77",
+			wantValid:         true,
+		},
+		{
+			name:              "fractional ratio",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(1000)),
+			quantityRatio:     2.5,
+			repackCostPerUnit: "25.5",
+			want:              "425.5",
+			wantValid:         true,
+		},
+		{
+			name:              "ratio below one makes the child dearer",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(100)),
+			quantityRatio:     0.5,
+			repackCostPerUnit: "0",
+			want:              "200",
+			wantValid:         true,
+		},
+		{
+			name:              "parent without cost price",
+			parentCostPrice:   decimal.NullDecimal{},
+			quantityRatio:     10,
+			repackCostPerUnit: "50",
+			wantValid:         false,
+		},
+		{
+			name:              "zero ratio",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(1000)),
+			quantityRatio:     0,
+			repackCostPerUnit: "50",
+			wantValid:         false,
+		},
+		{
+			name:              "negative ratio",
+			parentCostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(1000)),
+			quantityRatio:     -2,
+			repackCostPerUnit: "50",
+			wantValid:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DeriveRepackCostPrice(
+				tt.parentCostPrice,
+				tt.quantityRatio,
+				decimal.RequireFromString(tt.repackCostPerUnit),
+			)
+			if got.Valid != tt.wantValid {
+				t.Fatalf("valid = %v, want %v", got.Valid, tt.wantValid)
+			}
+			if !tt.wantValid {
+				return
+			}
+			if want := decimal.RequireFromString(tt.want); !got.Decimal.Equal(want) {
+				t.Errorf("cost price = %s, want %s", got.Decimal, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// csvFlushRows is how many rows are buffered before they are sent
+const csvFlushRows = 500
+
+type csvWriter struct {
+	writer   *csv.Writer
+	buffered int
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) WriteRow(values []any) error {
+	record := make([]string, 0, len(values))
+	for _, value := range values {
+		record = append(record, formatCSVValue(value))
+	}
+	if err := w.writer.Write(record); err != nil {
+		return err
+	}
+	w.buffered++
+	if w.buffered >= csvFlushRows {
+		w.buffered = 0
+		w.writer.Flush()
+		return w.writer.Error()
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func formatCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeCSVFormula(v)
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return escapeCSVFormula(*v)
+	case decimal.Decimal:
+		return v.String()
+	case *decimal.Decimal:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case float64:
+		return decimal.NewFromFloat(v).String()
+	case float32:
+		return decimal.NewFromFloat32(v).String()
+	}
+	return fmt.Sprint(value)
+}
+
+// escapeCSVFormula prefixes text that a spreadsheet would run as a formula
+// with a quote, numbers are written as they are
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
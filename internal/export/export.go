@@ -0,0 +1,74 @@
+// Package export renders list results as CSV or XLSX files. Rows are
+// written as they are produced so large results never need to be held in
+// memory.
+package export
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+const (
+	contentTypeCSV  = "text/csv"
+	contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+var ErrUnsupportedFormat = errors.New("format must be one of json, csv, xlsx")
+
+// FormatFromRequest picks the format from the format query parameter, or
+// from the Accept header when the parameter is empty. Anything else is
+// rendered as JSON.
+func FormatFromRequest(formatParam, acceptHeader string) (Format, error) {
+	formatParam = strings.ToLower(strings.TrimSpace(formatParam))
+	if formatParam != "" {
+		switch Format(formatParam) {
+		case FormatJSON, FormatCSV, FormatXLSX:
+			return Format(formatParam), nil
+		}
+		return "", ErrUnsupportedFormat
+	}
+	for _, mediaType := range strings.Split(acceptHeader, ",") {
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case contentTypeCSV:
+			return FormatCSV, nil
+		case contentTypeXLSX:
+			return FormatXLSX, nil
+		}
+	}
+	return FormatJSON, nil
+}
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return contentTypeXLSX
+	}
+	return contentTypeCSV
+}
+
+// Writer writes one table, the first row written is the header
+type Writer interface {
+	WriteRow(values []any) error
+	// Close flushes the remaining rows, the writer can not be used afterwards
+	Close() error
+}
+
+// NewWriter creates a writer of the format, sheetName is only used by XLSX
+func NewWriter(format Format, w io.Writer, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheetName)
+	}
+	return nil, ErrUnsupportedFormat
+}
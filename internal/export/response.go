@@ -0,0 +1,91 @@
+package export
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+// RowFunc writes one data row to the export
+type RowFunc func(values []any) error
+
+// Respond streams a table as the response. produce is called with the
+// function that writes every row, the header is written first. As long as
+// nothing has been sent an error from produce is answered like any service
+// error, afterwards the response can only be cut short.
+func Respond(
+	c *gin.Context,
+	format Format,
+	name string,
+	header []string,
+	produce func(write RowFunc) error,
+) {
+	response := &responseWriter{
+		c:           c,
+		contentType: format.ContentType(),
+		filename: fmt.Sprintf(
+			"%s-%s.%s", name, time.Now().Format("20060102150405"), format),
+	}
+	writer, err := NewWriter(format, response, name)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	headerValues := make([]any, 0, len(header))
+	for _, column := range header {
+		headerValues = append(headerValues, column)
+	}
+	err = writer.WriteRow(headerValues)
+	if err == nil {
+		err = produce(writer.WriteRow)
+	}
+	if err != nil {
+		if !response.started {
+			util.HandleServiceError(c, err)
+			return
+		}
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	if err := writer.Close(); err != nil {
+		if !response.started {
+			util.HandleServiceError(c, err)
+			return
+		}
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	if !response.started {
+		// an empty CSV still has to be answered with its headers
+		response.writeHeader()
+	}
+}
+
+// responseWriter sends the file headers only once data is written, so a
+// failure before the first flush can still be answered as JSON
+type responseWriter struct {
+	c           *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.writeHeader()
+	}
+	return w.c.Writer.Write(p)
+}
+
+func (w *responseWriter) writeHeader() {
+	w.started = true
+	w.c.Header("Content-Type", w.contentType)
+	w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.filename))
+	w.c.Status(http.StatusOK)
+	w.c.Writer.WriteHeaderNow()
+}
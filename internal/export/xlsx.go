@@ -0,0 +1,77 @@
+package export
+
+import (
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxWriter uses the excelize stream writer, rows are kept in a temporary
+// file instead of memory until the workbook is written on Close
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if sheetName == "" {
+		sheetName = "Sheet1"
+	}
+	if err := file.SetSheetName("Sheet1", sheetName); err != nil {
+		file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{w: w, file: file, stream: stream}, nil
+}
+
+func (w *xlsxWriter) WriteRow(values []any) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	row := make([]any, 0, len(values))
+	for _, value := range values {
+		row = append(row, formatXLSXValue(value))
+	}
+	return w.stream.SetRow(cell, row)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.w)
+}
+
+// formatXLSXValue keeps numbers numeric so they can be summed in a sheet
+func formatXLSXValue(value any) any {
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case decimal.Decimal:
+		return v.InexactFloat64()
+	case *decimal.Decimal:
+		if v == nil {
+			return nil
+		}
+		return v.InexactFloat64()
+	}
+	return value
+}
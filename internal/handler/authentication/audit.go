@@ -0,0 +1,29 @@
+package authentication
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+// recordAuthEvent writes to the auth audit trail, errors are logged only
+func (h *AuthHandler) recordAuthEvent(
+	c *gin.Context,
+	eventType repository.AuthEventType,
+	username, sessionID, reason string,
+) {
+	err := h.authEventRepository.Insert(c, &repository.AuthEventData{
+		ID:        uuid.NewString(),
+		EventType: eventType,
+		Username:  toNullString(truncate(username, maxLengthAuthEventUsername)),
+		SessionID: toNullString(sessionID),
+		Reason:    toNullString(reason),
+		IPAddress: toNullString(c.ClientIP()),
+		UserAgent: toNullString(truncate(c.Request.UserAgent(), maxLengthUserAgent)),
+	})
+	if err != nil {
+		log.Printf("authentication: failed to record auth event: %v", err)
+	}
+}
@@ -19,6 +19,5 @@ type User struct {
 	Username     string     `json:"username"`
 	HashPassword string     `json:"-"`
 	Role         Role       `json:"role"`
-	Token        *string    `json:"-"`
 	LastLogin    *time.Time `json:"last_login,omitempty"`
 }
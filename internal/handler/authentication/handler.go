@@ -3,59 +3,111 @@ package authentication
 import (
 	"database/sql"
 	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkysr90/rizkiplastik-be/internal/config"
+	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthHandler handles HTTP requests for authentication
 type AuthHandler struct {
-	db  *pgxpool.Pool
-	cfg *config.Config
+	db                     *pgxpool.Pool
+	cfg                    *config.Config
+	userRepository         repository.User
+	sessionRepository      repository.Session
+	loginAttemptRepository repository.LoginAttempt
+	authEventRepository    repository.AuthEvent
 }
 
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(db *pgxpool.Pool, cfg *config.Config) *AuthHandler {
-	return &AuthHandler{db: db, cfg: cfg}
+func NewAuthHandler(
+	db *pgxpool.Pool,
+	cfg *config.Config,
+	userRepository repository.User,
+	sessionRepository repository.Session,
+	loginAttemptRepository repository.LoginAttempt,
+	authEventRepository repository.AuthEvent,
+) *AuthHandler {
+	return &AuthHandler{
+		db:                     db,
+		cfg:                    cfg,
+		userRepository:         userRepository,
+		sessionRepository:      sessionRepository,
+		loginAttemptRepository: loginAttemptRepository,
+		authEventRepository:    authEventRepository,
+	}
 }
 
 // RegisterRoutes registers all authentication-related routes
-func (h *AuthHandler) RegisterRoutes(router *gin.Engine) {
+func (h *AuthHandler) RegisterRoutes(
+	router *gin.Engine,
+	authMiddleware *middleware.AuthMiddleware) {
 	api := router.Group("/api/v1/auth")
 	{
 		api.POST("/login", h.Login)
+		api.POST("/refresh", h.Refresh)
+		api.POST("/logout", authMiddleware.RequireAuth(), h.Logout)
+		api.POST("/logout-all", authMiddleware.RequireAuth(), h.LogoutAll)
+		api.GET("/me", authMiddleware.RequireAuth(), h.Me)
 	}
 }
 
-// Login handles user authentication and token generation
+// Login handles user authentication and opens a new session for the device
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	// Step 1 & 2: Check existence of the username and get stored hash
+	// Step 1: Refuse while the username or the IP address is locked out
+	lockedUntil, err := h.loginAttemptRepository.FindActiveLockout(
+		c, attemptKey(req.Username), attemptKey(c.ClientIP()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate user"})
+		return
+	}
+	if lockedUntil.Valid {
+		h.recordAuthEvent(c, repository.AuthEventTypeLoginLocked, req.Username, "", "")
+		retryAfter := int(math.Ceil(time.Until(lockedUntil.Time).Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": "Too many failed login attempts, try again later",
+		})
+		return
+	}
+	// Step 2: Check existence of the username and get stored hash
 	var user User
 	var hashPassword string
 	var role string
 	query := `
 		SELECT username, hash_password, role
 		FROM users
-		WHERE username = $1
+		WHERE username = $1 AND is_active = true
 	`
 
-	err := h.db.QueryRow(c, query, req.Username).Scan(
+	err = h.db.QueryRow(c, query, req.Username).Scan(
 		&user.Username,
 		&hashPassword,
 		&role,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// disabled users are reported as not found as well
+			h.recordFailedLogin(c, req.Username)
+			h.recordAuthEvent(c, repository.AuthEventTypeLoginFailure,
+				req.Username, "", repository.AuthEventReasonUserNotFound)
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 			return
 		}
@@ -65,38 +117,217 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// Step 3: Compare password with stored hash
 	err = bcrypt.CompareHashAndPassword([]byte(hashPassword), []byte(req.Password))
 	if err != nil {
+		h.recordFailedLogin(c, req.Username)
+		h.recordAuthEvent(c, repository.AuthEventTypeLoginFailure,
+			req.Username, "", repository.AuthEventReasonInvalidCredentials)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
-	// Step 4: Create JWT token with 7-day expiration
-	token, err := h.generateJWTToken(user.Username, Role(role))
+	// Step 4: Open a session, every device keeps its own session so logging
+	// in on one device does not log out the others
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	now := time.Now().UTC()
+	session := &repository.SessionData{
+		ID:               uuid.NewString(),
+		Username:         user.Username,
+		RefreshTokenHash: hashRefreshToken(refreshToken),
+		DeviceName:       toNullString(req.DeviceName),
+		UserAgent:        toNullString(truncate(c.Request.UserAgent(), maxLengthUserAgent)),
+		IPAddress:        toNullString(c.ClientIP()),
+		ExpiresAt:        now.Add(h.cfg.RefreshTokenTTL),
+	}
+	if err := h.sessionRepository.Insert(c, session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
+		return
+	}
+	// Step 5: Create the short-lived access token bound to the session
+	token, err := h.generateJWTToken(user.Username, Role(role), session.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
-	// Update token in database for manual revocation capability
 	updateQuery := `
 		UPDATE users
-		SET token = $1, last_login = $2
-		WHERE username = $3
+		SET last_login = $1
+		WHERE username = $2
 	`
+	_, err = h.db.Exec(c, updateQuery, now, user.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update last login"})
+		return
+	}
+	// A successful login clears the username counter, the IP counter keeps
+	// counting so one valid account cannot unlock guessing on the others
+	if err := h.loginAttemptRepository.Reset(
+		c, repository.LoginAttemptScopeUsername, attemptKey(user.Username)); err != nil {
+		log.Printf("authentication: failed to reset failed logins: %v", err)
+	}
+	h.recordAuthEvent(c, repository.AuthEventTypeLoginSuccess,
+		user.Username, session.ID, "")
+	// Step 6: Return both tokens to the response body
+	c.JSON(http.StatusOK, h.newLoginResponse(token, refreshToken, session.ID))
+}
 
+// Refresh rotates the refresh token and issues a new access token. A refresh
+// token can be used once, presenting a rotated token again revokes the
+// session because the token has most likely been stolen.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tx, err := h.db.BeginTx(c, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	defer tx.Rollback(c)
+	refreshTokenHash := hashRefreshToken(req.RefreshToken)
+	session, err := h.sessionRepository.FindByRefreshTokenHashForUpdate(
+		c, tx, refreshTokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	if session.RevokedAt.Valid {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+		return
+	}
+	if session.RefreshTokenHash != refreshTokenHash {
+		if err := h.sessionRepository.RevokeTransaction(c, tx, session.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+			return
+		}
+		if err := tx.Commit(c); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+			return
+		}
+		h.recordAuthEvent(c, repository.AuthEventTypeTokenRevoked, session.Username,
+			session.ID, repository.AuthEventReasonRefreshTokenReuse)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has already been used, session revoked"})
+		return
+	}
 	now := time.Now().UTC()
-	_, err = h.db.Exec(c, updateQuery, token, now, user.Username)
+	if !session.ExpiresAt.After(now) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has expired"})
+		return
+	}
+	// The role is read again so a refreshed access token never carries a
+	// stale role claim
+	user, err := h.userRepository.FindByUsername(c, session.Username)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update token"})
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
 		return
 	}
-	// Step 5: Return token to the response body
-	response := LoginResponse{
-		Token: token,
+	if !user.IsActive {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User has been disabled"})
+		return
 	}
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	session.RefreshTokenHash = hashRefreshToken(refreshToken)
+	session.UserAgent = toNullString(truncate(c.Request.UserAgent(), maxLengthUserAgent))
+	session.IPAddress = toNullString(c.ClientIP())
+	session.ExpiresAt = now.Add(h.cfg.RefreshTokenTTL)
+	if err := h.sessionRepository.RotateTransaction(c, tx, session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate refresh token"})
+		return
+	}
+	token, err := h.generateJWTToken(user.Username, Role(user.Role), session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate refresh token"})
+		return
+	}
+	c.JSON(http.StatusOK, h.newLoginResponse(token, refreshToken, session.ID))
+}
 
+// Logout revokes the session of the access token used for the request
+func (h *AuthHandler) Logout(c *gin.Context) {
+	identity, err := util.GetIdentity(c)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	err = h.sessionRepository.Revoke(c, identity.SessionID, identity.Username)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+	h.recordAuthEvent(c, repository.AuthEventTypeTokenRevoked, identity.Username,
+		identity.SessionID, repository.AuthEventReasonLogout)
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// LogoutAll revokes every session of the caller on every device
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	identity, err := util.GetIdentity(c)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	revoked, err := h.sessionRepository.RevokeAllByUsername(c, identity.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions"})
+		return
+	}
+	h.recordAuthEvent(c, repository.AuthEventTypeTokenRevoked, identity.Username,
+		"", repository.AuthEventReasonLogoutAll)
+	c.JSON(http.StatusOK, LogoutAllResponse{
+		RevokedSessions: revoked,
+	})
+}
+
+// Me reports the caller's role and the permissions granted to it
+func (h *AuthHandler) Me(c *gin.Context) {
+	identity, err := util.GetIdentity(c)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	permissions := middleware.PermissionsForRole(identity.Role)
+	response := MeResponse{
+		Username:    identity.Username,
+		Role:        identity.Role,
+		Permissions: make([]string, 0, len(permissions)),
+	}
+	for _, permission := range permissions {
+		response.Permissions = append(response.Permissions, string(permission))
+	}
 	c.JSON(http.StatusOK, response)
 }
 
-// generateJWTToken creates a new JWT token with specified claims
-func (h *AuthHandler) generateJWTToken(username string, role Role) (string, error) {
+func (h *AuthHandler) newLoginResponse(token, refreshToken, sessionID string) LoginResponse {
+	return LoginResponse{
+		Token:        token,
+		TokenType:    tokenTypeBearer,
+		ExpiresIn:    int64(h.cfg.AccessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+		SessionID:    sessionID,
+	}
+}
+
+// generateJWTToken creates a new access token bound to a session
+func (h *AuthHandler) generateJWTToken(username string, role Role, sessionID string) (string, error) {
 	// Get secret key from environment variable or use default for development
 	secretKey := h.cfg.JWTSecret
 	if secretKey == "" {
@@ -104,10 +335,13 @@ func (h *AuthHandler) generateJWTToken(username string, role Role) (string, erro
 	}
 
 	// Create token with claims
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"username": username,
 		"role":     string(role),
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days expiration
+		"sid":      sessionID,
+		"iat":      now.Unix(),
+		"exp":      now.Add(h.cfg.AccessTokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -120,3 +354,7 @@ func (h *AuthHandler) generateJWTToken(username string, role Role) (string, erro
 
 	return tokenString, nil
 }
+
+func toNullString(str string) sql.NullString {
+	return sql.NullString{String: str, Valid: str != ""}
+}
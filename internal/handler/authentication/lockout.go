@@ -0,0 +1,76 @@
+package authentication
+
+import (
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+const (
+	// maxFailedLoginsPerUsername and maxFailedLoginsPerIP are the failures
+	// allowed before the username or the IP address is locked. The IP limit
+	// is higher because the shop devices share one address.
+	maxFailedLoginsPerUsername = 5
+	maxFailedLoginsPerIP       = 20
+	// the first lockout lasts baseLockoutDuration and doubles on every
+	// further failure up to maxLockoutDuration
+	baseLockoutDuration = time.Minute
+	maxLockoutDuration  = time.Hour
+	// failedLoginResetAfter restarts a counter that has been quiet this long
+	failedLoginResetAfter = 2 * time.Hour
+)
+
+// lockoutDuration returns how long to lock after failedCount failures, zero
+// means the limit has not been reached yet
+func lockoutDuration(failedCount, maxFailed int) time.Duration {
+	if failedCount < maxFailed {
+		return 0
+	}
+	duration := baseLockoutDuration
+	for i := maxFailed; i < failedCount; i++ {
+		duration *= 2
+		if duration >= maxLockoutDuration {
+			return maxLockoutDuration
+		}
+	}
+	return duration
+}
+
+// attemptKey fits a username or an IP address into the attempt_key column,
+// the same value must be used to record, check and reset a counter
+func attemptKey(key string) string {
+	return truncate(key, maxLengthAttemptKey)
+}
+
+// recordFailedLogin increments the username and IP counters and locks the
+// ones that reached their limit. Errors are logged only, a broken counter
+// must not turn a wrong password into a server error.
+func (h *AuthHandler) recordFailedLogin(c *gin.Context, username string) {
+	counters := []struct {
+		scope     repository.LoginAttemptScope
+		key       string
+		maxFailed int
+	}{
+		{repository.LoginAttemptScopeUsername, attemptKey(username), maxFailedLoginsPerUsername},
+		{repository.LoginAttemptScopeIP, attemptKey(c.ClientIP()), maxFailedLoginsPerIP},
+	}
+	for _, counter := range counters {
+		failedCount, err := h.loginAttemptRepository.RecordFailure(
+			c, counter.scope, counter.key, failedLoginResetAfter)
+		if err != nil {
+			log.Printf("authentication: failed to record failed login: %v", err)
+			continue
+		}
+		duration := lockoutDuration(failedCount, counter.maxFailed)
+		if duration == 0 {
+			continue
+		}
+		lockedUntil := time.Now().Add(duration)
+		if err := h.loginAttemptRepository.Lock(
+			c, counter.scope, counter.key, lockedUntil); err != nil {
+			log.Printf("authentication: failed to lock login: %v", err)
+		}
+	}
+}
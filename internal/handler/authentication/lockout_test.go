@@ -0,0 +1,56 @@
+package authentication
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		failedCount int
+		maxFailed   int
+		want        time.Duration
+	}{
+		{"below the limit", 4, 5, 0},
+		{"no failure yet", 0, 5, 0},
+		{"limit reached", 5, 5, time.Minute},
+		{"doubles on the next failure", 6, 5, 2 * time.Minute},
+		{"keeps doubling", 9, 5, 16 * time.Minute},
+		{"last step below the cap", 10, 5, 32 * time.Minute},
+		{"capped at one hour", 11, 5, time.Hour},
+		{"stays capped", 500, 5, time.Hour},
+		{"ip limit reached", 20, 20, time.Minute},
+		{"ip limit below", 19, 20, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lockoutDuration(tt.failedCount, tt.maxFailed)
+			if got != tt.want {
+				t.Errorf("lockoutDuration(%d, %d) = %s, want %s",
+					tt.failedCount, tt.maxFailed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttemptKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"short key is kept", "cashier", "cashier"},
+		{"ip address is kept", "2001:db8::1", "2001:db8::1"},
+		{"long key is truncated", strings.Repeat("a", 100), strings.Repeat("a", 64)},
+		{"truncated by rune", strings.Repeat("é", 70), strings.Repeat("é", 64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := attemptKey(tt.key); got != tt.want {
+				t.Errorf("attemptKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,13 +2,32 @@ package authentication
 
 // LoginRequest represents data needed for login
 type LoginRequest struct {
+	// Username is capped at the users.username column size, the lockout
+	// counter and the audit trail still truncate what they store
 	Username string `json:"username" binding:"required,max=30"`
 	Password string `json:"password" binding:"required"`
+	// DeviceName labels the session, e.g. "cashier tablet"
+	DeviceName string `json:"device_name" binding:"max=100"`
 }
 
-// LoginResponse represents the response for login endpoint
+// LoginResponse represents the response for login and refresh endpoint
 type LoginResponse struct {
-	Token string `json:"token"`
+	// Token is the short-lived access token sent as Bearer token
+	Token        string `json:"token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	SessionID    string `json:"session_id"`
+}
+
+// RefreshRequest represents data needed to rotate a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutAllResponse represents the response for logout-all endpoint
+type LogoutAllResponse struct {
+	RevokedSessions int64 `json:"revoked_sessions"`
 }
 
 // UserClaims represents the JWT claims for user authentication
@@ -16,3 +35,10 @@ type UserClaims struct {
 	Username string `json:"username"`
 	Role     string `json:"role"`
 }
+
+// MeResponse represents the response for me endpoint
+type MeResponse struct {
+	Username    string   `json:"username"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
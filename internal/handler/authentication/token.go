@@ -0,0 +1,44 @@
+package authentication
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	tokenTypeBearer = "Bearer"
+	// refreshTokenBytes is the entropy of a refresh token before encoding
+	refreshTokenBytes  = 32
+	maxLengthUserAgent = 255
+	// maxLengthAuthEventUsername is the size of auth_events.username
+	maxLengthAuthEventUsername = 30
+	// maxLengthAttemptKey is the size of login_attempts.attempt_key
+	maxLengthAttemptKey = 64
+)
+
+// generateRefreshToken returns an opaque random refresh token
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashRefreshToken returns the digest stored in user_sessions, the refresh
+// token itself is never persisted
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// truncate cuts str to maxLength characters so it fits a VARCHAR column
+func truncate(str string, maxLength int) string {
+	runes := []rune(str)
+	if len(runes) > maxLength {
+		return string(runes[:maxLength])
+	}
+	return str
+}
@@ -0,0 +1,10 @@
+package authevents
+
+const (
+	fieldValidationFieldUsername  = "username"
+	fieldValidationFieldEventType = "event_type"
+	fieldValidationFieldStartDate = "start_date"
+	fieldValidationFieldEndDate   = "end_date"
+
+	dateLayout = "2006-01-02"
+)
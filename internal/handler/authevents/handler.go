@@ -0,0 +1,47 @@
+package authevents
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service AuthEventService
+}
+
+func NewHandler(service AuthEventService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/auth-events")
+	endpoint.GET("/", h.GetAuthEvents)
+}
+
+func (h *Handler) GetAuthEvents(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetAuthEventsRequest{
+		PaginationData: *pagination,
+		Username:       c.Query("username"),
+		EventType:      c.Query("event_type"),
+		IPAddress:      c.Query("ip_address"),
+		StartDate:      c.Query("start_date"),
+		EndDate:        c.Query("end_date"),
+	}
+	response, err := h.service.GetAuthEvents(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
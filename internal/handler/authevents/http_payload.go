@@ -0,0 +1,17 @@
+package authevents
+
+import "github.com/rizkysr90/rizkiplastik-be/internal/util"
+
+type GetAuthEventsRequest struct {
+	util.PaginationData `json:"pagination"`
+	Username            string `json:"username"`
+	EventType           string `json:"event_type"`
+	IPAddress           string `json:"ip_address"`
+	StartDate           string `json:"start_date"`
+	EndDate             string `json:"end_date"`
+}
+
+type GetAuthEventsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []AuthEventObject `json:"data"`
+}
@@ -0,0 +1,15 @@
+package authevents
+
+import "time"
+
+type AuthEventObject struct {
+	EventID   string    `json:"event_id"`
+	EventType string    `json:"event_type"`
+	Username  *string   `json:"username"`
+	Actor     *string   `json:"actor"`
+	SessionID *string   `json:"session_id"`
+	Reason    *string   `json:"reason"`
+	IPAddress *string   `json:"ip_address"`
+	UserAgent *string   `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package authevents
+
+import (
+	"context"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type AuthEventService interface {
+	GetAuthEvents(ctx context.Context, request *GetAuthEventsRequest) (*GetAuthEventsResponse, error)
+}
+
+type Service struct {
+	authEventRepository repository.AuthEvent
+}
+
+func NewService(authEventRepository repository.AuthEvent) AuthEventService {
+	return &Service{
+		authEventRepository: authEventRepository,
+	}
+}
@@ -0,0 +1,143 @@
+package authevents
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetAuthEvents struct {
+	*GetAuthEventsRequest
+}
+
+func (req *requestGetAuthEvents) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+	req.EventType = strings.TrimSpace(strings.ToUpper(req.EventType))
+	req.IPAddress = strings.TrimSpace(req.IPAddress)
+	req.StartDate = strings.TrimSpace(req.StartDate)
+	req.EndDate = strings.TrimSpace(req.EndDate)
+}
+
+func (req *requestGetAuthEvents) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateMaxLengthStr(req.Username, 30); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldUsername,
+			Message: err.Error(),
+		})
+	}
+	if req.EventType != "" {
+		if err := common.ValidateOneOf(req.EventType, []string{
+			string(repository.AuthEventTypeLoginSuccess),
+			string(repository.AuthEventTypeLoginFailure),
+			string(repository.AuthEventTypeLoginLocked),
+			string(repository.AuthEventTypeTokenRevoked),
+			string(repository.AuthEventTypePasswordChanged),
+		}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEventType,
+				Message: err.Error(),
+			})
+		}
+	}
+	var startDate, endDate time.Time
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStartDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEndDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEndDate,
+			Message: "end_date must not be before start_date",
+		})
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetAuthEvents(
+	ctx context.Context,
+	request *GetAuthEventsRequest,
+) (*GetAuthEventsResponse, error) {
+	input := &requestGetAuthEvents{
+		GetAuthEventsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	events, totalCount, err := s.authEventRepository.FindPaginated(ctx,
+		&repository.AuthEventFilter{
+			Username:  input.Username,
+			EventType: input.EventType,
+			IPAddress: input.IPAddress,
+			StartDate: input.StartDate,
+			EndDate:   input.EndDate,
+			Limit:     input.PageSize,
+			Offset:    input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]AuthEventObject, 0, len(events))
+	for i := range events {
+		data = append(data, toAuthEventObject(&events[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetAuthEventsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
+
+func toAuthEventObject(event *repository.AuthEventData) AuthEventObject {
+	object := AuthEventObject{
+		EventID:   event.ID,
+		EventType: string(event.EventType),
+		CreatedAt: event.CreatedAt,
+	}
+	if event.Username.Valid {
+		object.Username = &event.Username.String
+	}
+	if event.Actor.Valid {
+		object.Actor = &event.Actor.String
+	}
+	if event.SessionID.Valid {
+		object.SessionID = &event.SessionID.String
+	}
+	if event.Reason.Valid {
+		object.Reason = &event.Reason.String
+	}
+	if event.IPAddress.Valid {
+		object.IPAddress = &event.IPAddress.String
+	}
+	if event.UserAgent.Valid {
+		object.UserAgent = &event.UserAgent.String
+	}
+	return object
+}
@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 )
 
@@ -19,9 +18,7 @@ func NewCategoryHandler(categoryService Service) *Handler {
 }
 
 // RegisterRoutes registers all category related routes
-func (h *Handler) RegisterRoutes(
-	router *gin.Engine,
-	authMiddleware *middleware.AuthMiddleware) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 
 	endpoint := router.Group("/api/v1/categories")
 	{
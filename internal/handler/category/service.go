@@ -10,6 +10,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -55,6 +56,11 @@ func (req *reqCreateCategory) validate(ctx context.Context) error {
 
 func (s *Service) CreateCategory(ctx context.Context,
 	data *CreateCategoryRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := reqCreateCategory{
 		CreateCategoryRequest: data,
 	}
@@ -62,12 +68,13 @@ func (s *Service) CreateCategory(ctx context.Context,
 	if err := input.validate(ctx); err != nil {
 		return err
 	}
-	// since we dont use middleware, we need to set the created by and updated by to system
 	insertedData := &repository.CategoryData{
 		ID:        uuid.NewString(),
 		Name:      input.Name,
 		Code:      input.Code,
 		IsActive:  true,
+		CreatedBy: userID,
+		UpdatedBy: userID,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
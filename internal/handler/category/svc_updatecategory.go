@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -44,6 +45,11 @@ func (req *reqUpdateCategory) validate(ctx context.Context) error {
 	return nil
 }
 func (s *Service) UpdateCategory(ctx context.Context, data *UpdateCategoryRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqUpdateCategory{
 		UpdateCategoryRequest: data,
 	}
@@ -55,7 +61,7 @@ func (s *Service) UpdateCategory(ctx context.Context, data *UpdateCategoryReques
 		ID:        input.CategoryID,
 		Name:      input.CategoryName,
 		IsActive:  input.IsActive,
-		UpdatedBy: "SYSTEM",
+		UpdatedBy: userID,
 	}
 	if input.CategoryDescription != nil {
 		updatedCategory.Description = *input.CategoryDescription
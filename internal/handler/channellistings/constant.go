@@ -0,0 +1,19 @@
+package channellistings
+
+const (
+	fieldValidationFieldListingID     = "listing_id"
+	fieldValidationFieldVariantID     = "variant_id"
+	fieldValidationFieldChannel       = "channel"
+	fieldValidationFieldListingName   = "listing_name"
+	fieldValidationFieldVariationName = "variation_name"
+	fieldValidationFieldChannelSKU    = "channel_sku"
+	fieldValidationFieldListedPrice   = "listed_price"
+	fieldValidationFieldFeeCategory   = "fee_category"
+	fieldValidationFieldIsActive      = "is_active"
+	fieldValidationFieldStatus        = "status"
+
+	maxLengthListingName   = 250
+	maxLengthVariationName = 100
+	maxLengthChannelSKU    = 100
+	maxLengthFeeCategory   = 30
+)
@@ -0,0 +1,103 @@
+package channellistings
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service ChannelListingService
+}
+
+func NewHandler(service ChannelListingService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/channel-listings")
+	endpoint.POST("/", h.CreateChannelListing)
+	endpoint.GET("/", h.GetChannelListings)
+	endpoint.GET("/:listing_id", h.GetChannelListing)
+	endpoint.PUT("/:listing_id", h.UpdateChannelListing)
+	endpoint.DELETE("/:listing_id", h.DeleteChannelListing)
+}
+
+func (h *Handler) CreateChannelListing(c *gin.Context) {
+	request := &CreateChannelListingRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateChannelListing(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) UpdateChannelListing(c *gin.Context) {
+	request := &UpdateChannelListingRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.ListingID = c.Param("listing_id")
+	if err := h.service.UpdateChannelListing(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetChannelListings filters by variant_id, channel, status and search,
+// search matches the listing name, variation name or channel sku
+func (h *Handler) GetChannelListings(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetChannelListingsRequest{
+		PaginationData: *pagination,
+		VariantID:      c.Query("variant_id"),
+		Channel:        c.Query("channel"),
+		Search:         c.Query("search"),
+		Status:         c.Query("status"),
+	}
+	response, err := h.service.GetChannelListings(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetChannelListing(c *gin.Context) {
+	request := &GetChannelListingRequest{
+		ListingID: c.Param("listing_id"),
+	}
+	response, err := h.service.GetChannelListing(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) DeleteChannelListing(c *gin.Context) {
+	request := &DeleteChannelListingRequest{
+		ListingID: c.Param("listing_id"),
+	}
+	if err := h.service.DeleteChannelListing(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,58 @@
+package channellistings
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type ChannelListingPayload struct {
+	VariantID string `json:"variant_id"`
+	Channel   string `json:"channel"`
+	// ListingName and VariationName are the names shown on marketplace
+	// orders, they are used to match imported order lines to the variant
+	ListingName   string           `json:"listing_name"`
+	VariationName *string          `json:"variation_name"`
+	ChannelSKU    *string          `json:"channel_sku"`
+	ListedPrice   *decimal.Decimal `json:"listed_price"`
+	// FeeCategory selects the admin fee rate of the channel fee schedule
+	FeeCategory *string `json:"fee_category"`
+}
+
+type CreateChannelListingRequest struct {
+	ChannelListingPayload
+}
+
+type CreateChannelListingResponse struct {
+	ListingID string `json:"listing_id"`
+}
+
+type UpdateChannelListingRequest struct {
+	ListingID string `json:"listing_id"`
+	ChannelListingPayload
+	IsActive *bool `json:"is_active"`
+}
+
+type GetChannelListingRequest struct {
+	ListingID string `json:"listing_id"`
+}
+
+type GetChannelListingResponse struct {
+	Data ChannelListingObject `json:"data"`
+}
+
+type GetChannelListingsRequest struct {
+	util.PaginationData `json:"pagination"`
+	VariantID           string `json:"variant_id"`
+	Channel             string `json:"channel"`
+	Search              string `json:"search"`
+	Status              string `json:"status"`
+}
+
+type GetChannelListingsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []ChannelListingObject `json:"data"`
+}
+
+type DeleteChannelListingRequest struct {
+	ListingID string `json:"listing_id"`
+}
@@ -0,0 +1,25 @@
+package channellistings
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ChannelListingObject struct {
+	ListingID       string           `json:"listing_id"`
+	VariantID       string           `json:"variant_id"`
+	VariantFullName string           `json:"variant_full_name"`
+	VariantSKU      *string          `json:"variant_sku"`
+	Channel         string           `json:"channel"`
+	ListingName     string           `json:"listing_name"`
+	VariationName   *string          `json:"variation_name"`
+	ChannelSKU      *string          `json:"channel_sku"`
+	ListedPrice     *decimal.Decimal `json:"listed_price"`
+	FeeCategory     *string          `json:"fee_category"`
+	IsActive        bool             `json:"is_active"`
+	CreatedBy       string           `json:"created_by"`
+	UpdatedBy       string           `json:"updated_by"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package channellistings
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type ChannelListingService interface {
+	CreateChannelListing(
+		ctx context.Context,
+		request *CreateChannelListingRequest,
+	) (*CreateChannelListingResponse, error)
+	UpdateChannelListing(ctx context.Context, request *UpdateChannelListingRequest) error
+	GetChannelListing(
+		ctx context.Context,
+		request *GetChannelListingRequest,
+	) (*GetChannelListingResponse, error)
+	GetChannelListings(
+		ctx context.Context,
+		request *GetChannelListingsRequest,
+	) (*GetChannelListingsResponse, error)
+	DeleteChannelListing(ctx context.Context, request *DeleteChannelListingRequest) error
+}
+
+type Service struct {
+	db                              *pgxpool.Pool
+	variantChannelListingRepository repository.VariantChannelListing
+	productVariantRepository        repository.ProductVariant
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	variantChannelListingRepository repository.VariantChannelListing,
+	productVariantRepository repository.ProductVariant,
+) ChannelListingService {
+	return &Service{
+		db:                              db,
+		variantChannelListingRepository: variantChannelListingRepository,
+		productVariantRepository:        productVariantRepository,
+	}
+}
@@ -0,0 +1,72 @@
+package channellistings
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateChannelListing struct {
+	*CreateChannelListingRequest
+}
+
+func (req *requestCreateChannelListing) sanitize() {
+	req.ChannelListingPayload.sanitize()
+}
+
+func (req *requestCreateChannelListing) validateField() []httperror.FieldValidation {
+	return req.ChannelListingPayload.validateField()
+}
+
+func (s *Service) CreateChannelListing(
+	ctx context.Context,
+	request *CreateChannelListingRequest,
+) (*CreateChannelListingResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateChannelListing{
+		CreateChannelListingRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	if err := s.checkVariant(ctx, tx, input.VariantID); err != nil {
+		// error is already handled by checkVariant
+		return nil, err
+	}
+	listing := &repository.VariantChannelListingData{
+		ID:            uuid.NewString(),
+		VariantID:     input.VariantID,
+		Channel:       repository.OnlineChannel(input.Channel),
+		ListingName:   input.ListingName,
+		VariationName: toNullString(input.VariationName),
+		ChannelSKU:    toNullString(input.ChannelSKU),
+		ListedPrice:   toNullDecimal(input.ListedPrice),
+		FeeCategory:   toNullString(input.FeeCategory),
+		IsActive:      true,
+		CreatedBy:     userID,
+		UpdatedBy:     userID,
+	}
+	if err := s.variantChannelListingRepository.InsertTransaction(
+		ctx, tx, listing); err != nil {
+		return nil, handleSaveError(ctx, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateChannelListingResponse{ListingID: listing.ID}, nil
+}
@@ -0,0 +1,64 @@
+package channellistings
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteChannelListing struct {
+	*DeleteChannelListingRequest
+}
+
+func (req *requestDeleteChannelListing) sanitize() {
+	req.ListingID = strings.TrimSpace(req.ListingID)
+}
+
+func (req *requestDeleteChannelListing) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ListingID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldListingID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// DeleteChannelListing soft deletes the listing, its names become free to be
+// listed again
+func (s *Service) DeleteChannelListing(
+	ctx context.Context,
+	request *DeleteChannelListingRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteChannelListing{
+		DeleteChannelListingRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	err = s.variantChannelListingRepository.SoftDelete(ctx, input.ListingID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"channel listing not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
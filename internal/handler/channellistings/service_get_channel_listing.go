@@ -0,0 +1,58 @@
+package channellistings
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetChannelListing struct {
+	*GetChannelListingRequest
+}
+
+func (req *requestGetChannelListing) sanitize() {
+	req.ListingID = strings.TrimSpace(req.ListingID)
+}
+
+func (req *requestGetChannelListing) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ListingID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldListingID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetChannelListing(
+	ctx context.Context,
+	request *GetChannelListingRequest,
+) (*GetChannelListingResponse, error) {
+	input := &requestGetChannelListing{
+		GetChannelListingRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	listing, err := s.variantChannelListingRepository.FindByID(ctx, input.ListingID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"channel listing not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetChannelListingResponse{
+		Data: toChannelListingObject(listing),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package channellistings
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetChannelListings struct {
+	*GetChannelListingsRequest
+}
+
+func (req *requestGetChannelListings) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.Channel = strings.TrimSpace(strings.ToUpper(req.Channel))
+	req.Search = strings.TrimSpace(req.Search)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+}
+
+func (req *requestGetChannelListings) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.VariantID != "" {
+		if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldVariantID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.Channel != "" {
+		if err := common.ValidateOneOf(req.Channel, allowedChannels); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldChannel,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, []string{"TRUE", "FALSE"}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetChannelListings(
+	ctx context.Context,
+	request *GetChannelListingsRequest,
+) (*GetChannelListingsResponse, error) {
+	input := &requestGetChannelListings{
+		GetChannelListingsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	listings, totalCount, err := s.variantChannelListingRepository.FindPaginated(ctx,
+		&repository.VariantChannelListingFilter{
+			VariantID: input.VariantID,
+			Channel:   input.Channel,
+			Search:    input.Search,
+			IsActive:  input.Status,
+			Limit:     input.PageSize,
+			Offset:    input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]ChannelListingObject, 0, len(listings))
+	for i := range listings {
+		data = append(data, toChannelListingObject(&listings[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetChannelListingsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
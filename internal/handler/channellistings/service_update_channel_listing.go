@@ -0,0 +1,83 @@
+package channellistings
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateChannelListing struct {
+	*UpdateChannelListingRequest
+}
+
+func (req *requestUpdateChannelListing) sanitize() {
+	req.ListingID = strings.TrimSpace(req.ListingID)
+	req.ChannelListingPayload.sanitize()
+}
+
+func (req *requestUpdateChannelListing) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ListingID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldListingID,
+			Message: err.Error(),
+		})
+	}
+	if req.IsActive == nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldIsActive,
+			Message: "is_active is required",
+		})
+	}
+	return append(fieldValidation, req.ChannelListingPayload.validateField()...)
+}
+
+// UpdateChannelListing replaces every field of the listing
+func (s *Service) UpdateChannelListing(
+	ctx context.Context,
+	request *UpdateChannelListingRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateChannelListing{
+		UpdateChannelListingRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := s.checkVariant(ctx, tx, input.VariantID); err != nil {
+		// error is already handled by checkVariant
+		return err
+	}
+	if err := s.variantChannelListingRepository.UpdateTransaction(ctx, tx,
+		&repository.VariantChannelListingData{
+			ID:            input.ListingID,
+			VariantID:     input.VariantID,
+			Channel:       repository.OnlineChannel(input.Channel),
+			ListingName:   input.ListingName,
+			VariationName: toNullString(input.VariationName),
+			ChannelSKU:    toNullString(input.ChannelSKU),
+			ListedPrice:   toNullDecimal(input.ListedPrice),
+			FeeCategory:   toNullString(input.FeeCategory),
+			IsActive:      *input.IsActive,
+			UpdatedBy:     userID,
+		}); err != nil {
+		return handleSaveError(ctx, err)
+	}
+	return tx.Commit(ctx)
+}
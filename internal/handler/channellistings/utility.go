@@ -0,0 +1,189 @@
+package channellistings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+var allowedChannels = []string{
+	string(repository.OnlineChannelShopee),
+	string(repository.OnlineChannelLazada),
+	string(repository.OnlineChannelTokopedia),
+	string(repository.OnlineChannelTiktok),
+}
+
+// sanitize trims the payload, optional names that are empty after trimming
+// are treated as not set
+func (payload *ChannelListingPayload) sanitize() {
+	payload.VariantID = strings.TrimSpace(payload.VariantID)
+	payload.Channel = strings.TrimSpace(strings.ToUpper(payload.Channel))
+	payload.ListingName = strings.TrimSpace(payload.ListingName)
+	payload.VariationName = trimOptional(payload.VariationName)
+	payload.ChannelSKU = trimOptional(payload.ChannelSKU)
+	payload.FeeCategory = trimOptional(payload.FeeCategory)
+	if payload.FeeCategory != nil {
+		*payload.FeeCategory = strings.ToUpper(*payload.FeeCategory)
+	}
+}
+
+func (payload *ChannelListingPayload) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(payload.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateOneOf(payload.Channel, allowedChannels); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldChannel,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateStringRequired(
+		payload.ListingName, fieldValidationFieldListingName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldListingName,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateMaxLengthStr(
+		payload.ListingName, maxLengthListingName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldListingName,
+			Message: err.Error(),
+		})
+	}
+	if payload.VariationName != nil {
+		if err := common.ValidateMaxLengthStr(
+			*payload.VariationName, maxLengthVariationName); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldVariationName,
+				Message: err.Error(),
+			})
+		}
+	}
+	if payload.ChannelSKU != nil {
+		if err := common.ValidateMaxLengthStr(
+			*payload.ChannelSKU, maxLengthChannelSKU); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldChannelSKU,
+				Message: err.Error(),
+			})
+		}
+	}
+	if payload.FeeCategory != nil {
+		if err := common.ValidateMaxLengthStr(
+			*payload.FeeCategory, maxLengthFeeCategory); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldFeeCategory,
+				Message: err.Error(),
+			})
+		}
+	}
+	if payload.ListedPrice != nil && !payload.ListedPrice.IsPositive() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldListedPrice,
+			Message: "listed_price must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func toNullDecimal(value *decimal.Decimal) decimal.NullDecimal {
+	if value == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: value.Round(2), Valid: true}
+}
+
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+// checkVariant makes sure the listing points to an active variant
+func (s *Service) checkVariant(ctx context.Context, tx pgx.Tx, variantID string) error {
+	variants, err := s.productVariantRepository.FindManyByID(ctx, tx, []string{variantID})
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(variants) == 0 {
+		return httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+			Field:   fieldValidationFieldVariantID,
+			Message: "variant not found",
+		}})
+	}
+	return nil
+}
+
+// handleSaveError maps repository errors of insert and update
+func handleSaveError(ctx context.Context, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"channel listing not found",
+		))
+	}
+	if errors.Is(err, pg.ErrChannelListingAlreadyExists) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
+
+func toChannelListingObject(
+	listing *repository.VariantChannelListingData,
+) ChannelListingObject {
+	object := ChannelListingObject{
+		ListingID:       listing.ID,
+		VariantID:       listing.VariantID,
+		VariantFullName: listing.VariantFullName,
+		VariantSKU:      fromNullString(listing.VariantSKU),
+		Channel:         string(listing.Channel),
+		ListingName:     listing.ListingName,
+		VariationName:   fromNullString(listing.VariationName),
+		ChannelSKU:      fromNullString(listing.ChannelSKU),
+		FeeCategory:     fromNullString(listing.FeeCategory),
+		IsActive:        listing.IsActive,
+		CreatedBy:       listing.CreatedBy,
+		UpdatedBy:       listing.UpdatedBy,
+		CreatedAt:       listing.CreatedAt,
+		UpdatedAt:       listing.UpdatedAt,
+	}
+	if listing.ListedPrice.Valid {
+		object.ListedPrice = &listing.ListedPrice.Decimal
+	}
+	return object
+}
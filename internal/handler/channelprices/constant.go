@@ -0,0 +1,15 @@
+package channelprices
+
+const (
+	fieldValidationFieldVariantID    = "variant_id"
+	fieldValidationFieldVariantIDs   = "variant_ids"
+	fieldValidationFieldTargetMargin = "target_margin"
+	fieldValidationFieldChannel      = "channel"
+	fieldValidationFieldDate         = "date"
+
+	// maxTargetMargin is the highest target margin in percent of the cost
+	maxTargetMargin = 1000
+	maxBulkVariants = 100
+
+	dateLayout = "2006-01-02"
+)
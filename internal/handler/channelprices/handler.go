@@ -0,0 +1,62 @@
+package channelprices
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service ChannelPriceService
+}
+
+func NewHandler(service ChannelPriceService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/variants")
+	endpoint.GET("/channel-prices", h.GetBulkChannelPrices)
+	endpoint.GET("/:variant_id/channel-prices", h.GetVariantChannelPrices)
+}
+
+// GetVariantChannelPrices takes target_margin and the optional channel and
+// date as query parameters
+func (h *Handler) GetVariantChannelPrices(c *gin.Context) {
+	request := &GetVariantChannelPricesRequest{
+		VariantID:         c.Param("variant_id"),
+		ChannelPriceQuery: channelPriceQuery(c),
+	}
+	response, err := h.service.GetVariantChannelPrices(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBulkChannelPrices takes comma separated variant_ids on top of the
+// query parameters of GetVariantChannelPrices
+func (h *Handler) GetBulkChannelPrices(c *gin.Context) {
+	request := &GetBulkChannelPricesRequest{
+		VariantIDs:        c.Query("variant_ids"),
+		ChannelPriceQuery: channelPriceQuery(c),
+	}
+	response, err := h.service.GetBulkChannelPrices(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func channelPriceQuery(c *gin.Context) ChannelPriceQuery {
+	return ChannelPriceQuery{
+		TargetMargin: c.Query("target_margin"),
+		Channel:      c.Query("channel"),
+		Date:         c.Query("date"),
+	}
+}
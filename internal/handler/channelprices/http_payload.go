@@ -0,0 +1,30 @@
+package channelprices
+
+type GetVariantChannelPricesRequest struct {
+	VariantID string `json:"variant_id"`
+	ChannelPriceQuery
+}
+
+type GetVariantChannelPricesResponse struct {
+	Data VariantChannelPricesObject `json:"data"`
+}
+
+type GetBulkChannelPricesRequest struct {
+	// VariantIDs is a comma separated list of variant ids
+	VariantIDs string `json:"variant_ids"`
+	ChannelPriceQuery
+}
+
+type GetBulkChannelPricesResponse struct {
+	Data []VariantChannelPricesObject `json:"data"`
+}
+
+type ChannelPriceQuery struct {
+	// TargetMargin is the net margin wanted after the fee in percent of the
+	// variant cost price
+	TargetMargin string `json:"target_margin"`
+	// Channel limits the result to one channel, all channels when empty
+	Channel string `json:"channel"`
+	// Date selects the fee schedule active on that date, today when empty
+	Date string `json:"date"`
+}
@@ -0,0 +1,33 @@
+package channelprices
+
+import "github.com/shopspring/decimal"
+
+type VariantChannelPricesObject struct {
+	VariantID    string           `json:"variant_id"`
+	FullName     string           `json:"full_name"`
+	CostPrice    *decimal.Decimal `json:"cost_price"`
+	TargetMargin decimal.Decimal  `json:"target_margin"`
+	// TargetNet is what the shop wants to receive after the fee
+	TargetNet *decimal.Decimal     `json:"target_net"`
+	Channels  []ChannelPriceObject `json:"channels"`
+	Message   string               `json:"message,omitempty"`
+}
+
+type ChannelPriceObject struct {
+	Channel             string           `json:"channel"`
+	ScheduleID          *string          `json:"schedule_id"`
+	ListingID           *string          `json:"listing_id"`
+	FeeCategory         *string          `json:"fee_category"`
+	RecommendedPrice    *decimal.Decimal `json:"recommended_price"`
+	ExpectedFee         *decimal.Decimal `json:"expected_fee"`
+	AdminFee            *decimal.Decimal `json:"admin_fee"`
+	FreeDeliveryFee     *decimal.Decimal `json:"free_delivery_fee"`
+	NetMargin           *decimal.Decimal `json:"net_margin"`
+	NetMarginPercentage *decimal.Decimal `json:"net_margin_percentage"`
+	// ListedPrice and the fields after it describe the price currently set
+	// on the listing, they are empty when the listing has no price
+	ListedPrice          *decimal.Decimal `json:"listed_price"`
+	ListedPriceFee       *decimal.Decimal `json:"listed_price_fee"`
+	ListedPriceNetMargin *decimal.Decimal `json:"listed_price_net_margin"`
+	Message              string           `json:"message,omitempty"`
+}
@@ -0,0 +1,41 @@
+package channelprices
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type ChannelPriceService interface {
+	GetVariantChannelPrices(
+		ctx context.Context,
+		request *GetVariantChannelPricesRequest,
+	) (*GetVariantChannelPricesResponse, error)
+	GetBulkChannelPrices(
+		ctx context.Context,
+		request *GetBulkChannelPricesRequest,
+	) (*GetBulkChannelPricesResponse, error)
+}
+
+type Service struct {
+	db                              *pgxpool.Pool
+	productVariantRepository        repository.ProductVariant
+	variantChannelListingRepository repository.VariantChannelListing
+	pricingEngine                   *pricing.Engine
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	productVariantRepository repository.ProductVariant,
+	variantChannelListingRepository repository.VariantChannelListing,
+	pricingEngine *pricing.Engine,
+) ChannelPriceService {
+	return &Service{
+		db:                              db,
+		productVariantRepository:        productVariantRepository,
+		variantChannelListingRepository: variantChannelListingRepository,
+		pricingEngine:                   pricingEngine,
+	}
+}
@@ -0,0 +1,77 @@
+package channelprices
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetBulkChannelPrices struct {
+	*GetBulkChannelPricesRequest
+	variantIDs []string
+	params     *channelPriceParams
+}
+
+func (req *requestGetBulkChannelPrices) sanitize() {
+	req.ChannelPriceQuery.sanitize()
+	seen := map[string]bool{}
+	for _, variantID := range strings.Split(req.VariantIDs, ",") {
+		variantID = strings.TrimSpace(variantID)
+		if variantID == "" || seen[variantID] {
+			continue
+		}
+		seen[variantID] = true
+		req.variantIDs = append(req.variantIDs, variantID)
+	}
+}
+
+func (req *requestGetBulkChannelPrices) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if len(req.variantIDs) == 0 || len(req.variantIDs) > maxBulkVariants {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field: fieldValidationFieldVariantIDs,
+			Message: fmt.Sprintf(
+				"variant_ids must contain 1 to %d ids", maxBulkVariants),
+		})
+	}
+	for _, variantID := range req.variantIDs {
+		if err := common.ValidateUUIDFormat(variantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldVariantIDs,
+				Message: variantID + ": " + err.Error(),
+			})
+		}
+	}
+	params, queryValidation := req.ChannelPriceQuery.validateField()
+	req.params = params
+	return append(fieldValidation, queryValidation...)
+}
+
+// GetBulkChannelPrices prices many variants at once, a variant that cannot
+// be priced carries a message instead of failing the whole request
+func (s *Service) GetBulkChannelPrices(
+	ctx context.Context,
+	request *GetBulkChannelPricesRequest,
+) (*GetBulkChannelPricesResponse, error) {
+	input := &requestGetBulkChannelPrices{
+		GetBulkChannelPricesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	data, notFound, err := s.calculateChannelPrices(ctx, input.variantIDs, input.params)
+	if err != nil {
+		return nil, toInternalServerError(ctx, err)
+	}
+	if len(notFound) > 0 {
+		return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"variants not found: "+strings.Join(notFound, ", "),
+		))
+	}
+	return &GetBulkChannelPricesResponse{Data: data}, nil
+}
@@ -0,0 +1,62 @@
+package channelprices
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetVariantChannelPrices struct {
+	*GetVariantChannelPricesRequest
+	params *channelPriceParams
+}
+
+func (req *requestGetVariantChannelPrices) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.ChannelPriceQuery.sanitize()
+}
+
+func (req *requestGetVariantChannelPrices) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	params, queryValidation := req.ChannelPriceQuery.validateField()
+	req.params = params
+	return append(fieldValidation, queryValidation...)
+}
+
+// GetVariantChannelPrices recommends a listing price on every channel so the
+// variant earns the target margin after the channel fee
+func (s *Service) GetVariantChannelPrices(
+	ctx context.Context,
+	request *GetVariantChannelPricesRequest,
+) (*GetVariantChannelPricesResponse, error) {
+	input := &requestGetVariantChannelPrices{
+		GetVariantChannelPricesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	data, notFound, err := s.calculateChannelPrices(
+		ctx, []string{input.VariantID}, input.params)
+	if err != nil {
+		return nil, toInternalServerError(ctx, err)
+	}
+	if len(notFound) > 0 {
+		return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"variant not found",
+		))
+	}
+	if data[0].Message != "" {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(data[0].Message))
+	}
+	return &GetVariantChannelPricesResponse{Data: data[0]}, nil
+}
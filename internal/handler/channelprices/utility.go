@@ -0,0 +1,259 @@
+package channelprices
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+var allowedChannels = []string{
+	string(repository.OnlineChannelShopee),
+	string(repository.OnlineChannelLazada),
+	string(repository.OnlineChannelTokopedia),
+	string(repository.OnlineChannelTiktok),
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// channelPriceParams is a validated ChannelPriceQuery
+type channelPriceParams struct {
+	targetMargin decimal.Decimal
+	channels     []repository.OnlineChannel
+	date         time.Time
+}
+
+func (query *ChannelPriceQuery) sanitize() {
+	query.TargetMargin = strings.TrimSpace(query.TargetMargin)
+	query.Channel = strings.TrimSpace(strings.ToUpper(query.Channel))
+	query.Date = strings.TrimSpace(query.Date)
+}
+
+func (query *ChannelPriceQuery) validateField() (
+	*channelPriceParams,
+	[]httperror.FieldValidation,
+) {
+	fieldValidation := []httperror.FieldValidation{}
+	params := &channelPriceParams{date: time.Now()}
+	targetMargin, err := decimal.NewFromString(query.TargetMargin)
+	if err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldTargetMargin,
+			Message: "target_margin must be a number",
+		})
+	} else if targetMargin.IsNegative() ||
+		targetMargin.GreaterThan(decimal.NewFromInt(maxTargetMargin)) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldTargetMargin,
+			Message: "target_margin must be between 0 and 1000",
+		})
+	}
+	params.targetMargin = targetMargin
+	if query.Channel != "" {
+		if err := common.ValidateOneOf(query.Channel, allowedChannels); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldChannel,
+				Message: err.Error(),
+			})
+		}
+		params.channels = []repository.OnlineChannel{
+			repository.OnlineChannel(query.Channel),
+		}
+	} else {
+		for _, channel := range allowedChannels {
+			params.channels = append(params.channels, repository.OnlineChannel(channel))
+		}
+	}
+	if query.Date != "" {
+		date, err := time.Parse(dateLayout, query.Date)
+		if err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+		params.date = date
+	}
+	return params, fieldValidation
+}
+
+// calculateChannelPrices prices every variant in the order of variantIDs,
+// the ids of variants that do not exist or are inactive are returned
+// separately
+func (s *Service) calculateChannelPrices(
+	ctx context.Context,
+	variantIDs []string,
+	params *channelPriceParams,
+) ([]VariantChannelPricesObject, []string, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+	variants, err := s.productVariantRepository.FindManyByID(ctx, tx, variantIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	listings, err := s.variantChannelListingRepository.FindActiveByVariantIDs(
+		ctx, tx, variantIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	schedules := map[repository.OnlineChannel]*repository.ChannelFeeScheduleData{}
+	for _, channel := range params.channels {
+		schedule, err := s.pricingEngine.ActiveSchedule(ctx, channel, params.date)
+		if err != nil && !errors.Is(err, pricing.ErrNoActiveSchedule) {
+			return nil, nil, err
+		}
+		schedules[channel] = schedule
+	}
+	variantByID := make(map[string]*repository.ProductVariantData, len(variants))
+	for i := range variants {
+		variantByID[variants[i].ID] = &variants[i]
+	}
+	listingsByVariantID := map[string][]repository.VariantChannelListingData{}
+	for _, listing := range listings {
+		listingsByVariantID[listing.VariantID] = append(
+			listingsByVariantID[listing.VariantID], listing)
+	}
+	data := make([]VariantChannelPricesObject, 0, len(variantIDs))
+	notFound := []string{}
+	for _, variantID := range variantIDs {
+		variant, ok := variantByID[variantID]
+		if !ok {
+			notFound = append(notFound, variantID)
+			continue
+		}
+		data = append(data, priceVariant(
+			variant, listingsByVariantID[variantID], schedules, params))
+	}
+	return data, notFound, nil
+}
+
+func priceVariant(
+	variant *repository.ProductVariantData,
+	listings []repository.VariantChannelListingData,
+	schedules map[repository.OnlineChannel]*repository.ChannelFeeScheduleData,
+	params *channelPriceParams,
+) VariantChannelPricesObject {
+	object := VariantChannelPricesObject{
+		VariantID:    variant.ID,
+		FullName:     variant.FullName,
+		TargetMargin: params.targetMargin,
+		Channels:     []ChannelPriceObject{},
+	}
+	if !variant.CostPrice.Valid {
+		object.Message = "variant has no cost price"
+		return object
+	}
+	cost := variant.CostPrice.Decimal
+	targetNet := cost.Add(cost.Mul(params.targetMargin).Div(hundred)).Round(2)
+	object.CostPrice = &cost
+	object.TargetNet = &targetNet
+	for _, channel := range params.channels {
+		object.Channels = append(object.Channels, priceChannel(
+			channel, cost, targetNet,
+			findChannelListing(listings, channel), schedules[channel]))
+	}
+	return object
+}
+
+func priceChannel(
+	channel repository.OnlineChannel,
+	cost, targetNet decimal.Decimal,
+	listing *repository.VariantChannelListingData,
+	schedule *repository.ChannelFeeScheduleData,
+) ChannelPriceObject {
+	object := ChannelPriceObject{Channel: string(channel)}
+	if listing != nil {
+		object.ListingID = &listing.ID
+		if listing.ListedPrice.Valid {
+			object.ListedPrice = &listing.ListedPrice.Decimal
+		}
+	}
+	if schedule == nil {
+		object.Message = pricing.ErrNoActiveSchedule.Error()
+		return object
+	}
+	object.ScheduleID = &schedule.ID
+	category, ok := feeCategory(listing, schedule)
+	if !ok {
+		object.Message = "fee category is unknown, set fee_category on the channel listing"
+		return object
+	}
+	object.FeeCategory = &category
+	quote, err := pricing.RecommendListingPrice(schedule, category, targetNet)
+	if err != nil {
+		object.Message = err.Error()
+		return object
+	}
+	netMargin := quote.Net.Sub(cost)
+	netMarginPercentage := decimal.Zero
+	if quote.Price.IsPositive() {
+		netMarginPercentage = netMargin.Mul(hundred).Div(quote.Price).Round(2)
+	}
+	object.RecommendedPrice = &quote.Price
+	object.ExpectedFee = &quote.Fee.Total
+	object.AdminFee = &quote.Fee.AdminFee
+	object.FreeDeliveryFee = &quote.Fee.FreeDeliveryFee
+	object.NetMargin = &netMargin
+	object.NetMarginPercentage = &netMarginPercentage
+	if object.ListedPrice != nil {
+		listedQuote, err := pricing.QuotePrice(schedule, category, *object.ListedPrice)
+		if err == nil {
+			listedNetMargin := listedQuote.Net.Sub(cost)
+			object.ListedPriceFee = &listedQuote.Fee.Total
+			object.ListedPriceNetMargin = &listedNetMargin
+		}
+	}
+	return object
+}
+
+// findChannelListing prefers the most recently updated listing of the
+// channel that has a fee category, listings are sorted by updated_at desc
+func findChannelListing(
+	listings []repository.VariantChannelListingData,
+	channel repository.OnlineChannel,
+) *repository.VariantChannelListingData {
+	var found *repository.VariantChannelListingData
+	for i := range listings {
+		if listings[i].Channel != channel {
+			continue
+		}
+		if listings[i].FeeCategory.Valid {
+			return &listings[i]
+		}
+		if found == nil {
+			found = &listings[i]
+		}
+	}
+	return found
+}
+
+// feeCategory takes the category of the listing, a schedule with a single
+// rate needs no category
+func feeCategory(
+	listing *repository.VariantChannelListingData,
+	schedule *repository.ChannelFeeScheduleData,
+) (string, bool) {
+	if listing != nil && listing.FeeCategory.Valid {
+		return listing.FeeCategory.String, true
+	}
+	if len(schedule.Rates) == 1 {
+		return schedule.Rates[0].Category, true
+	}
+	return "", false
+}
+
+func toInternalServerError(ctx context.Context, err error) error {
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
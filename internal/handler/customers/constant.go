@@ -0,0 +1,17 @@
+package customers
+
+const (
+	fieldValidationFieldCustomerID = "customer_id"
+	fieldValidationFieldName       = "name"
+	fieldValidationFieldPhone      = "phone"
+	fieldValidationFieldAddress    = "address"
+	fieldValidationFieldPriceTier  = "price_tier"
+	fieldValidationFieldNote       = "note"
+	fieldValidationFieldIsActive   = "is_active"
+	fieldValidationFieldStatus     = "status"
+
+	maxLengthName    = 100
+	maxLengthPhone   = 30
+	maxLengthAddress = 255
+	maxLengthNote    = 255
+)
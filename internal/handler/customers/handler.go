@@ -0,0 +1,100 @@
+package customers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service CustomerService
+}
+
+func NewHandler(service CustomerService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/customers")
+	endpoint.POST("/", h.CreateCustomer)
+	endpoint.GET("/", h.GetCustomers)
+	endpoint.GET("/:customer_id", h.GetCustomer)
+	endpoint.PUT("/:customer_id", h.UpdateCustomer)
+	endpoint.DELETE("/:customer_id", h.DeleteCustomer)
+}
+
+func (h *Handler) CreateCustomer(c *gin.Context) {
+	request := &CreateCustomerRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateCustomer(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetCustomers(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetCustomersRequest{
+		PaginationData: *pagination,
+		Search:         c.Query("search"),
+		PriceTier:      c.Query("price_tier"),
+		Status:         c.Query("status"),
+	}
+	response, err := h.service.GetCustomers(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetCustomer(c *gin.Context) {
+	request := &GetCustomerRequest{
+		CustomerID: c.Param("customer_id"),
+	}
+	response, err := h.service.GetCustomer(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateCustomer(c *gin.Context) {
+	request := &UpdateCustomerRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.CustomerID = c.Param("customer_id")
+	if err := h.service.UpdateCustomer(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) DeleteCustomer(c *gin.Context) {
+	request := &DeleteCustomerRequest{
+		CustomerID: c.Param("customer_id"),
+	}
+	if err := h.service.DeleteCustomer(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,52 @@
+package customers
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type CustomerPayload struct {
+	Name    string  `json:"name"`
+	Phone   *string `json:"phone"`
+	Address *string `json:"address"`
+	// PriceTier is RETAIL, RESELLER or WHOLESALE, RETAIL when empty
+	PriceTier string  `json:"price_tier"`
+	Note      *string `json:"note"`
+}
+
+type CreateCustomerRequest struct {
+	CustomerPayload
+}
+
+type CreateCustomerResponse struct {
+	CustomerID string `json:"customer_id"`
+}
+
+type UpdateCustomerRequest struct {
+	CustomerID string `json:"customer_id"`
+	CustomerPayload
+	IsActive *bool `json:"is_active"`
+}
+
+type GetCustomerRequest struct {
+	CustomerID string `json:"customer_id"`
+}
+
+type GetCustomerResponse struct {
+	Data CustomerObject `json:"data"`
+}
+
+type GetCustomersRequest struct {
+	util.PaginationData `json:"pagination"`
+	Search              string `json:"search"`
+	PriceTier           string `json:"price_tier"`
+	Status              string `json:"status"`
+}
+
+type GetCustomersResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []CustomerObject `json:"data"`
+}
+
+type DeleteCustomerRequest struct {
+	CustomerID string `json:"customer_id"`
+}
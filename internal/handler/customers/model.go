@@ -0,0 +1,17 @@
+package customers
+
+import "time"
+
+type CustomerObject struct {
+	CustomerID string    `json:"customer_id"`
+	Name       string    `json:"name"`
+	Phone      *string   `json:"phone"`
+	Address    *string   `json:"address"`
+	PriceTier  string    `json:"price_tier"`
+	Note       *string   `json:"note"`
+	IsActive   bool      `json:"is_active"`
+	CreatedBy  string    `json:"created_by"`
+	UpdatedBy  string    `json:"updated_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package customers
+
+import (
+	"context"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type CustomerService interface {
+	CreateCustomer(ctx context.Context, request *CreateCustomerRequest) (*CreateCustomerResponse, error)
+	GetCustomer(ctx context.Context, request *GetCustomerRequest) (*GetCustomerResponse, error)
+	GetCustomers(ctx context.Context, request *GetCustomersRequest) (*GetCustomersResponse, error)
+	UpdateCustomer(ctx context.Context, request *UpdateCustomerRequest) error
+	DeleteCustomer(ctx context.Context, request *DeleteCustomerRequest) error
+}
+
+type Service struct {
+	customerRepository repository.Customer
+}
+
+func NewService(customerRepository repository.Customer) CustomerService {
+	return &Service{
+		customerRepository: customerRepository,
+	}
+}
@@ -0,0 +1,64 @@
+package customers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateCustomer struct {
+	*CreateCustomerRequest
+}
+
+func (req *requestCreateCustomer) sanitize() {
+	req.CustomerPayload.sanitize()
+}
+
+func (req *requestCreateCustomer) validateField() []httperror.FieldValidation {
+	return req.CustomerPayload.validateField()
+}
+
+func (s *Service) CreateCustomer(
+	ctx context.Context,
+	request *CreateCustomerRequest,
+) (*CreateCustomerResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateCustomer{
+		CreateCustomerRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if input.PriceTier != string(repository.CustomerPriceTierRetail) {
+		if err := middleware.CheckPermission(
+			ctx, middleware.PermissionPricingWrite); err != nil {
+			// error is already handled by CheckPermission
+			return nil, err
+		}
+	}
+	customer := &repository.CustomerData{
+		ID:        uuid.NewString(),
+		Name:      input.Name,
+		Phone:     toNullString(input.Phone),
+		Address:   toNullString(input.Address),
+		PriceTier: repository.CustomerPriceTier(input.PriceTier),
+		Note:      toNullString(input.Note),
+		IsActive:  true,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	}
+	if err := s.customerRepository.Insert(ctx, customer); err != nil {
+		return nil, handleSaveError(ctx, err)
+	}
+	return &CreateCustomerResponse{CustomerID: customer.ID}, nil
+}
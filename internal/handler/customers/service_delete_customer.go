@@ -0,0 +1,63 @@
+package customers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteCustomer struct {
+	*DeleteCustomerRequest
+}
+
+func (req *requestDeleteCustomer) sanitize() {
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+}
+
+func (req *requestDeleteCustomer) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.CustomerID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldCustomerID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// DeleteCustomer soft deletes the customer, its sales keep pointing to it
+// and its phone becomes free to be used again
+func (s *Service) DeleteCustomer(
+	ctx context.Context,
+	request *DeleteCustomerRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteCustomer{
+		DeleteCustomerRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if err := s.customerRepository.SoftDelete(ctx, input.CustomerID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"customer not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package customers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetCustomer struct {
+	*GetCustomerRequest
+}
+
+func (req *requestGetCustomer) sanitize() {
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+}
+
+func (req *requestGetCustomer) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.CustomerID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldCustomerID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetCustomer(
+	ctx context.Context,
+	request *GetCustomerRequest,
+) (*GetCustomerResponse, error) {
+	input := &requestGetCustomer{
+		GetCustomerRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	customer, err := s.customerRepository.FindByID(ctx, input.CustomerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"customer not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetCustomerResponse{
+		Data: toCustomerObject(customer),
+	}, nil
+}
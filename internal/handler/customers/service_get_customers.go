@@ -0,0 +1,83 @@
+package customers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetCustomers struct {
+	*GetCustomersRequest
+}
+
+func (req *requestGetCustomers) sanitize() {
+	req.Search = strings.TrimSpace(req.Search)
+	req.PriceTier = strings.TrimSpace(strings.ToUpper(req.PriceTier))
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+}
+
+func (req *requestGetCustomers) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.PriceTier != "" {
+		if err := common.ValidateOneOf(req.PriceTier, allowedPriceTiers); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldPriceTier,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, []string{"TRUE", "FALSE"}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetCustomers(
+	ctx context.Context,
+	request *GetCustomersRequest,
+) (*GetCustomersResponse, error) {
+	input := &requestGetCustomers{
+		GetCustomersRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	customers, totalCount, err := s.customerRepository.FindPaginated(ctx,
+		&repository.CustomerFilter{
+			Search:    input.Search,
+			PriceTier: input.PriceTier,
+			IsActive:  input.Status,
+			Limit:     input.PageSize,
+			Offset:    input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]CustomerObject, 0, len(customers))
+	for i := range customers {
+		data = append(data, toCustomerObject(&customers[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetCustomersResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
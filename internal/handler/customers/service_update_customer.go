@@ -0,0 +1,83 @@
+package customers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateCustomer struct {
+	*UpdateCustomerRequest
+}
+
+func (req *requestUpdateCustomer) sanitize() {
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+	req.CustomerPayload.sanitize()
+}
+
+func (req *requestUpdateCustomer) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.CustomerID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldCustomerID,
+			Message: err.Error(),
+		})
+	}
+	if req.IsActive == nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldIsActive,
+			Message: "is_active is required",
+		})
+	}
+	return append(fieldValidation, req.CustomerPayload.validateField()...)
+}
+
+// UpdateCustomer replaces every field of the customer. Past sales keep the
+// price tier the customer had at sale time.
+func (s *Service) UpdateCustomer(
+	ctx context.Context,
+	request *UpdateCustomerRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateCustomer{
+		UpdateCustomerRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	stored, err := s.customerRepository.FindByID(ctx, input.CustomerID)
+	if err != nil {
+		return handleSaveError(ctx, err)
+	}
+	if string(stored.PriceTier) != input.PriceTier {
+		if err := middleware.CheckPermission(
+			ctx, middleware.PermissionPricingWrite); err != nil {
+			// error is already handled by CheckPermission
+			return err
+		}
+	}
+	if err := s.customerRepository.Update(ctx, &repository.CustomerData{
+		ID:        input.CustomerID,
+		Name:      input.Name,
+		Phone:     toNullString(input.Phone),
+		Address:   toNullString(input.Address),
+		PriceTier: repository.CustomerPriceTier(input.PriceTier),
+		Note:      toNullString(input.Note),
+		IsActive:  *input.IsActive,
+		UpdatedBy: userID,
+	}); err != nil {
+		return handleSaveError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package customers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+var allowedPriceTiers = []string{
+	string(repository.CustomerPriceTierRetail),
+	string(repository.CustomerPriceTierReseller),
+	string(repository.CustomerPriceTierWholesale),
+}
+
+// sanitize trims the payload, optional fields that are empty after trimming
+// are treated as not set
+func (payload *CustomerPayload) sanitize() {
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.Phone = trimOptional(payload.Phone)
+	payload.Address = trimOptional(payload.Address)
+	payload.PriceTier = strings.TrimSpace(strings.ToUpper(payload.PriceTier))
+	if payload.PriceTier == "" {
+		payload.PriceTier = string(repository.CustomerPriceTierRetail)
+	}
+	payload.Note = trimOptional(payload.Note)
+}
+
+func (payload *CustomerPayload) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateStringRequired(
+		payload.Name, fieldValidationFieldName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldName,
+			Message: err.Error(),
+		})
+	}
+	lengthFields := []struct {
+		field     string
+		value     *string
+		maxLength int
+	}{
+		{fieldValidationFieldName, &payload.Name, maxLengthName},
+		{fieldValidationFieldPhone, payload.Phone, maxLengthPhone},
+		{fieldValidationFieldAddress, payload.Address, maxLengthAddress},
+		{fieldValidationFieldNote, payload.Note, maxLengthNote},
+	}
+	for _, limit := range lengthFields {
+		if limit.value == nil {
+			continue
+		}
+		if err := common.ValidateMaxLengthStr(*limit.value, limit.maxLength); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   limit.field,
+				Message: err.Error(),
+			})
+		}
+	}
+	if err := common.ValidateOneOf(payload.PriceTier, allowedPriceTiers); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPriceTier,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+// handleSaveError maps repository errors of insert and update
+func handleSaveError(ctx context.Context, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"customer not found",
+		))
+	}
+	if errors.Is(err, pg.ErrCustomerAlreadyExists) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
+
+func toCustomerObject(customer *repository.CustomerData) CustomerObject {
+	return CustomerObject{
+		CustomerID: customer.ID,
+		Name:       customer.Name,
+		Phone:      fromNullString(customer.Phone),
+		Address:    fromNullString(customer.Address),
+		PriceTier:  string(customer.PriceTier),
+		Note:       fromNullString(customer.Note),
+		IsActive:   customer.IsActive,
+		CreatedBy:  customer.CreatedBy,
+		UpdatedBy:  customer.UpdatedBy,
+		CreatedAt:  customer.CreatedAt,
+		UpdatedAt:  customer.UpdatedAt,
+	}
+}
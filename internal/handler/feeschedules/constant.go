@@ -0,0 +1,18 @@
+package feeschedules
+
+const (
+	fieldValidationFieldScheduleID                = "schedule_id"
+	fieldValidationFieldChannel                   = "channel"
+	fieldValidationFieldName                      = "name"
+	fieldValidationFieldEffectiveFrom             = "effective_from"
+	fieldValidationFieldDate                      = "date"
+	fieldValidationFieldFreeDeliveryFeePercentage = "free_delivery_fee_percentage"
+	fieldValidationFieldFreeDeliveryFeeCap        = "free_delivery_fee_cap"
+	fieldValidationFieldRates                     = "rates"
+
+	maxLengthName     = 100
+	maxLengthCategory = 30
+	maxRates          = 50
+
+	dateLayout = "2006-01-02"
+)
@@ -0,0 +1,114 @@
+package feeschedules
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service FeeScheduleService
+}
+
+func NewHandler(service FeeScheduleService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/fee-schedules")
+	endpoint.POST("/", h.CreateFeeSchedule)
+	endpoint.GET("/", h.GetFeeSchedules)
+	endpoint.GET("/active", h.GetActiveFeeSchedule)
+	endpoint.GET("/:schedule_id", h.GetFeeSchedule)
+	endpoint.PUT("/:schedule_id", h.UpdateFeeSchedule)
+	endpoint.DELETE("/:schedule_id", h.DeleteFeeSchedule)
+}
+
+func (h *Handler) CreateFeeSchedule(c *gin.Context) {
+	request := &CreateFeeScheduleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateFeeSchedule(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) UpdateFeeSchedule(c *gin.Context) {
+	request := &UpdateFeeScheduleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.ScheduleID = c.Param("schedule_id")
+	if err := h.service.UpdateFeeSchedule(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) GetFeeSchedules(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetFeeSchedulesRequest{
+		PaginationData: *pagination,
+		Channel:        c.Query("channel"),
+	}
+	response, err := h.service.GetFeeSchedules(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetActiveFeeSchedule takes the channel and an optional date in YYYY-MM-DD
+// format as query parameters
+func (h *Handler) GetActiveFeeSchedule(c *gin.Context) {
+	request := &GetActiveFeeScheduleRequest{
+		Channel: c.Query("channel"),
+		Date:    c.Query("date"),
+	}
+	response, err := h.service.GetActiveFeeSchedule(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetFeeSchedule(c *gin.Context) {
+	request := &GetFeeScheduleRequest{
+		ScheduleID: c.Param("schedule_id"),
+	}
+	response, err := h.service.GetFeeSchedule(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) DeleteFeeSchedule(c *gin.Context) {
+	request := &DeleteFeeScheduleRequest{
+		ScheduleID: c.Param("schedule_id"),
+	}
+	if err := h.service.DeleteFeeSchedule(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,64 @@
+package feeschedules
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type FeeSchedulePayload struct {
+	Channel string `json:"channel"`
+	Name    string `json:"name"`
+	// EffectiveFrom is the first day the schedule applies in YYYY-MM-DD
+	// format, it applies until the next schedule of the channel starts
+	EffectiveFrom             string           `json:"effective_from"`
+	FreeDeliveryFeePercentage decimal.Decimal  `json:"free_delivery_fee_percentage"`
+	FreeDeliveryFeeCap        *decimal.Decimal `json:"free_delivery_fee_cap"`
+	Rates                     []FeeRatePayload `json:"rates"`
+}
+
+type FeeRatePayload struct {
+	Category           string           `json:"category"`
+	AdminFeePercentage decimal.Decimal  `json:"admin_fee_percentage"`
+	AdminFeeCap        *decimal.Decimal `json:"admin_fee_cap"`
+}
+
+type CreateFeeScheduleRequest struct {
+	FeeSchedulePayload
+}
+
+type CreateFeeScheduleResponse struct {
+	ScheduleID string `json:"schedule_id"`
+}
+
+type UpdateFeeScheduleRequest struct {
+	ScheduleID string `json:"schedule_id"`
+	FeeSchedulePayload
+}
+
+type GetFeeScheduleRequest struct {
+	ScheduleID string `json:"schedule_id"`
+}
+
+type GetFeeScheduleResponse struct {
+	Data FeeScheduleObject `json:"data"`
+}
+
+type GetActiveFeeScheduleRequest struct {
+	Channel string `json:"channel"`
+	// Date defaults to today when empty
+	Date string `json:"date"`
+}
+
+type GetFeeSchedulesRequest struct {
+	util.PaginationData `json:"pagination"`
+	Channel             string `json:"channel"`
+}
+
+type GetFeeSchedulesResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []FeeScheduleObject `json:"data"`
+}
+
+type DeleteFeeScheduleRequest struct {
+	ScheduleID string `json:"schedule_id"`
+}
@@ -0,0 +1,27 @@
+package feeschedules
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type FeeScheduleObject struct {
+	ScheduleID                string           `json:"schedule_id"`
+	Channel                   string           `json:"channel"`
+	Name                      string           `json:"name"`
+	EffectiveFrom             string           `json:"effective_from"`
+	FreeDeliveryFeePercentage decimal.Decimal  `json:"free_delivery_fee_percentage"`
+	FreeDeliveryFeeCap        *decimal.Decimal `json:"free_delivery_fee_cap"`
+	Rates                     []FeeRateObject  `json:"rates"`
+	CreatedBy                 string           `json:"created_by"`
+	UpdatedBy                 string           `json:"updated_by"`
+	CreatedAt                 time.Time        `json:"created_at"`
+	UpdatedAt                 time.Time        `json:"updated_at"`
+}
+
+type FeeRateObject struct {
+	Category           string           `json:"category"`
+	AdminFeePercentage decimal.Decimal  `json:"admin_fee_percentage"`
+	AdminFeeCap        *decimal.Decimal `json:"admin_fee_cap"`
+}
@@ -0,0 +1,48 @@
+package feeschedules
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type FeeScheduleService interface {
+	CreateFeeSchedule(
+		ctx context.Context,
+		request *CreateFeeScheduleRequest,
+	) (*CreateFeeScheduleResponse, error)
+	UpdateFeeSchedule(ctx context.Context, request *UpdateFeeScheduleRequest) error
+	GetFeeSchedule(
+		ctx context.Context,
+		request *GetFeeScheduleRequest,
+	) (*GetFeeScheduleResponse, error)
+	GetActiveFeeSchedule(
+		ctx context.Context,
+		request *GetActiveFeeScheduleRequest,
+	) (*GetFeeScheduleResponse, error)
+	GetFeeSchedules(
+		ctx context.Context,
+		request *GetFeeSchedulesRequest,
+	) (*GetFeeSchedulesResponse, error)
+	DeleteFeeSchedule(ctx context.Context, request *DeleteFeeScheduleRequest) error
+}
+
+type Service struct {
+	db                    *pgxpool.Pool
+	feeScheduleRepository repository.ChannelFeeSchedule
+	pricingEngine         *pricing.Engine
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	feeScheduleRepository repository.ChannelFeeSchedule,
+	pricingEngine *pricing.Engine,
+) FeeScheduleService {
+	return &Service{
+		db:                    db,
+		feeScheduleRepository: feeScheduleRepository,
+		pricingEngine:         pricingEngine,
+	}
+}
@@ -0,0 +1,54 @@
+package feeschedules
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateFeeSchedule struct {
+	*CreateFeeScheduleRequest
+}
+
+func (req *requestCreateFeeSchedule) sanitize() {
+	req.FeeSchedulePayload.sanitize()
+}
+
+func (req *requestCreateFeeSchedule) validateField() []httperror.FieldValidation {
+	return req.FeeSchedulePayload.validateField()
+}
+
+func (s *Service) CreateFeeSchedule(
+	ctx context.Context,
+	request *CreateFeeScheduleRequest,
+) (*CreateFeeScheduleResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateFeeSchedule{
+		CreateFeeScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	schedule := input.toFeeScheduleData(uuid.NewString(), userID)
+	if err := s.feeScheduleRepository.InsertTransaction(ctx, tx, schedule); err != nil {
+		return nil, handleSaveError(ctx, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateFeeScheduleResponse{ScheduleID: schedule.ID}, nil
+}
@@ -0,0 +1,79 @@
+package feeschedules
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteFeeSchedule struct {
+	*DeleteFeeScheduleRequest
+}
+
+func (req *requestDeleteFeeSchedule) sanitize() {
+	req.ScheduleID = strings.TrimSpace(req.ScheduleID)
+}
+
+func (req *requestDeleteFeeSchedule) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ScheduleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldScheduleID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// DeleteFeeSchedule soft deletes the schedule, the previous schedule of the
+// channel then applies again from its own effective date. The schedule active
+// today can only be deleted when there is a previous schedule.
+func (s *Service) DeleteFeeSchedule(
+	ctx context.Context,
+	request *DeleteFeeScheduleRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteFeeSchedule{
+		DeleteFeeScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	schedule, schedules, err := s.lockChannelSchedules(ctx, tx, input.ScheduleID)
+	if err != nil {
+		// error is already handled by lockChannelSchedules
+		return err
+	}
+	if err := validateActiveScheduleRemoval(ctx, schedule, schedules); err != nil {
+		// error is already handled by validateActiveScheduleRemoval
+		return err
+	}
+	err = s.feeScheduleRepository.SoftDeleteTransaction(ctx, tx, input.ScheduleID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"fee schedule not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return tx.Commit(ctx)
+}
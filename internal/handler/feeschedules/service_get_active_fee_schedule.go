@@ -0,0 +1,74 @@
+package feeschedules
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetActiveFeeSchedule struct {
+	*GetActiveFeeScheduleRequest
+	date time.Time
+}
+
+func (req *requestGetActiveFeeSchedule) sanitize() {
+	req.Channel = strings.TrimSpace(strings.ToUpper(req.Channel))
+	req.Date = strings.TrimSpace(req.Date)
+}
+
+func (req *requestGetActiveFeeSchedule) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateOneOf(req.Channel, allowedChannels); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldChannel,
+			Message: err.Error(),
+		})
+	}
+	req.date = time.Now()
+	if req.Date != "" {
+		date, err := time.Parse(dateLayout, req.Date)
+		if err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+		req.date = date
+	}
+	return fieldValidation
+}
+
+// GetActiveFeeSchedule returns the schedule the pricing engine uses for the
+// channel on the requested date
+func (s *Service) GetActiveFeeSchedule(
+	ctx context.Context,
+	request *GetActiveFeeScheduleRequest,
+) (*GetFeeScheduleResponse, error) {
+	input := &requestGetActiveFeeSchedule{
+		GetActiveFeeScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	schedule, err := s.pricingEngine.ActiveSchedule(
+		ctx, repository.OnlineChannel(input.Channel), input.date)
+	if err != nil {
+		if errors.Is(err, pricing.ErrNoActiveSchedule) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(err.Error()))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetFeeScheduleResponse{
+		Data: toFeeScheduleObject(schedule),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package feeschedules
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetFeeSchedule struct {
+	*GetFeeScheduleRequest
+}
+
+func (req *requestGetFeeSchedule) sanitize() {
+	req.ScheduleID = strings.TrimSpace(req.ScheduleID)
+}
+
+func (req *requestGetFeeSchedule) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ScheduleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldScheduleID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetFeeSchedule(
+	ctx context.Context,
+	request *GetFeeScheduleRequest,
+) (*GetFeeScheduleResponse, error) {
+	input := &requestGetFeeSchedule{
+		GetFeeScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	schedule, err := s.feeScheduleRepository.FindByID(ctx, input.ScheduleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"fee schedule not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetFeeScheduleResponse{
+		Data: toFeeScheduleObject(schedule),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package feeschedules
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetFeeSchedules struct {
+	*GetFeeSchedulesRequest
+}
+
+func (req *requestGetFeeSchedules) sanitize() {
+	req.Channel = strings.TrimSpace(strings.ToUpper(req.Channel))
+}
+
+func (req *requestGetFeeSchedules) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.Channel != "" {
+		if err := common.ValidateOneOf(req.Channel, allowedChannels); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldChannel,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetFeeSchedules(
+	ctx context.Context,
+	request *GetFeeSchedulesRequest,
+) (*GetFeeSchedulesResponse, error) {
+	input := &requestGetFeeSchedules{
+		GetFeeSchedulesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	schedules, totalCount, err := s.feeScheduleRepository.FindPaginated(ctx,
+		&repository.ChannelFeeScheduleFilter{
+			Channel: input.Channel,
+			Limit:   input.PageSize,
+			Offset:  input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]FeeScheduleObject, 0, len(schedules))
+	for i := range schedules {
+		data = append(data, toFeeScheduleObject(&schedules[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetFeeSchedulesResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
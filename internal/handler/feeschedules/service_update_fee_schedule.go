@@ -0,0 +1,76 @@
+package feeschedules
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateFeeSchedule struct {
+	*UpdateFeeScheduleRequest
+}
+
+func (req *requestUpdateFeeSchedule) sanitize() {
+	req.ScheduleID = strings.TrimSpace(req.ScheduleID)
+	req.FeeSchedulePayload.sanitize()
+}
+
+func (req *requestUpdateFeeSchedule) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ScheduleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldScheduleID,
+			Message: err.Error(),
+		})
+	}
+	return append(fieldValidation, req.FeeSchedulePayload.validateField()...)
+}
+
+// UpdateFeeSchedule replaces the schedule and all of its rates. Moving the
+// schedule active today to another channel or to a later date follows the
+// same rule as deleting it.
+func (s *Service) UpdateFeeSchedule(
+	ctx context.Context,
+	request *UpdateFeeScheduleRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateFeeSchedule{
+		UpdateFeeScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	schedule := input.toFeeScheduleData(input.ScheduleID, userID)
+	stored, schedules, err := s.lockChannelSchedules(ctx, tx, input.ScheduleID)
+	if err != nil {
+		// error is already handled by lockChannelSchedules
+		return err
+	}
+	if stored.Channel != schedule.Channel ||
+		schedule.EffectiveFrom.Format(dateLayout) > time.Now().Format(dateLayout) {
+		if err := validateActiveScheduleRemoval(ctx, stored, schedules); err != nil {
+			// error is already handled by validateActiveScheduleRemoval
+			return err
+		}
+	}
+	if err := s.feeScheduleRepository.UpdateTransaction(ctx, tx, schedule); err != nil {
+		return handleSaveError(ctx, err)
+	}
+	return tx.Commit(ctx)
+}
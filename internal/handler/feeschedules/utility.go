@@ -0,0 +1,278 @@
+package feeschedules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+var allowedChannels = []string{
+	string(repository.OnlineChannelShopee),
+	string(repository.OnlineChannelLazada),
+	string(repository.OnlineChannelTokopedia),
+	string(repository.OnlineChannelTiktok),
+}
+
+var maxPercentage = decimal.NewFromInt(100)
+
+func (payload *FeeSchedulePayload) sanitize() {
+	payload.Channel = strings.TrimSpace(strings.ToUpper(payload.Channel))
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.EffectiveFrom = strings.TrimSpace(payload.EffectiveFrom)
+	for i := range payload.Rates {
+		payload.Rates[i].Category = strings.TrimSpace(
+			strings.ToUpper(payload.Rates[i].Category))
+	}
+}
+
+func (payload *FeeSchedulePayload) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateOneOf(payload.Channel, allowedChannels); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldChannel,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateStringRequired(
+		payload.Name, fieldValidationFieldName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldName,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateMaxLengthStr(payload.Name, maxLengthName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldName,
+			Message: err.Error(),
+		})
+	}
+	if _, err := time.Parse(dateLayout, payload.EffectiveFrom); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEffectiveFrom,
+			Message: "invalid date format, use YYYY-MM-DD",
+		})
+	}
+	if !isValidPercentage(payload.FreeDeliveryFeePercentage) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldFreeDeliveryFeePercentage,
+			Message: "free_delivery_fee_percentage must be between 0 and 100",
+		})
+	}
+	if payload.FreeDeliveryFeeCap != nil && !payload.FreeDeliveryFeeCap.IsPositive() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldFreeDeliveryFeeCap,
+			Message: "free_delivery_fee_cap must be greater than 0",
+		})
+	}
+	if len(payload.Rates) == 0 || len(payload.Rates) > maxRates {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldRates,
+			Message: fmt.Sprintf("rates must contain 1 to %d items", maxRates),
+		})
+	}
+	seenCategories := map[string]bool{}
+	for i, rate := range payload.Rates {
+		field := func(name string) string {
+			return fmt.Sprintf("%s[%d].%s", fieldValidationFieldRates, i, name)
+		}
+		if err := common.ValidateStringRequired(rate.Category, "category"); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("category"),
+				Message: err.Error(),
+			})
+		}
+		if err := common.ValidateMaxLengthStr(rate.Category, maxLengthCategory); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("category"),
+				Message: err.Error(),
+			})
+		}
+		if seenCategories[rate.Category] {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("category"),
+				Message: "category is duplicated",
+			})
+		}
+		seenCategories[rate.Category] = true
+		if !isValidPercentage(rate.AdminFeePercentage) {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("admin_fee_percentage"),
+				Message: "admin_fee_percentage must be between 0 and 100",
+			})
+		} else if !rate.AdminFeePercentage.Add(
+			payload.FreeDeliveryFeePercentage).LessThan(maxPercentage) {
+			// A listing price can only cover the fee when the fee is less
+			// than the whole price
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("admin_fee_percentage"),
+				Message: "admin_fee_percentage plus free_delivery_fee_percentage must be less than 100",
+			})
+		}
+		if rate.AdminFeeCap != nil && !rate.AdminFeeCap.IsPositive() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("admin_fee_cap"),
+				Message: "admin_fee_cap must be greater than 0",
+			})
+		}
+	}
+	return fieldValidation
+}
+
+// toFeeScheduleData maps a validated payload, rates get new ids since an
+// update replaces all of them
+func (payload *FeeSchedulePayload) toFeeScheduleData(
+	scheduleID, userID string,
+) *repository.ChannelFeeScheduleData {
+	effectiveFrom, _ := time.Parse(dateLayout, payload.EffectiveFrom)
+	schedule := &repository.ChannelFeeScheduleData{
+		ID:                        scheduleID,
+		Channel:                   repository.OnlineChannel(payload.Channel),
+		Name:                      payload.Name,
+		EffectiveFrom:             effectiveFrom,
+		FreeDeliveryFeePercentage: payload.FreeDeliveryFeePercentage,
+		FreeDeliveryFeeCap:        toNullDecimal(payload.FreeDeliveryFeeCap),
+		CreatedBy:                 userID,
+		UpdatedBy:                 userID,
+		Rates:                     make([]repository.ChannelFeeRateData, 0, len(payload.Rates)),
+	}
+	for _, rate := range payload.Rates {
+		schedule.Rates = append(schedule.Rates, repository.ChannelFeeRateData{
+			ID:                 uuid.NewString(),
+			Category:           rate.Category,
+			AdminFeePercentage: rate.AdminFeePercentage,
+			AdminFeeCap:        toNullDecimal(rate.AdminFeeCap),
+		})
+	}
+	return schedule
+}
+
+func isValidPercentage(percentage decimal.Decimal) bool {
+	return !percentage.IsNegative() && percentage.LessThan(maxPercentage)
+}
+
+func toNullDecimal(value *decimal.Decimal) decimal.NullDecimal {
+	if value == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: value.Round(2), Valid: true}
+}
+
+func fromNullDecimal(value decimal.NullDecimal) *decimal.Decimal {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Decimal
+}
+
+// lockChannelSchedules locks every schedule of the channel of scheduleID and
+// returns the schedule with all of them, a concurrent delete or update of a
+// schedule of the same channel waits until the transaction ends
+func (s *Service) lockChannelSchedules(
+	ctx context.Context,
+	tx pgx.Tx,
+	scheduleID string,
+) (*repository.ChannelFeeScheduleData, []repository.ChannelFeeScheduleData, error) {
+	stored, err := s.feeScheduleRepository.FindByID(ctx, scheduleID)
+	if err != nil {
+		return nil, nil, handleSaveError(ctx, err)
+	}
+	schedules, err := s.feeScheduleRepository.FindByChannelForUpdate(ctx, tx, stored.Channel)
+	if err != nil {
+		return nil, nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	for i := range schedules {
+		if schedules[i].ID == scheduleID {
+			return &schedules[i], schedules, nil
+		}
+	}
+	// the schedule was deleted or moved to another channel before the lock
+	return nil, nil, handleSaveError(ctx, pgx.ErrNoRows)
+}
+
+// validateActiveScheduleRemoval rejects taking away the schedule active today
+// when the channel has no earlier schedule to fall back to, every fee
+// calculation of the channel would fail without one. schedules are the locked
+// schedules of the channel, latest effective date first.
+func validateActiveScheduleRemoval(
+	ctx context.Context,
+	schedule *repository.ChannelFeeScheduleData,
+	schedules []repository.ChannelFeeScheduleData,
+) error {
+	active := activeScheduleOn(schedules, time.Now())
+	if active == nil || active.ID != schedule.ID {
+		return nil
+	}
+	if activeScheduleOn(schedules, schedule.EffectiveFrom.AddDate(0, 0, -1)) != nil {
+		return nil
+	}
+	return httperror.NewBadRequest(ctx, httperror.WithMessage(
+		"the only active fee schedule of "+string(schedule.Channel)+
+			" can not be removed, create the schedule that replaces it first",
+	))
+}
+
+// activeScheduleOn picks the schedule with the latest effective date on or
+// before date, the same rule as FindActive
+func activeScheduleOn(
+	schedules []repository.ChannelFeeScheduleData,
+	date time.Time,
+) *repository.ChannelFeeScheduleData {
+	day := date.Format(dateLayout)
+	for i := range schedules {
+		if schedules[i].EffectiveFrom.Format(dateLayout) <= day {
+			return &schedules[i]
+		}
+	}
+	return nil
+}
+
+// handleSaveError maps repository errors of insert and update
+func handleSaveError(ctx context.Context, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"fee schedule not found",
+		))
+	}
+	if errors.Is(err, pg.ErrFeeScheduleAlreadyExists) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
+
+func toFeeScheduleObject(schedule *repository.ChannelFeeScheduleData) FeeScheduleObject {
+	object := FeeScheduleObject{
+		ScheduleID:                schedule.ID,
+		Channel:                   string(schedule.Channel),
+		Name:                      schedule.Name,
+		EffectiveFrom:             schedule.EffectiveFrom.Format(dateLayout),
+		FreeDeliveryFeePercentage: schedule.FreeDeliveryFeePercentage,
+		FreeDeliveryFeeCap:        fromNullDecimal(schedule.FreeDeliveryFeeCap),
+		Rates:                     make([]FeeRateObject, 0, len(schedule.Rates)),
+		CreatedBy:                 schedule.CreatedBy,
+		UpdatedBy:                 schedule.UpdatedBy,
+		CreatedAt:                 schedule.CreatedAt,
+		UpdatedAt:                 schedule.UpdatedAt,
+	}
+	for _, rate := range schedule.Rates {
+		object.Rates = append(object.Rates, FeeRateObject{
+			Category:           rate.Category,
+			AdminFeePercentage: rate.AdminFeePercentage,
+			AdminFeeCap:        fromNullDecimal(rate.AdminFeeCap),
+		})
+	}
+	return object
+}
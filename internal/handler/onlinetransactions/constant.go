@@ -0,0 +1,34 @@
+package onlinetransactions
+
+const (
+	fieldValidationFieldTransactionID = "transaction_id"
+	fieldValidationFieldChannel       = "channel"
+	fieldValidationFieldOrderNumber   = "order_number"
+	fieldValidationFieldCreatedDate   = "created_date"
+	fieldValidationFieldStartDate     = "start_date"
+	fieldValidationFieldEndDate       = "end_date"
+	fieldValidationFieldProducts      = "products"
+	fieldValidationFieldFile          = "file"
+
+	maxLengthOrderNumber = 100
+	maxLengthProductName = 50
+	// maxLengthErrorLogProductName is the size of product_error_logs.product_name
+	maxLengthErrorLogProductName = 255
+	maxProducts                  = 100
+
+	dateLayout = "2006-01-02"
+
+	// maxImportFileSize and maxImportRows bound one import request
+	maxImportFileSize = 10 << 20
+	maxImportRows     = 5000
+)
+
+// Status of a row in the import report
+const (
+	importRowStatusImported        = "IMPORTED"
+	importRowStatusAlreadyImported = "ALREADY_IMPORTED"
+	importRowStatusCancelled       = "CANCELLED"
+	importRowStatusUnmatched       = "UNMATCHED"
+	importRowStatusInvalid         = "INVALID"
+	importRowStatusFailed          = "FAILED"
+)
@@ -0,0 +1,115 @@
+package onlinetransactions
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service OnlineTransactionService
+}
+
+func NewHandler(service OnlineTransactionService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/online-transactions")
+	endpoint.POST("/", h.CreateOnlineTransaction)
+	endpoint.POST("/import", h.ImportOnlineTransactions)
+	endpoint.GET("/", h.GetOnlineTransactions)
+	endpoint.GET("/:transaction_id", h.GetOnlineTransaction)
+	endpoint.DELETE("/:transaction_id", h.DeleteOnlineTransaction)
+}
+
+func (h *Handler) CreateOnlineTransaction(c *gin.Context) {
+	request := &CreateOnlineTransactionRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateOnlineTransaction(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetOnlineTransactions(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetOnlineTransactionsRequest{
+		PaginationData: *pagination,
+		Channel:        c.Query("channel"),
+		OrderNumber:    c.Query("order_number"),
+		StartDate:      c.Query("start_date"),
+		EndDate:        c.Query("end_date"),
+	}
+	response, err := h.service.GetOnlineTransactions(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetOnlineTransaction(c *gin.Context) {
+	request := &GetOnlineTransactionRequest{
+		TransactionID: c.Param("transaction_id"),
+	}
+	response, err := h.service.GetOnlineTransaction(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) DeleteOnlineTransaction(c *gin.Context) {
+	request := &DeleteOnlineTransactionRequest{
+		TransactionID: c.Param("transaction_id"),
+	}
+	if err := h.service.DeleteOnlineTransaction(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ImportOnlineTransactions accepts a multipart form with the export in the
+// file field and an optional channel field
+func (h *Handler) ImportOnlineTransactions(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+	request := &ImportOnlineTransactionsRequest{
+		Channel:  c.PostForm("channel"),
+		FileName: fileHeader.Filename,
+		File:     file,
+	}
+	response, err := h.service.ImportOnlineTransactions(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,72 @@
+package onlinetransactions
+
+import (
+	"io"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type CreateOnlineTransactionRequest struct {
+	Channel     string `json:"channel"`
+	OrderNumber string `json:"order_number"`
+	// CreatedDate is the order date in YYYY-MM-DD format
+	CreatedDate string                                  `json:"created_date"`
+	Products    []CreateOnlineTransactionProductRequest `json:"products"`
+}
+
+type CreateOnlineTransactionProductRequest struct {
+	// VariantID is optional, when set product_name and cost_price default
+	// to the variant full name and cost price
+	VariantID   *string          `json:"variant_id"`
+	ProductName string           `json:"product_name"`
+	CostPrice   *decimal.Decimal `json:"cost_price"`
+	SalePrice   decimal.Decimal  `json:"sale_price"`
+	Quantity    int              `json:"quantity"`
+	// FeeAmount is the marketplace fee charged for the whole line
+	FeeAmount decimal.Decimal `json:"fee_amount"`
+}
+
+type CreateOnlineTransactionResponse struct {
+	TransactionID  string          `json:"transaction_id"`
+	TotalNetProfit decimal.Decimal `json:"total_net_profit"`
+}
+
+type GetOnlineTransactionRequest struct {
+	TransactionID string `json:"transaction_id"`
+}
+
+type GetOnlineTransactionResponse struct {
+	Data OnlineTransactionObject `json:"data"`
+}
+
+type GetOnlineTransactionsRequest struct {
+	util.PaginationData `json:"pagination"`
+	Channel             string `json:"channel"`
+	OrderNumber         string `json:"order_number"`
+	StartDate           string `json:"start_date"`
+	EndDate             string `json:"end_date"`
+}
+
+type GetOnlineTransactionsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []OnlineTransactionObject `json:"data"`
+}
+
+type DeleteOnlineTransactionRequest struct {
+	TransactionID string `json:"transaction_id"`
+}
+
+type ImportOnlineTransactionsRequest struct {
+	Channel  string    `json:"channel"`
+	FileName string    `json:"file_name"`
+	File     io.Reader `json:"-"`
+}
+
+type ImportOnlineTransactionsResponse struct {
+	TotalRows      int               `json:"total_rows"`
+	ImportedOrders int               `json:"imported_orders"`
+	SkippedOrders  int               `json:"skipped_orders"`
+	FailedOrders   int               `json:"failed_orders"`
+	Rows           []ImportRowObject `json:"rows"`
+}
@@ -0,0 +1,239 @@
+package onlinetransactions
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+// importColumn is a column the importer understands, a column is found by
+// any of its header aliases
+type importColumn string
+
+const (
+	importColumnOrderNumber   importColumn = "order_number"
+	importColumnOrderStatus   importColumn = "order_status"
+	importColumnCreatedDate   importColumn = "created_date"
+	importColumnProductName   importColumn = "product_name"
+	importColumnVariationName importColumn = "variation_name"
+	importColumnSalePrice     importColumn = "sale_price"
+	importColumnQuantity      importColumn = "quantity"
+	importColumnFeeAmount     importColumn = "fee_amount"
+)
+
+// importColumnAliases lists the headers of the Shopee order export in
+// Indonesian and English. Every fee column found is summed into the order fee.
+var importColumnAliases = map[importColumn][]string{
+	importColumnOrderNumber:   {"no. pesanan", "order id", "order sn", "order_number"},
+	importColumnOrderStatus:   {"status pesanan", "order status", "order_status"},
+	importColumnCreatedDate:   {"waktu pesanan dibuat", "order creation date", "created_date"},
+	importColumnProductName:   {"nama produk", "product name", "product_name"},
+	importColumnVariationName: {"nama variasi", "variation name", "variation_name"},
+	importColumnSalePrice:     {"harga setelah diskon", "deal price", "sale_price"},
+	importColumnQuantity:      {"jumlah", "quantity"},
+	importColumnFeeAmount: {
+		"biaya administrasi",
+		"biaya layanan",
+		"biaya proses pesanan",
+		"commission fee",
+		"service fee",
+		"transaction fee",
+		"fee_amount",
+	},
+}
+
+var requiredImportColumns = []importColumn{
+	importColumnOrderNumber,
+	importColumnCreatedDate,
+	importColumnProductName,
+	importColumnSalePrice,
+	importColumnQuantity,
+}
+
+// cancelledOrderStatuses are skipped, they never turned into revenue
+var cancelledOrderStatuses = []string{"batal", "dibatalkan", "cancelled", "canceled"}
+
+var importDateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02-01-2006 15:04",
+	"02-01-2006",
+	"02/01/2006 15:04",
+	"02/01/2006",
+}
+
+var thousandSeparatorPattern = regexp.MustCompile(`^-?\d{1,3}(\.\d{3})+$`)
+
+// importRow is one order line of the export file
+type importRow struct {
+	RowNumber     int
+	OrderNumber   string
+	Cancelled     bool
+	CreatedDate   time.Time
+	ProductName   string
+	VariationName string
+	SalePrice     decimal.Decimal
+	Quantity      int
+	// FeeAmount is the fee of the whole order, the export repeats it on
+	// every line of the order
+	FeeAmount decimal.Decimal
+	// Error is set when the line cannot be read
+	Error string
+}
+
+// readImportFile reads a CSV or XLSX file into rows of cells
+func readImportFile(fileName string, file io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		return reader.ReadAll()
+	case ".xlsx":
+		workbook, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer workbook.Close()
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("xlsx file has no sheet")
+		}
+		return workbook.GetRows(sheets[0])
+	default:
+		return nil, errors.New("file must be a .csv or .xlsx file")
+	}
+}
+
+// mapImportHeader returns the index of every known column, fee columns can
+// appear more than once
+func mapImportHeader(header []string) (map[importColumn][]int, []string) {
+	columns := map[importColumn][]int{}
+	for i, cell := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff")))
+		for column, aliases := range importColumnAliases {
+			for _, alias := range aliases {
+				if name == alias {
+					columns[column] = append(columns[column], i)
+				}
+			}
+		}
+	}
+	missing := []string{}
+	for _, column := range requiredImportColumns {
+		if len(columns[column]) == 0 {
+			missing = append(missing, importColumnAliases[column][0])
+		}
+	}
+	return columns, missing
+}
+
+// parseImportRows turns the cells below the header into order lines, row
+// numbers start at 2 so they match the spreadsheet
+func parseImportRows(cells [][]string, columns map[importColumn][]int) []importRow {
+	rows := []importRow{}
+	for i, record := range cells[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		cell := func(column importColumn) string {
+			indexes := columns[column]
+			if len(indexes) == 0 || indexes[0] >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[indexes[0]])
+		}
+		row := importRow{
+			RowNumber:     i + 2,
+			OrderNumber:   cell(importColumnOrderNumber),
+			ProductName:   cell(importColumnProductName),
+			VariationName: cell(importColumnVariationName),
+		}
+		status := strings.ToLower(cell(importColumnOrderStatus))
+		for _, cancelled := range cancelledOrderStatuses {
+			if strings.Contains(status, cancelled) {
+				row.Cancelled = true
+			}
+		}
+		errorMessages := []string{}
+		if row.OrderNumber == "" {
+			errorMessages = append(errorMessages, "order number is empty")
+		}
+		if row.ProductName == "" {
+			errorMessages = append(errorMessages, "product name is empty")
+		}
+		createdDate, err := parseImportDate(cell(importColumnCreatedDate))
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		row.CreatedDate = createdDate
+		salePrice, err := parseImportAmount(cell(importColumnSalePrice))
+		if err != nil || !salePrice.IsPositive() {
+			errorMessages = append(errorMessages, "invalid sale price")
+		}
+		row.SalePrice = salePrice
+		quantity, err := parseImportAmount(cell(importColumnQuantity))
+		if err != nil || !quantity.IsInteger() || !quantity.IsPositive() {
+			errorMessages = append(errorMessages, "invalid quantity")
+		}
+		row.Quantity = int(quantity.IntPart())
+		for _, index := range columns[importColumnFeeAmount] {
+			if index >= len(record) || strings.TrimSpace(record[index]) == "" {
+				continue
+			}
+			fee, err := parseImportAmount(record[index])
+			if err != nil {
+				errorMessages = append(errorMessages, "invalid fee amount")
+				continue
+			}
+			row.FeeAmount = row.FeeAmount.Add(fee.Abs())
+		}
+		row.Error = strings.Join(errorMessages, ", ")
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, errors.New("invalid order date")
+}
+
+// parseImportAmount reads amounts written as 12500, 12.500, Rp 12.500 or
+// 12.500,50
+func parseImportAmount(value string) (decimal.Decimal, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "Rp"), "IDR")
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	switch {
+	case strings.Contains(value, ",") && strings.Contains(value, "."):
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	case strings.Contains(value, ","):
+		value = strings.ReplaceAll(value, ",", ".")
+	case thousandSeparatorPattern.MatchString(value):
+		value = strings.ReplaceAll(value, ".", "")
+	}
+	return decimal.NewFromString(value)
+}
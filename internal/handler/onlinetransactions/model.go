@@ -0,0 +1,44 @@
+package onlinetransactions
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type OnlineTransactionObject struct {
+	TransactionID   string                           `json:"transaction_id"`
+	Channel         string                           `json:"channel"`
+	OrderNumber     string                           `json:"order_number"`
+	CreatedDate     string                           `json:"created_date"`
+	PeriodMonth     int                              `json:"period_month"`
+	PeriodYear      int                              `json:"period_year"`
+	TotalBaseAmount decimal.Decimal                  `json:"total_base_amount"`
+	TotalSaleAmount decimal.Decimal                  `json:"total_sale_amount"`
+	TotalFeeAmount  decimal.Decimal                  `json:"total_fee_amount"`
+	TotalNetProfit  decimal.Decimal                  `json:"total_net_profit"`
+	CreatedBy       string                           `json:"created_by"`
+	CreatedAt       time.Time                        `json:"created_at"`
+	Products        []OnlineTransactionProductObject `json:"products,omitempty"`
+}
+
+type OnlineTransactionProductObject struct {
+	ProductID   string          `json:"product_id"`
+	VariantID   *string         `json:"variant_id"`
+	ProductName string          `json:"product_name"`
+	CostPrice   decimal.Decimal `json:"cost_price"`
+	SalePrice   decimal.Decimal `json:"sale_price"`
+	Quantity    int             `json:"quantity"`
+	FeeAmount   decimal.Decimal `json:"fee_amount"`
+	NetProfit   decimal.Decimal `json:"net_profit"`
+}
+
+type ImportRowObject struct {
+	RowNumber     int     `json:"row_number"`
+	OrderNumber   string  `json:"order_number"`
+	ProductName   string  `json:"product_name"`
+	VariationName string  `json:"variation_name"`
+	Status        string  `json:"status"`
+	Message       string  `json:"message,omitempty"`
+	TransactionID *string `json:"transaction_id"`
+}
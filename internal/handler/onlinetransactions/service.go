@@ -0,0 +1,52 @@
+package onlinetransactions
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type OnlineTransactionService interface {
+	CreateOnlineTransaction(
+		ctx context.Context,
+		request *CreateOnlineTransactionRequest,
+	) (*CreateOnlineTransactionResponse, error)
+	GetOnlineTransaction(
+		ctx context.Context,
+		request *GetOnlineTransactionRequest,
+	) (*GetOnlineTransactionResponse, error)
+	GetOnlineTransactions(
+		ctx context.Context,
+		request *GetOnlineTransactionsRequest,
+	) (*GetOnlineTransactionsResponse, error)
+	DeleteOnlineTransaction(ctx context.Context, request *DeleteOnlineTransactionRequest) error
+	ImportOnlineTransactions(
+		ctx context.Context,
+		request *ImportOnlineTransactionsRequest,
+	) (*ImportOnlineTransactionsResponse, error)
+}
+
+type Service struct {
+	db                           *pgxpool.Pool
+	onlineTransactionRepository  repository.OnlineTransaction
+	productVariantRepository     repository.ProductVariant
+	marketplaceProductRepository repository.MarketplaceProduct
+	productErrorLogRepository    repository.ProductErrorLog
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	onlineTransactionRepository repository.OnlineTransaction,
+	productVariantRepository repository.ProductVariant,
+	marketplaceProductRepository repository.MarketplaceProduct,
+	productErrorLogRepository repository.ProductErrorLog,
+) OnlineTransactionService {
+	return &Service{
+		db:                           db,
+		onlineTransactionRepository:  onlineTransactionRepository,
+		productVariantRepository:     productVariantRepository,
+		marketplaceProductRepository: marketplaceProductRepository,
+		productErrorLogRepository:    productErrorLogRepository,
+	}
+}
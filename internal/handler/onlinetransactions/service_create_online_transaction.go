@@ -0,0 +1,285 @@
+package onlinetransactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateOnlineTransaction struct {
+	*CreateOnlineTransactionRequest
+	createdDate time.Time
+}
+
+func (req *requestCreateOnlineTransaction) sanitize() {
+	req.Channel = strings.TrimSpace(strings.ToUpper(req.Channel))
+	req.OrderNumber = strings.TrimSpace(req.OrderNumber)
+	req.CreatedDate = strings.TrimSpace(req.CreatedDate)
+	for i := range req.Products {
+		product := &req.Products[i]
+		product.ProductName = strings.TrimSpace(product.ProductName)
+		if product.VariantID != nil {
+			*product.VariantID = strings.TrimSpace(*product.VariantID)
+			if *product.VariantID == "" {
+				product.VariantID = nil
+			}
+		}
+	}
+}
+
+func (req *requestCreateOnlineTransaction) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateOneOf(req.Channel, allowedChannels); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldChannel,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateStringRequired(
+		req.OrderNumber, fieldValidationFieldOrderNumber); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldOrderNumber,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateMaxLengthStr(req.OrderNumber, maxLengthOrderNumber); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldOrderNumber,
+			Message: err.Error(),
+		})
+	}
+	createdDate, err := time.Parse(dateLayout, req.CreatedDate)
+	if err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldCreatedDate,
+			Message: "invalid date format, use YYYY-MM-DD",
+		})
+	}
+	req.createdDate = createdDate
+	if len(req.Products) == 0 || len(req.Products) > maxProducts {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldProducts,
+			Message: fmt.Sprintf("products must contain 1 to %d items", maxProducts),
+		})
+	}
+	for i := range req.Products {
+		fieldValidation = append(fieldValidation,
+			validateProduct(i, &req.Products[i])...)
+	}
+	return fieldValidation
+}
+
+func validateProduct(
+	index int,
+	product *CreateOnlineTransactionProductRequest,
+) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	field := func(name string) string {
+		return fmt.Sprintf("%s[%d].%s", fieldValidationFieldProducts, index, name)
+	}
+	if product.VariantID != nil {
+		if err := common.ValidateUUIDFormat(*product.VariantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("variant_id"),
+				Message: err.Error(),
+			})
+		}
+	} else {
+		if product.ProductName == "" {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("product_name"),
+				Message: "product_name is required when variant_id is empty",
+			})
+		}
+		if product.CostPrice == nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("cost_price"),
+				Message: "cost_price is required when variant_id is empty",
+			})
+		}
+	}
+	if err := common.ValidateMaxLengthStr(product.ProductName, maxLengthProductName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("product_name"),
+			Message: err.Error(),
+		})
+	}
+	if product.CostPrice != nil && product.CostPrice.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("cost_price"),
+			Message: "cost_price must not be negative",
+		})
+	}
+	if !product.SalePrice.IsPositive() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("sale_price"),
+			Message: "sale_price must be greater than 0",
+		})
+	}
+	if product.Quantity <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("quantity"),
+			Message: "quantity must be greater than 0",
+		})
+	}
+	if product.FeeAmount.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("fee_amount"),
+			Message: "fee_amount must not be negative",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) CreateOnlineTransaction(
+	ctx context.Context,
+	request *CreateOnlineTransactionRequest,
+) (*CreateOnlineTransactionResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateOnlineTransaction{
+		CreateOnlineTransactionRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	channel := repository.OnlineChannel(input.Channel)
+	if err := s.onlineTransactionRepository.LockOrderNumberTransaction(
+		ctx, tx, channel, input.OrderNumber); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	_, err = s.onlineTransactionRepository.FindIDByOrderNumber(
+		ctx, tx, channel, input.OrderNumber)
+	if err == nil {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"order number already exists for channel "+input.Channel,
+		))
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	products, err := s.mapTransactionProducts(ctx, tx, input.Products)
+	if err != nil {
+		// error is already handled by mapTransactionProducts
+		return nil, err
+	}
+	transaction := &repository.OnlineTransactionData{
+		ID:          uuid.NewString(),
+		Channel:     channel,
+		OrderNumber: input.OrderNumber,
+		CreatedDate: input.createdDate,
+		PeriodMonth: int(input.createdDate.Month()),
+		PeriodYear:  input.createdDate.Year(),
+		CreatedBy:   userID,
+		Products:    products,
+	}
+	calculateTotals(transaction)
+	if err := s.onlineTransactionRepository.InsertTransaction(
+		ctx, tx, transaction); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateOnlineTransactionResponse{
+		TransactionID:  transaction.ID,
+		TotalNetProfit: transaction.TotalNetProfit,
+	}, nil
+}
+
+// mapTransactionProducts resolves the variants referenced by the products,
+// filling the product name and cost price the client left empty
+func (s *Service) mapTransactionProducts(
+	ctx context.Context,
+	tx pgx.Tx,
+	requests []CreateOnlineTransactionProductRequest,
+) ([]repository.OnlineTransactionProductData, error) {
+	variantIDs := []string{}
+	for _, request := range requests {
+		if request.VariantID != nil {
+			variantIDs = append(variantIDs, *request.VariantID)
+		}
+	}
+	variantByID := map[string]repository.ProductVariantData{}
+	if len(variantIDs) > 0 {
+		variants, err := s.productVariantRepository.FindManyByID(ctx, tx, variantIDs)
+		if err != nil {
+			return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		for _, variant := range variants {
+			variantByID[variant.ID] = variant
+		}
+	}
+	fieldValidation := []httperror.FieldValidation{}
+	products := make([]repository.OnlineTransactionProductData, 0, len(requests))
+	for i, request := range requests {
+		product := repository.OnlineTransactionProductData{
+			ID:          uuid.NewString(),
+			ProductName: request.ProductName,
+			SalePrice:   request.SalePrice,
+			Quantity:    request.Quantity,
+			FeeAmount:   request.FeeAmount,
+		}
+		if request.CostPrice != nil {
+			product.CostPrice = *request.CostPrice
+		}
+		if request.VariantID != nil {
+			field := fmt.Sprintf("%s[%d].variant_id", fieldValidationFieldProducts, i)
+			variant, ok := variantByID[*request.VariantID]
+			if !ok {
+				fieldValidation = append(fieldValidation, httperror.FieldValidation{
+					Field:   field,
+					Message: "variant not found",
+				})
+				continue
+			}
+			product.VariantID = sql.NullString{String: variant.ID, Valid: true}
+			if product.ProductName == "" {
+				product.ProductName = truncateString(variant.FullName, maxLengthProductName)
+			}
+			if request.CostPrice == nil {
+				if !variant.CostPrice.Valid {
+					fieldValidation = append(fieldValidation, httperror.FieldValidation{
+						Field:   field,
+						Message: "variant has no cost price, cost_price is required",
+					})
+					continue
+				}
+				product.CostPrice = variant.CostPrice.Decimal
+			}
+		}
+		products = append(products, product)
+	}
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	return products, nil
+}
@@ -0,0 +1,64 @@
+package onlinetransactions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteOnlineTransaction struct {
+	*DeleteOnlineTransactionRequest
+}
+
+func (req *requestDeleteOnlineTransaction) sanitize() {
+	req.TransactionID = strings.TrimSpace(req.TransactionID)
+}
+
+func (req *requestDeleteOnlineTransaction) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.TransactionID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldTransactionID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// DeleteOnlineTransaction soft deletes the transaction so it no longer counts
+// in the summary
+func (s *Service) DeleteOnlineTransaction(
+	ctx context.Context,
+	request *DeleteOnlineTransactionRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteOnlineTransaction{
+		DeleteOnlineTransactionRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	err = s.onlineTransactionRepository.SoftDelete(ctx, input.TransactionID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"online transaction not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
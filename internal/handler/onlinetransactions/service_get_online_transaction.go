@@ -0,0 +1,58 @@
+package onlinetransactions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetOnlineTransaction struct {
+	*GetOnlineTransactionRequest
+}
+
+func (req *requestGetOnlineTransaction) sanitize() {
+	req.TransactionID = strings.TrimSpace(req.TransactionID)
+}
+
+func (req *requestGetOnlineTransaction) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.TransactionID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldTransactionID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetOnlineTransaction(
+	ctx context.Context,
+	request *GetOnlineTransactionRequest,
+) (*GetOnlineTransactionResponse, error) {
+	input := &requestGetOnlineTransaction{
+		GetOnlineTransactionRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	transaction, err := s.onlineTransactionRepository.FindByID(ctx, input.TransactionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"online transaction not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetOnlineTransactionResponse{
+		Data: toOnlineTransactionObject(transaction),
+	}, nil
+}
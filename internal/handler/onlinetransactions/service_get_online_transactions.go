@@ -0,0 +1,102 @@
+package onlinetransactions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetOnlineTransactions struct {
+	*GetOnlineTransactionsRequest
+}
+
+func (req *requestGetOnlineTransactions) sanitize() {
+	req.Channel = strings.TrimSpace(strings.ToUpper(req.Channel))
+	req.OrderNumber = strings.TrimSpace(req.OrderNumber)
+	req.StartDate = strings.TrimSpace(req.StartDate)
+	req.EndDate = strings.TrimSpace(req.EndDate)
+}
+
+func (req *requestGetOnlineTransactions) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.Channel != "" {
+		if err := common.ValidateOneOf(req.Channel, allowedChannels); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldChannel,
+				Message: err.Error(),
+			})
+		}
+	}
+	var startDate, endDate time.Time
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStartDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEndDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEndDate,
+			Message: "end_date must not be before start_date",
+		})
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetOnlineTransactions(
+	ctx context.Context,
+	request *GetOnlineTransactionsRequest,
+) (*GetOnlineTransactionsResponse, error) {
+	input := &requestGetOnlineTransactions{
+		GetOnlineTransactionsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	transactions, totalCount, err := s.onlineTransactionRepository.FindPaginated(ctx,
+		&repository.OnlineTransactionFilter{
+			Channel:     input.Channel,
+			OrderNumber: input.OrderNumber,
+			StartDate:   input.StartDate,
+			EndDate:     input.EndDate,
+			Limit:       input.PageSize,
+			Offset:      input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]OnlineTransactionObject, 0, len(transactions))
+	for i := range transactions {
+		data = append(data, toOnlineTransactionObject(&transactions[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetOnlineTransactionsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
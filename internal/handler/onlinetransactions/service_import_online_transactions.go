@@ -0,0 +1,369 @@
+package onlinetransactions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestImportOnlineTransactions struct {
+	*ImportOnlineTransactionsRequest
+}
+
+func (req *requestImportOnlineTransactions) sanitize() {
+	req.Channel = strings.TrimSpace(strings.ToUpper(req.Channel))
+	if req.Channel == "" {
+		req.Channel = string(repository.OnlineChannelShopee)
+	}
+}
+
+func (req *requestImportOnlineTransactions) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	// the column aliases follow the Shopee order export only
+	if req.Channel != string(repository.OnlineChannelShopee) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldChannel,
+			Message: "only SHOPEE order exports are supported",
+		})
+	}
+	if req.File == nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldFile,
+			Message: "file is required",
+		})
+	}
+	return fieldValidation
+}
+
+// ImportOnlineTransactions creates one online transaction per order of a
+// marketplace export. Every order is imported in its own transaction so a
+// bad order does not block the others, and orders that already exist are
+// reported instead of created again.
+func (s *Service) ImportOnlineTransactions(
+	ctx context.Context,
+	request *ImportOnlineTransactionsRequest,
+) (*ImportOnlineTransactionsResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestImportOnlineTransactions{
+		ImportOnlineTransactionsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	cells, err := readImportFile(input.FileName, input.File)
+	if err != nil {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"failed to read import file: "+err.Error(),
+		))
+	}
+	if len(cells) < 2 {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"import file has no order lines",
+		))
+	}
+	if len(cells)-1 > maxImportRows {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"import file has too many rows",
+		))
+	}
+	columns, missing := mapImportHeader(cells[0])
+	if len(missing) > 0 {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"import file is missing columns: "+strings.Join(missing, ", "),
+		))
+	}
+	rows := parseImportRows(cells, columns)
+	response := &ImportOnlineTransactionsResponse{
+		TotalRows: len(rows),
+		Rows:      []ImportRowObject{},
+	}
+	for _, orderRows := range groupImportRowsByOrder(rows) {
+		reports, err := s.importOrder(ctx, userID,
+			repository.OnlineChannel(input.Channel), orderRows)
+		if err != nil {
+			// error is already handled by importOrder
+			return nil, err
+		}
+		switch reports[0].Status {
+		case importRowStatusImported:
+			response.ImportedOrders++
+		case importRowStatusAlreadyImported, importRowStatusCancelled:
+			response.SkippedOrders++
+		default:
+			response.FailedOrders++
+		}
+		response.Rows = append(response.Rows, reports...)
+	}
+	return response, nil
+}
+
+// groupImportRowsByOrder keeps the order of first appearance, rows without an
+// order number become an order of their own
+func groupImportRowsByOrder(rows []importRow) [][]importRow {
+	orders := [][]importRow{}
+	indexByOrderNumber := map[string]int{}
+	for _, row := range rows {
+		if row.OrderNumber == "" {
+			orders = append(orders, []importRow{row})
+			continue
+		}
+		index, ok := indexByOrderNumber[row.OrderNumber]
+		if !ok {
+			index = len(orders)
+			indexByOrderNumber[row.OrderNumber] = index
+			orders = append(orders, []importRow{})
+		}
+		orders[index] = append(orders[index], row)
+	}
+	return orders
+}
+
+func (s *Service) importOrder(
+	ctx context.Context,
+	userID string,
+	channel repository.OnlineChannel,
+	rows []importRow,
+) ([]ImportRowObject, error) {
+	for _, row := range rows {
+		if row.Cancelled {
+			return newImportReports(rows, importRowStatusCancelled,
+				"order is cancelled", nil), nil
+		}
+	}
+	invalid := false
+	for _, row := range rows {
+		if row.Error != "" {
+			invalid = true
+		}
+	}
+	if invalid {
+		reports := newImportReports(rows, importRowStatusInvalid,
+			"order has invalid lines", nil)
+		for i, row := range rows {
+			if row.Error != "" {
+				reports[i].Message = row.Error
+			}
+		}
+		return reports, nil
+	}
+	orderNumber := rows[0].OrderNumber
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	if err := s.onlineTransactionRepository.LockOrderNumberTransaction(
+		ctx, tx, channel, orderNumber); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	transactionID, err := s.onlineTransactionRepository.FindIDByOrderNumber(
+		ctx, tx, channel, orderNumber)
+	if err == nil {
+		return newImportReports(rows, importRowStatusAlreadyImported,
+			"order has been imported before", &transactionID), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	matchByKey, err := s.findMarketplaceProducts(ctx, tx, channel, rows)
+	if err != nil {
+		// error is already handled by findMarketplaceProducts
+		return nil, err
+	}
+	// logs of an earlier attempt are replaced by the outcome of this one
+	if err := s.productErrorLogRepository.ResolveByOrderNumberTransaction(
+		ctx, tx, orderNumber); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	unmatched := false
+	for _, row := range rows {
+		message := unmatchedMessage(matchByKey, row)
+		if message == "" {
+			continue
+		}
+		unmatched = true
+		if err := s.productErrorLogRepository.InsertTransaction(ctx, tx,
+			&repository.ProductErrorLogData{
+				ID:           uuid.NewString(),
+				OrderNumber:  orderNumber,
+				CreatedDate:  row.CreatedDate,
+				ProductName:  truncateString(marketplaceProductLabel(row), maxLengthErrorLogProductName),
+				ErrorMessage: message,
+				CreatedBy:    userID,
+			}); err != nil {
+			return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+	}
+	if unmatched {
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		reports := newImportReports(rows, importRowStatusFailed,
+			"order not imported, other lines are unmatched", nil)
+		for i, row := range rows {
+			if message := unmatchedMessage(matchByKey, row); message != "" {
+				reports[i].Status = importRowStatusUnmatched
+				reports[i].Message = message
+			}
+		}
+		return reports, nil
+	}
+	transaction := newImportedTransaction(userID, channel, rows, matchByKey)
+	if err := s.onlineTransactionRepository.InsertTransaction(
+		ctx, tx, transaction); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return newImportReports(rows, importRowStatusImported, "", &transaction.ID), nil
+}
+
+func (s *Service) findMarketplaceProducts(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel repository.OnlineChannel,
+	rows []importRow,
+) (map[repository.MarketplaceProductKey]repository.MarketplaceProductData, error) {
+	keys := make([]repository.MarketplaceProductKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, newMarketplaceProductKey(row))
+	}
+	products, err := s.marketplaceProductRepository.FindByKeys(ctx, tx, channel, keys)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	matchByKey := map[repository.MarketplaceProductKey]repository.MarketplaceProductData{}
+	for _, product := range products {
+		matchByKey[product.MarketplaceProductKey] = product
+	}
+	return matchByKey, nil
+}
+
+// newImportedTransaction builds the transaction of a fully matched order. The
+// order fee is spread over the lines by their share of the sale amount, the
+// last line takes the rounding difference.
+func newImportedTransaction(
+	userID string,
+	channel repository.OnlineChannel,
+	rows []importRow,
+	matchByKey map[repository.MarketplaceProductKey]repository.MarketplaceProductData,
+) *repository.OnlineTransactionData {
+	createdDate := rows[0].CreatedDate
+	orderFee := decimal.Zero
+	totalSale := decimal.Zero
+	for _, row := range rows {
+		if orderFee.IsZero() {
+			orderFee = row.FeeAmount
+		}
+		totalSale = totalSale.Add(row.SalePrice.Mul(decimal.NewFromInt(int64(row.Quantity))))
+	}
+	transaction := &repository.OnlineTransactionData{
+		ID:          uuid.NewString(),
+		Channel:     channel,
+		OrderNumber: rows[0].OrderNumber,
+		CreatedDate: createdDate,
+		PeriodMonth: int(createdDate.Month()),
+		PeriodYear:  createdDate.Year(),
+		CreatedBy:   userID,
+	}
+	remainingFee := orderFee
+	for i, row := range rows {
+		match := matchByKey[newMarketplaceProductKey(row)]
+		lineSale := row.SalePrice.Mul(decimal.NewFromInt(int64(row.Quantity)))
+		fee := remainingFee
+		if i < len(rows)-1 && totalSale.IsPositive() {
+			fee = orderFee.Mul(lineSale).Div(totalSale).Round(2)
+		}
+		remainingFee = remainingFee.Sub(fee)
+		transaction.Products = append(transaction.Products,
+			repository.OnlineTransactionProductData{
+				ID:          uuid.NewString(),
+				VariantID:   match.VariantID,
+				ProductName: truncateString(match.ProductName, maxLengthProductName),
+				CostPrice:   match.CostPrice.Decimal,
+				SalePrice:   row.SalePrice,
+				Quantity:    row.Quantity,
+				FeeAmount:   fee,
+			})
+	}
+	calculateTotals(transaction)
+	return transaction
+}
+
+// unmatchedMessage explains why a line can not be imported, empty when the
+// line matches a product with a cost price. A line without a cost price is
+// unmatched, a zero cost would overstate the net profit.
+func unmatchedMessage(
+	matchByKey map[repository.MarketplaceProductKey]repository.MarketplaceProductData,
+	row importRow,
+) string {
+	match, ok := matchByKey[newMarketplaceProductKey(row)]
+	if !ok {
+		return "no product matches the marketplace name"
+	}
+	if !match.CostPrice.Valid {
+		return "variant has no cost price"
+	}
+	return ""
+}
+
+func newMarketplaceProductKey(row importRow) repository.MarketplaceProductKey {
+	return repository.MarketplaceProductKey{
+		ListingName:   strings.ToLower(strings.TrimSpace(row.ProductName)),
+		VariationName: strings.ToLower(strings.TrimSpace(row.VariationName)),
+	}
+}
+
+// marketplaceProductLabel is the product name written to the error log
+func marketplaceProductLabel(row importRow) string {
+	if row.VariationName == "" {
+		return row.ProductName
+	}
+	return row.ProductName + " - " + row.VariationName
+}
+
+func newImportReports(
+	rows []importRow,
+	status, message string,
+	transactionID *string,
+) []ImportRowObject {
+	reports := make([]ImportRowObject, 0, len(rows))
+	for _, row := range rows {
+		reports = append(reports, ImportRowObject{
+			RowNumber:     row.RowNumber,
+			OrderNumber:   row.OrderNumber,
+			ProductName:   row.ProductName,
+			VariationName: row.VariationName,
+			Status:        status,
+			Message:       message,
+			TransactionID: transactionID,
+		})
+	}
+	return reports
+}
@@ -0,0 +1,102 @@
+package onlinetransactions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewImportedTransaction(t *testing.T) {
+	type line struct {
+		productName string
+		salePrice   string
+		quantity    int
+		feeAmount   string
+	}
+	tests := []struct {
+		name      string
+		lines     []line
+		wantFees  []string
+		wantTotal string
+	}{
+		{
+			name:      "single line takes the whole fee",
+			lines:     []line{{"a", "10000", 2, "1500"}},
+			wantFees:  []string{"1500"},
+			wantTotal: "1500",
+		},
+		{
+			name: "fee is spread by the share of the sale amount",
+			lines: []line{
+				{"a", "10000", 3, "1000"},
+				{"b", "10000", 1, "1000"},
+			},
+			wantFees:  []string{"750", "250"},
+			wantTotal: "1000",
+		},
+		{
+			name: "last line takes the rounding difference",
+			lines: []line{
+				{"a", "1000", 1, "100"},
+				{"b", "1000", 1, "100"},
+				{"c", "1000", 1, "100"},
+			},
+			wantFees:  []string{"33.33", "33.33", "33.34"},
+			wantTotal: "100",
+		},
+		{
+			name: "fee is read from the first line that has one",
+			lines: []line{
+				{"a", "2000", 1, "0"},
+				{"b", "2000", 1, "90"},
+			},
+			wantFees:  []string{"45", "45"},
+			wantTotal: "90",
+		},
+		{
+			name: "zero sale amount leaves the fee on the last line",
+			lines: []line{
+				{"a", "0", 1, "50"},
+				{"b", "0", 1, "50"},
+			},
+			wantFees:  []string{"50", "0"},
+			wantTotal: "50",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createdDate := time.Date(2026, time.March, 5, 10, 0, 0, 0, time.UTC)
+			matchByKey := map[repository.MarketplaceProductKey]repository.MarketplaceProductData{}
+			var rows []importRow
+			for _, l := range tt.lines {
+				row := importRow{
+					OrderNumber: "ORDER-1",
+					CreatedDate: createdDate,
+					ProductName: l.productName,
+					SalePrice:   decimal.RequireFromString(l.salePrice),
+					Quantity:    l.quantity,
+					FeeAmount:   decimal.RequireFromString(l.feeAmount),
+				}
+				rows = append(rows, row)
+				matchByKey[newMarketplaceProductKey(row)] = repository.MarketplaceProductData{
+					ProductName: l.productName,
+					CostPrice:   decimal.NewNullDecimal(decimal.NewFromInt(500)),
+				}
+			}
+			transaction := newImportedTransaction("user-1", repository.OnlineChannelShopee, rows, matchByKey)
+			if len(transaction.Products) != len(tt.wantFees) {
+				t.Fatalf("got %d lines, want %d", len(transaction.Products), len(tt.wantFees))
+			}
+			for i, want := range tt.wantFees {
+				assertDecimal(t, "line "+tt.lines[i].productName+" fee",
+					transaction.Products[i].FeeAmount, want)
+			}
+			assertDecimal(t, "total fee", transaction.TotalFeeAmount, tt.wantTotal)
+			if transaction.PeriodMonth != 3 || transaction.PeriodYear != 2026 {
+				t.Errorf("period = %d/%d, want 3/2026", transaction.PeriodMonth, transaction.PeriodYear)
+			}
+		})
+	}
+}
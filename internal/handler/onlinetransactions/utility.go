@@ -0,0 +1,86 @@
+package onlinetransactions
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var allowedChannels = []string{
+	string(repository.OnlineChannelShopee),
+	string(repository.OnlineChannelLazada),
+	string(repository.OnlineChannelTokopedia),
+	string(repository.OnlineChannelTiktok),
+}
+
+// lineNetProfit is what the shop keeps from one line after the product cost
+// and the marketplace fee
+func lineNetProfit(product *repository.OnlineTransactionProductData) decimal.Decimal {
+	quantity := decimal.NewFromInt(int64(product.Quantity))
+	return product.SalePrice.Mul(quantity).
+		Sub(product.CostPrice.Mul(quantity)).
+		Sub(product.FeeAmount)
+}
+
+// calculateTotals fills the transaction totals from its products, the
+// totals are never taken from the client
+func calculateTotals(transaction *repository.OnlineTransactionData) {
+	totalBase := decimal.Zero
+	totalSale := decimal.Zero
+	totalFee := decimal.Zero
+	for i := range transaction.Products {
+		product := &transaction.Products[i]
+		quantity := decimal.NewFromInt(int64(product.Quantity))
+		totalBase = totalBase.Add(product.CostPrice.Mul(quantity))
+		totalSale = totalSale.Add(product.SalePrice.Mul(quantity))
+		totalFee = totalFee.Add(product.FeeAmount)
+	}
+	transaction.TotalBaseAmount = totalBase.Round(2)
+	transaction.TotalSaleAmount = totalSale.Round(2)
+	transaction.TotalFeeAmount = totalFee.Round(2)
+	transaction.TotalNetProfit = totalSale.Sub(totalBase).Sub(totalFee).Round(2)
+}
+
+func toOnlineTransactionObject(
+	transaction *repository.OnlineTransactionData,
+) OnlineTransactionObject {
+	object := OnlineTransactionObject{
+		TransactionID:   transaction.ID,
+		Channel:         string(transaction.Channel),
+		OrderNumber:     transaction.OrderNumber,
+		CreatedDate:     transaction.CreatedDate.Format(dateLayout),
+		PeriodMonth:     transaction.PeriodMonth,
+		PeriodYear:      transaction.PeriodYear,
+		TotalBaseAmount: transaction.TotalBaseAmount,
+		TotalSaleAmount: transaction.TotalSaleAmount,
+		TotalFeeAmount:  transaction.TotalFeeAmount,
+		TotalNetProfit:  transaction.TotalNetProfit,
+		CreatedBy:       transaction.CreatedBy,
+		CreatedAt:       transaction.CreatedAt,
+	}
+	for i := range transaction.Products {
+		product := &transaction.Products[i]
+		productObject := OnlineTransactionProductObject{
+			ProductID:   product.ID,
+			ProductName: product.ProductName,
+			CostPrice:   product.CostPrice,
+			SalePrice:   product.SalePrice,
+			Quantity:    product.Quantity,
+			FeeAmount:   product.FeeAmount,
+			NetProfit:   lineNetProfit(product).Round(2),
+		}
+		if product.VariantID.Valid {
+			productObject.VariantID = &product.VariantID.String
+		}
+		object.Products = append(object.Products, productObject)
+	}
+	return object
+}
+
+// truncateString cuts str to maxLength characters so it fits a VARCHAR column
+func truncateString(str string, maxLength int) string {
+	runes := []rune(str)
+	if len(runes) > maxLength {
+		return string(runes[:maxLength])
+	}
+	return str
+}
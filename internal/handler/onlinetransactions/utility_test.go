@@ -0,0 +1,86 @@
+package onlinetransactions
+
+import (
+	"testing"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateTotals(t *testing.T) {
+	type line struct {
+		costPrice string
+		salePrice string
+		quantity  int
+		feeAmount string
+	}
+	tests := []struct {
+		name          string
+		lines         []line
+		wantBase      string
+		wantSale      string
+		wantFee       string
+		wantNetProfit string
+	}{
+		{
+			name:          "no lines",
+			wantBase:      "0",
+			wantSale:      "0",
+			wantFee:       "0",
+			wantNetProfit: "0",
+		},
+		{
+			name:          "single line",
+			lines:         []line{{"8000", "10000", 2, "1500"}},
+			wantBase:      "16000",
+			wantSale:      "20000",
+			wantFee:       "1500",
+			wantNetProfit: "2500",
+		},
+		{
+			name: "several lines are summed before rounding",
+			lines: []line{
+				{"1000.125", "1500", 1, "100.10"},
+				{"1000.125", "1500", 1, "100.10"},
+			},
+			wantBase:      "2000.25",
+			wantSale:      "3000",
+			wantFee:       "200.2",
+			wantNetProfit: "799.55",
+		},
+		{
+			name:          "fees above the margin give a loss",
+			lines:         []line{{"9000", "10000", 1, "1500"}},
+			wantBase:      "9000",
+			wantSale:      "10000",
+			wantFee:       "1500",
+			wantNetProfit: "-500",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &repository.OnlineTransactionData{}
+			for _, l := range tt.lines {
+				transaction.Products = append(transaction.Products,
+					repository.OnlineTransactionProductData{
+						CostPrice: decimal.RequireFromString(l.costPrice),
+						SalePrice: decimal.RequireFromString(l.salePrice),
+						Quantity:  l.quantity,
+						FeeAmount: decimal.RequireFromString(l.feeAmount),
+					})
+			}
+			calculateTotals(transaction)
+			assertDecimal(t, "total base", transaction.TotalBaseAmount, tt.wantBase)
+			assertDecimal(t, "total sale", transaction.TotalSaleAmount, tt.wantSale)
+			assertDecimal(t, "total fee", transaction.TotalFeeAmount, tt.wantFee)
+			assertDecimal(t, "net profit", transaction.TotalNetProfit, tt.wantNetProfit)
+		})
+	}
+}
+
+func assertDecimal(t *testing.T, field string, got decimal.Decimal, want string) {
+	t.Helper()
+	if !got.Equal(decimal.RequireFromString(want)) {
+		t.Errorf("%s = %s, want %s", field, got, want)
+	}
+}
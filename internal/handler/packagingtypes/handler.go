@@ -22,8 +22,7 @@ func NewHandler(packagingTypeRepo repository.PackagingType) *Handler {
 }
 
 // RegisterRoutes registers all category related routes
-func (h *Handler) RegisterRoutes(
-	router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 
 	endpoint := router.Group("/api/v1/packaging-types")
 	{
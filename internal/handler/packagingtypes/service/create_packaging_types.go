@@ -10,6 +10,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/packagingtypes/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/packagingtypes/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -44,6 +45,11 @@ func (req *reqCreatePackagingType) validateFields(ctx context.Context) error {
 }
 
 func (s *PackagingType) CreatePackagingType(ctx context.Context, request *model.RequestCreatePackagingType) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqCreatePackagingType{
 		RequestCreatePackagingType: request,
 	}
@@ -56,8 +62,8 @@ func (s *PackagingType) CreatePackagingType(ctx context.Context, request *model.
 		ID:        uuid.NewString(),
 		Name:      input.PackagingName,
 		Code:      input.PackagingCode,
-		CreatedBy: "SYSTEM",
-		UpdatedBy: "SYSTEM",
+		CreatedBy: userID,
+		UpdatedBy: userID,
 	}
 
 	// Handle description field
@@ -11,6 +11,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/packagingtypes/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/packagingtypes/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -45,6 +46,11 @@ func (req *reqUpdatePackagingType) validateFields(ctx context.Context) error {
 func (s *PackagingType) UpdatePackagingType(
 	ctx context.Context,
 	data *model.RequestUpdatePackagingType) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqUpdatePackagingType{
 		RequestUpdatePackagingType: data,
 	}
@@ -56,7 +62,7 @@ func (s *PackagingType) UpdatePackagingType(
 		ID:        input.PackagingID,
 		Name:      input.PackagingName,
 		IsActive:  input.IsActive,
-		UpdatedBy: "SYSTEM",
+		UpdatedBy: userID,
 	}
 	if input.PackagingDescription != nil {
 		updatedData.Description = sql.NullString{String: *input.PackagingDescription, Valid: true}
@@ -0,0 +1,17 @@
+package pricechanges
+
+const (
+	fieldValidationFieldVariantID    = "variant_id"
+	fieldValidationFieldScheduleID   = "schedule_id"
+	fieldValidationFieldSource       = "source"
+	fieldValidationFieldStatus       = "status"
+	fieldValidationFieldStartDate    = "start_date"
+	fieldValidationFieldEndDate      = "end_date"
+	fieldValidationFieldEffectiveAt  = "effective_at"
+	fieldValidationFieldCostPrice    = "cost_price"
+	fieldValidationFieldSellingPrice = "selling_price"
+	fieldValidationFieldReason       = "reason"
+
+	dateLayout      = "2006-01-02"
+	maxLengthReason = 255
+)
@@ -0,0 +1,101 @@
+package pricechanges
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service PriceChangeService
+}
+
+func NewHandler(service PriceChangeService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/variants")
+	endpoint.GET("/:variant_id/price-history", h.GetPriceHistory)
+	endpoint.POST("/:variant_id/price-schedules", h.CreatePriceSchedule)
+	endpoint.GET("/:variant_id/price-schedules", h.GetPriceSchedules)
+	endpoint.POST("/:variant_id/price-schedules/:schedule_id/cancel",
+		h.CancelPriceSchedule)
+}
+
+// GetPriceHistory takes the optional source, start_date and end_date as query
+// parameters
+func (h *Handler) GetPriceHistory(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetPriceHistoryRequest{
+		PaginationData: *pagination,
+		VariantID:      c.Param("variant_id"),
+		Source:         c.Query("source"),
+		StartDate:      c.Query("start_date"),
+		EndDate:        c.Query("end_date"),
+	}
+	response, err := h.service.GetPriceHistory(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) CreatePriceSchedule(c *gin.Context) {
+	request := &CreatePriceScheduleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.VariantID = c.Param("variant_id")
+	response, err := h.service.CreatePriceSchedule(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetPriceSchedules takes the optional status as query parameter
+func (h *Handler) GetPriceSchedules(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetPriceSchedulesRequest{
+		PaginationData: *pagination,
+		VariantID:      c.Param("variant_id"),
+		Status:         c.Query("status"),
+	}
+	response, err := h.service.GetPriceSchedules(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) CancelPriceSchedule(c *gin.Context) {
+	request := &CancelPriceScheduleRequest{
+		VariantID:  c.Param("variant_id"),
+		ScheduleID: c.Param("schedule_id"),
+	}
+	if err := h.service.CancelPriceSchedule(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,56 @@
+package pricechanges
+
+import (
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type GetPriceHistoryRequest struct {
+	util.PaginationData `json:"pagination"`
+	VariantID           string `json:"variant_id"`
+	Source              string `json:"source"`
+	// StartDate and EndDate are inclusive dates in YYYY-MM-DD format
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type GetPriceHistoryResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []PriceHistoryObject `json:"data"`
+}
+
+type CreatePriceScheduleRequest struct {
+	VariantID string `json:"-"`
+	// CostPrice or SellingPrice is nil when the current price is kept, at
+	// least one of them is required
+	CostPrice    *decimal.Decimal `json:"cost_price"`
+	SellingPrice *decimal.Decimal `json:"selling_price"`
+	// EffectiveAt is an RFC3339 timestamp in the future
+	EffectiveAt string  `json:"effective_at"`
+	Reason      *string `json:"reason"`
+
+	// effectiveAt is parsed by validateField
+	effectiveAt time.Time
+}
+
+type CreatePriceScheduleResponse struct {
+	Data PriceScheduleObject `json:"data"`
+}
+
+type GetPriceSchedulesRequest struct {
+	util.PaginationData `json:"pagination"`
+	VariantID           string `json:"variant_id"`
+	Status              string `json:"status"`
+}
+
+type GetPriceSchedulesResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []PriceScheduleObject `json:"data"`
+}
+
+type CancelPriceScheduleRequest struct {
+	VariantID  string `json:"variant_id"`
+	ScheduleID string `json:"schedule_id"`
+}
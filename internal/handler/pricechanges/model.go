@@ -0,0 +1,37 @@
+package pricechanges
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type PriceHistoryObject struct {
+	ID              string           `json:"id"`
+	VariantID       string           `json:"variant_id"`
+	OldCostPrice    *decimal.Decimal `json:"old_cost_price"`
+	NewCostPrice    *decimal.Decimal `json:"new_cost_price"`
+	OldSellingPrice decimal.Decimal  `json:"old_selling_price"`
+	NewSellingPrice decimal.Decimal  `json:"new_selling_price"`
+	Source          string           `json:"source"`
+	Reason          *string          `json:"reason"`
+	ScheduleID      *string          `json:"schedule_id"`
+	ChangedBy       string           `json:"changed_by"`
+	ChangedAt       time.Time        `json:"changed_at"`
+}
+
+type PriceScheduleObject struct {
+	ID           string           `json:"id"`
+	VariantID    string           `json:"variant_id"`
+	CostPrice    *decimal.Decimal `json:"cost_price"`
+	SellingPrice *decimal.Decimal `json:"selling_price"`
+	EffectiveAt  time.Time        `json:"effective_at"`
+	Reason       *string          `json:"reason"`
+	Status       string           `json:"status"`
+	AppliedAt    *time.Time       `json:"applied_at"`
+	CreatedBy    string           `json:"created_by"`
+	CancelledBy  *string          `json:"cancelled_by"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	CancelledAt  *time.Time       `json:"cancelled_at"`
+}
@@ -0,0 +1,48 @@
+package pricechanges
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type PriceChangeService interface {
+	GetPriceHistory(
+		ctx context.Context,
+		request *GetPriceHistoryRequest,
+	) (*GetPriceHistoryResponse, error)
+	CreatePriceSchedule(
+		ctx context.Context,
+		request *CreatePriceScheduleRequest,
+	) (*CreatePriceScheduleResponse, error)
+	GetPriceSchedules(
+		ctx context.Context,
+		request *GetPriceSchedulesRequest,
+	) (*GetPriceSchedulesResponse, error)
+	CancelPriceSchedule(ctx context.Context, request *CancelPriceScheduleRequest) error
+}
+
+type Service struct {
+	db                             *pgxpool.Pool
+	productVariantRepository       repository.ProductVariant
+	repackRecipeRepository         repository.RepackRecipe
+	variantPriceHistoryRepository  repository.VariantPriceHistory
+	variantPriceScheduleRepository repository.VariantPriceSchedule
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	productVariantRepository repository.ProductVariant,
+	repackRecipeRepository repository.RepackRecipe,
+	variantPriceHistoryRepository repository.VariantPriceHistory,
+	variantPriceScheduleRepository repository.VariantPriceSchedule,
+) PriceChangeService {
+	return &Service{
+		db:                             db,
+		productVariantRepository:       productVariantRepository,
+		repackRecipeRepository:         repackRecipeRepository,
+		variantPriceHistoryRepository:  variantPriceHistoryRepository,
+		variantPriceScheduleRepository: variantPriceScheduleRepository,
+	}
+}
@@ -0,0 +1,80 @@
+package pricechanges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCancelPriceSchedule struct {
+	*CancelPriceScheduleRequest
+}
+
+func (req *requestCancelPriceSchedule) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.ScheduleID = strings.TrimSpace(req.ScheduleID)
+}
+
+func (req *requestCancelPriceSchedule) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateUUIDFormat(req.ScheduleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldScheduleID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// CancelPriceSchedule cancels a schedule that is not applied yet
+func (s *Service) CancelPriceSchedule(
+	ctx context.Context,
+	request *CancelPriceScheduleRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestCancelPriceSchedule{
+		CancelPriceScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := s.variantPriceScheduleRepository.CancelTransaction(
+		ctx, tx, input.VariantID, input.ScheduleID,
+		sql.NullString{String: userID, Valid: true}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"pending price schedule not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
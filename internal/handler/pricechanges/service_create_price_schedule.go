@@ -0,0 +1,185 @@
+package pricechanges
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestCreatePriceSchedule struct {
+	*CreatePriceScheduleRequest
+}
+
+func (req *requestCreatePriceSchedule) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.EffectiveAt = strings.TrimSpace(req.EffectiveAt)
+	if req.Reason != nil {
+		*req.Reason = strings.TrimSpace(*req.Reason)
+	}
+}
+
+func (req *requestCreatePriceSchedule) validateField(
+	now time.Time,
+) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if req.CostPrice == nil && req.SellingPrice == nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSellingPrice,
+			Message: "cost_price or selling_price is required",
+		})
+	}
+	prices := []struct {
+		field string
+		value *decimal.Decimal
+	}{
+		{fieldValidationFieldCostPrice, req.CostPrice},
+		{fieldValidationFieldSellingPrice, req.SellingPrice},
+	}
+	for _, price := range prices {
+		if price.value != nil && !price.value.IsPositive() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   price.field,
+				Message: price.field + " must be greater than 0",
+			})
+		}
+	}
+	if req.CostPrice != nil && req.SellingPrice != nil &&
+		req.CostPrice.GreaterThan(*req.SellingPrice) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldCostPrice,
+			Message: "cost_price must be less than selling_price",
+		})
+	}
+	effectiveAt, err := time.Parse(time.RFC3339, req.EffectiveAt)
+	if err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEffectiveAt,
+			Message: "invalid timestamp format, use RFC3339",
+		})
+	} else if !effectiveAt.After(now) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEffectiveAt,
+			Message: "effective_at must be in the future",
+		})
+	}
+	req.effectiveAt = effectiveAt
+	if req.Reason != nil {
+		if err := common.ValidateMaxLengthStr(*req.Reason, maxLengthReason); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldReason,
+				Message: err.Error(),
+			})
+		}
+	}
+	return fieldValidation
+}
+
+// validateStoredPrices checks a schedule that changes only one price against
+// the other price stored on the variant, the scheduler would otherwise leave
+// the variant with a cost price above its selling price
+func (req *requestCreatePriceSchedule) validateStoredPrices(
+	variant *repository.ProductVariantData,
+) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.CostPrice == nil && variant.CostPrice.Valid &&
+		req.SellingPrice.LessThan(variant.CostPrice.Decimal) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field: fieldValidationFieldSellingPrice,
+			Message: "selling_price must not be less than the current cost_price " +
+				variant.CostPrice.Decimal.String(),
+		})
+	}
+	if req.SellingPrice == nil &&
+		req.CostPrice.GreaterThan(variant.SellingPrice) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field: fieldValidationFieldCostPrice,
+			Message: "cost_price must not be greater than the current selling_price " +
+				variant.SellingPrice.String(),
+		})
+	}
+	return fieldValidation
+}
+
+// CreatePriceSchedule schedules a price change of an active variant, the
+// background scheduler applies it once effective_at has passed
+func (s *Service) CreatePriceSchedule(
+	ctx context.Context,
+	request *CreatePriceScheduleRequest,
+) (*CreatePriceScheduleResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreatePriceSchedule{
+		CreatePriceScheduleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField(time.Now())
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	variant, err := s.findActiveVariant(ctx, tx, input.VariantID)
+	if err != nil {
+		// error is already handled by findActiveVariant
+		return nil, err
+	}
+	fieldValidation = input.validateStoredPrices(variant)
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if input.CostPrice != nil {
+		// the cost price of a repacked variant follows its parent, a scheduled
+		// cost would be overwritten by the next recompute
+		recipes, err := s.repackRecipeRepository.FindActiveByChildVariantIDs(
+			ctx, tx, []string{variant.ID})
+		if err != nil {
+			return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		if len(recipes) > 0 {
+			return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"cost price of a repacked variant is derived from its parent "+
+					"and can not be scheduled",
+			))
+		}
+	}
+	schedule := &repository.VariantPriceScheduleData{
+		ID:           uuid.NewString(),
+		VariantID:    variant.ID,
+		CostPrice:    toNullDecimal(input.CostPrice),
+		SellingPrice: toNullDecimal(input.SellingPrice),
+		EffectiveAt:  input.effectiveAt,
+		Reason:       toNullString(input.Reason),
+		Status:       repository.VariantPriceScheduleStatusPending,
+		CreatedBy:    userID,
+	}
+	if err := s.variantPriceScheduleRepository.Insert(ctx, schedule); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &CreatePriceScheduleResponse{
+		Data: toPriceScheduleObject(schedule),
+	}, nil
+}
@@ -0,0 +1,110 @@
+package pricechanges
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetPriceHistory struct {
+	*GetPriceHistoryRequest
+}
+
+func (req *requestGetPriceHistory) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.Source = strings.TrimSpace(strings.ToUpper(req.Source))
+	req.StartDate = strings.TrimSpace(req.StartDate)
+	req.EndDate = strings.TrimSpace(req.EndDate)
+}
+
+func (req *requestGetPriceHistory) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if req.Source != "" {
+		if err := common.ValidateOneOf(req.Source, allowedSources); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldSource,
+				Message: err.Error(),
+			})
+		}
+	}
+	var startDate, endDate time.Time
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStartDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEndDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEndDate,
+			Message: "end_date must not be before start_date",
+		})
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+// GetPriceHistory lists the price changes of a variant, including deleted
+// and inactive variants, newest first
+func (s *Service) GetPriceHistory(
+	ctx context.Context,
+	request *GetPriceHistoryRequest,
+) (*GetPriceHistoryResponse, error) {
+	input := &requestGetPriceHistory{
+		GetPriceHistoryRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	history, totalCount, err := s.variantPriceHistoryRepository.FindPaginated(ctx,
+		&repository.VariantPriceHistoryFilter{
+			VariantID: input.VariantID,
+			Source:    input.Source,
+			StartDate: input.StartDate,
+			EndDate:   input.EndDate,
+			Limit:     input.PageSize,
+			Offset:    input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]PriceHistoryObject, 0, len(history))
+	for i := range history {
+		data = append(data, toPriceHistoryObject(&history[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetPriceHistoryResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package pricechanges
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetPriceSchedules struct {
+	*GetPriceSchedulesRequest
+}
+
+func (req *requestGetPriceSchedules) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+}
+
+func (req *requestGetPriceSchedules) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, allowedScheduleStatuses); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+// GetPriceSchedules lists the scheduled price changes of a variant ordered by
+// effective time, status PENDING lists the ones not applied yet
+func (s *Service) GetPriceSchedules(
+	ctx context.Context,
+	request *GetPriceSchedulesRequest,
+) (*GetPriceSchedulesResponse, error) {
+	input := &requestGetPriceSchedules{
+		GetPriceSchedulesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	schedules, totalCount, err := s.variantPriceScheduleRepository.FindPaginated(ctx,
+		&repository.VariantPriceScheduleFilter{
+			VariantID: input.VariantID,
+			Status:    input.Status,
+			Limit:     input.PageSize,
+			Offset:    input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]PriceScheduleObject, 0, len(schedules))
+	for i := range schedules {
+		data = append(data, toPriceScheduleObject(&schedules[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetPriceSchedulesResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
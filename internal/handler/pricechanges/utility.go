@@ -0,0 +1,115 @@
+package pricechanges
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+var allowedSources = []string{
+	string(repository.VariantPriceChangeSourceManual),
+	string(repository.VariantPriceChangeSourceRepackCost),
+	string(repository.VariantPriceChangeSourceGoodsReceipt),
+	string(repository.VariantPriceChangeSourceScheduled),
+}
+
+var allowedScheduleStatuses = []string{
+	string(repository.VariantPriceScheduleStatusPending),
+	string(repository.VariantPriceScheduleStatusApplied),
+	string(repository.VariantPriceScheduleStatusCancelled),
+}
+
+// findActiveVariant maps a missing or inactive variant to not found
+func (s *Service) findActiveVariant(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+) (*repository.ProductVariantData, error) {
+	variants, err := s.productVariantRepository.FindManyByID(
+		ctx, tx, []string{variantID})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(variants) == 0 {
+		return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"variant not found",
+		))
+	}
+	return &variants[0], nil
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil || *value == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+func toNullDecimal(value *decimal.Decimal) decimal.NullDecimal {
+	if value == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: value.Round(2), Valid: true}
+}
+
+func fromNullDecimal(value decimal.NullDecimal) *decimal.Decimal {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Decimal
+}
+
+func fromNullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func toPriceHistoryObject(change *repository.VariantPriceHistoryData) PriceHistoryObject {
+	return PriceHistoryObject{
+		ID:              change.ID,
+		VariantID:       change.VariantID,
+		OldCostPrice:    fromNullDecimal(change.OldCostPrice),
+		NewCostPrice:    fromNullDecimal(change.NewCostPrice),
+		OldSellingPrice: change.OldSellingPrice,
+		NewSellingPrice: change.NewSellingPrice,
+		Source:          string(change.Source),
+		Reason:          fromNullString(change.Reason),
+		ScheduleID:      fromNullString(change.ScheduleID),
+		ChangedBy:       change.ChangedBy,
+		ChangedAt:       change.ChangedAt,
+	}
+}
+
+func toPriceScheduleObject(schedule *repository.VariantPriceScheduleData) PriceScheduleObject {
+	return PriceScheduleObject{
+		ID:           schedule.ID,
+		VariantID:    schedule.VariantID,
+		CostPrice:    fromNullDecimal(schedule.CostPrice),
+		SellingPrice: fromNullDecimal(schedule.SellingPrice),
+		EffectiveAt:  schedule.EffectiveAt,
+		Reason:       fromNullString(schedule.Reason),
+		Status:       string(schedule.Status),
+		AppliedAt:    fromNullTime(schedule.AppliedAt),
+		CreatedBy:    schedule.CreatedBy,
+		CancelledBy:  fromNullString(schedule.CancelledBy),
+		CreatedAt:    schedule.CreatedAt,
+		UpdatedAt:    schedule.UpdatedAt,
+		CancelledAt:  fromNullTime(schedule.CancelledAt),
+	}
+}
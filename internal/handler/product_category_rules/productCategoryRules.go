@@ -20,7 +20,7 @@ func NewHandler(productCategoryRules repository.ProductCategoryRules) *Handler {
 	return &Handler{service: service}
 }
 
-func (h *Handler) RegisterRoutes(router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	endpoint := router.Group("/api/v1/categories-rules")
 
 	endpoint.POST("/:product_category_id/packaging-rules", h.PostPackagingRules)
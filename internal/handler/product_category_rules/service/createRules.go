@@ -8,6 +8,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/product_category_rules/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/product_category_rules/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -42,6 +43,11 @@ func (s *ProductCategoryRules) CreateRules(
 	ctx context.Context,
 	request *model.CreateRulesRequest,
 ) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqCreateRules{
 		CreateRulesRequest: request,
 	}
@@ -53,9 +59,9 @@ func (s *ProductCategoryRules) CreateRules(
 		RuleID:          uuid.NewString(),
 		PackagingTypeID: input.PackagingTypeID,
 		CategoryID:      input.ProductCategoryID,
-		CreatedBy:       "SYSTEM",
+		CreatedBy:       userID,
 		IsDefault:       false,
-		UpdatedBy:       "SYSTEM",
+		UpdatedBy:       userID,
 	}
 	if input.IsDefault != nil {
 		insertedData.IsDefault = *input.IsDefault
@@ -7,6 +7,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/product_category_rules/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/product_category_rules/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -49,6 +50,11 @@ func (s *ProductCategoryRules) UpdateRules(
 	ctx context.Context,
 	request *model.UpdateRulesRequest,
 ) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqUpdateRules{
 		UpdateRulesRequest: request,
 	}
@@ -61,7 +67,7 @@ func (s *ProductCategoryRules) UpdateRules(
 		PackagingTypeID: input.PackagingTypeID,
 		CategoryID:      input.ProductCategoryID,
 		IsDefault:       input.IsDefault,
-		UpdatedBy:       "SYSTEM",
+		UpdatedBy:       userID,
 	}
 	if err := s.ProductCategoryRules.UpdateTransaction(ctx, updatedData); err != nil {
 		return handleRepositoryError(ctx, err)
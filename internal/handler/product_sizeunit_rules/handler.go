@@ -18,7 +18,7 @@ func NewHandler(productSizeUnitRulesRepository repository.ProductSizeUnitRules)
 	service := service.NewProductSizeUnitRulesService(productSizeUnitRulesRepository)
 	return &Handler{service: service}
 }
-func (h *Handler) RegisterRoutes(router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	endpoint := router.Group("/api/v1/categories-rules")
 
 	endpoint.POST("/:product_category_id/size-unit-rules", h.PostSizeUnitRules)
@@ -8,6 +8,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -45,7 +46,11 @@ func (s *ProductSizeUnitRulesService) CreateRule(
 	ctx context.Context,
 	request *model.CreateSizeUnitRulesRequest,
 ) error {
-	userID := ctx.Value("userID").(string)
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqCreateSizeUnitRules{
 		CreateSizeUnitRulesRequest: request,
 	}
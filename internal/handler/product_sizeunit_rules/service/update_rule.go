@@ -7,6 +7,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -42,7 +43,11 @@ func (s *ProductSizeUnitRulesService) UpdateRule(
 	ctx context.Context,
 	request *model.UpdateSizeUnitRulesRequest,
 ) error {
-	userID := ctx.Value("userID").(string)
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqUpdateRule{
 		UpdateSizeUnitRulesRequest: request,
 	}
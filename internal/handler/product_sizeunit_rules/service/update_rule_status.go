@@ -5,13 +5,18 @@ import (
 	"strings"
 
 	"github.com/rizkysr90/rizkiplastik-be/internal/model"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 )
 
 func (s *ProductSizeUnitRulesService) UpdateRuleStatus(
 	ctx context.Context,
 	request *model.UpdateSizeUnitRulesStatusRequest,
 ) error {
-	userID := ctx.Value("userID").(string)
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	request.RuleID = strings.TrimSpace(request.RuleID)
 	if err := s.productSizeUnitRulesRepository.UpdateStatusRule(
 		ctx, request.RuleID, request.Status, userID); err != nil {
@@ -16,4 +16,15 @@ const (
 	fieldValidationFieldRepackTimeMinutes = "repack_time_minutes"
 	fieldValidationFieldVariantName       = "variant_name"
 	fieldValidationFieldProductID         = "product_id"
+	fieldValidationFieldVariantID         = "variant_id"
+	fieldValidationFieldIsActive          = "is_active"
+	fieldValidationFieldName              = "name"
+	fieldValidationFieldRecipeID          = "recipe_id"
+	fieldValidationFieldCustomerID        = "customer_id"
+	fieldValidationFieldQuantity          = "quantity"
+	fieldValidationFieldPriceChangeReason = "price_change_reason"
+)
+
+const (
+	maxLengthPriceChangeReason = 255
 )
@@ -0,0 +1,108 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+func (query *PriceQuery) sanitize() {
+	query.CustomerID = strings.TrimSpace(query.CustomerID)
+	query.Quantity = strings.TrimSpace(query.Quantity)
+}
+
+// validateField also returns the parsed quantity
+func (query *PriceQuery) validateField() (int, []httperror.FieldValidation) {
+	fieldValidation := []httperror.FieldValidation{}
+	if query.CustomerID != "" {
+		if err := common.ValidateUUIDFormat(query.CustomerID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldCustomerID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if query.Quantity == "" {
+		return 1, fieldValidation
+	}
+	quantity, err := strconv.Atoi(query.Quantity)
+	if err != nil || quantity < 1 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldQuantity,
+			Message: "quantity must be a whole number greater than 0",
+		})
+	}
+	return quantity, fieldValidation
+}
+
+// applyEffectivePrices fills the tier prices of every variant and the unit
+// price the customer pays for quantity
+func (s *Service) applyEffectivePrices(
+	ctx context.Context,
+	customerID string,
+	quantity int,
+	products []ProductDetailObject,
+) error {
+	tier, err := s.tierPriceResolver.CustomerTier(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, pricing.ErrCustomerNotFound) ||
+			errors.Is(err, pricing.ErrCustomerInactive) {
+			return httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+				Field:   fieldValidationFieldCustomerID,
+				Message: err.Error(),
+			}})
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	variantIDs := []string{}
+	for _, product := range products {
+		for _, variant := range product.Variants {
+			variantIDs = append(variantIDs, variant.VariantID)
+		}
+	}
+	if len(variantIDs) == 0 {
+		return nil
+	}
+	pricesByVariantID, err := s.tierPriceResolver.TierPrices(ctx, variantIDs)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	for i := range products {
+		for j := range products[i].Variants {
+			variant := &products[i].Variants[j]
+			prices := pricesByVariantID[variant.VariantID]
+			variant.TierPrices = make([]TierPriceObject, 0, len(prices))
+			for _, price := range prices {
+				variant.TierPrices = append(variant.TierPrices, TierPriceObject{
+					PriceTier:   string(price.PriceTier),
+					MinQuantity: price.MinQuantity,
+					UnitPrice:   price.UnitPrice,
+				})
+			}
+			effectivePrice := pricing.ResolveUnitPrice(
+				variant.SellPrice, tier, quantity, prices)
+			variant.EffectivePrice = &EffectivePriceObject{
+				CustomerPriceTier: string(tier),
+				Quantity:          quantity,
+				UnitPrice:         effectivePrice.UnitPrice,
+			}
+			if effectivePrice.PriceTier != "" {
+				variant.EffectivePrice.AppliedTierPrice = &TierPriceObject{
+					PriceTier:   string(effectivePrice.PriceTier),
+					MinQuantity: effectivePrice.MinQuantity,
+					UnitPrice:   effectivePrice.UnitPrice,
+				}
+			}
+		}
+	}
+	return nil
+}
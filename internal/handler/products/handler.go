@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/export"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 )
 
@@ -17,11 +18,28 @@ func NewHandler(service ProductService) *Handler {
 	}
 }
 
-func (h *Handler) RegisterRoutes(router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	endpoint := router.Group("/api/v1/products")
 	endpoint.POST("/", h.CreateProduct)
 	endpoint.PUT("/:product_id/single-product-type", h.UpdateSingleProductType)
 	endpoint.PUT("/:product_id/variant-product-type", h.UpdateVariantProductType)
+	endpoint.GET("/", h.GetProducts)
+	endpoint.GET("/:product_id", h.GetProduct)
+	endpoint.DELETE("/:product_id", h.DeleteProduct)
+	endpoint.POST("/:product_id/restore", h.RestoreProduct)
+	endpoint.DELETE("/:product_id/variants/:variant_id", h.DeleteVariant)
+	endpoint.POST("/:product_id/variants/:variant_id/restore", h.RestoreVariant)
+	endpoint.GET("/trash", h.GetDeletedProducts)
+}
+
+// RegisterRecipeRoutes registers repack recipe routes, they are guarded
+// separately from product routes
+func (h *Handler) RegisterRecipeRoutes(router gin.IRouter) {
+	recipeEndpoint := router.Group("/api/v1/repack-recipes")
+	recipeEndpoint.GET("/", h.GetRepackRecipes)
+	recipeEndpoint.GET("/:recipe_id", h.GetRepackRecipe)
+	recipeEndpoint.PUT("/:recipe_id", h.UpdateRepackRecipe)
+	recipeEndpoint.PATCH("/:recipe_id/status", h.UpdateRepackRecipeStatus)
 }
 
 func (h *Handler) CreateProduct(c *gin.Context) {
@@ -65,3 +83,195 @@ func (h *Handler) UpdateVariantProductType(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+// GetProduct takes the optional customer_id and quantity of the effective
+// prices as query parameters
+func (h *Handler) GetProduct(c *gin.Context) {
+	request := &GetProductRequest{
+		ProductID:  c.Param("product_id"),
+		PriceQuery: priceQuery(c),
+	}
+	response, err := h.service.GetProduct(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+func (h *Handler) GetProducts(c *gin.Context) {
+	format, err := export.FormatFromRequest(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if format != export.FormatJSON {
+		h.exportProducts(c, format)
+		return
+	}
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetProductsRequest{
+		PaginationData: *pagination,
+		CategoryID:     c.Query("category_id"),
+		ProductType:    c.Query("product_type"),
+		IsActive:       c.Query("is_active"),
+		Name:           c.Query("name"),
+		PriceQuery:     priceQuery(c),
+	}
+	response, err := h.service.GetProducts(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func priceQuery(c *gin.Context) PriceQuery {
+	return PriceQuery{
+		CustomerID: c.Query("customer_id"),
+		Quantity:   c.Query("quantity"),
+	}
+}
+func (h *Handler) exportProducts(c *gin.Context, format export.Format) {
+	request := &ExportProductsRequest{
+		CategoryID:  c.Query("category_id"),
+		ProductType: c.Query("product_type"),
+		IsActive:    c.Query("is_active"),
+		Name:        c.Query("name"),
+	}
+	export.Respond(c, format, "products", productExportHeader,
+		func(write export.RowFunc) error {
+			return h.service.ExportProducts(c, request,
+				func(row *ProductExportRow) error {
+					return write(row.values())
+				})
+		})
+}
+func (h *Handler) DeleteProduct(c *gin.Context) {
+	request := &DeleteProductRequest{
+		ProductID: c.Param("product_id"),
+	}
+	if err := h.service.DeleteProduct(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+func (h *Handler) RestoreProduct(c *gin.Context) {
+	request := &DeleteProductRequest{
+		ProductID: c.Param("product_id"),
+	}
+	if err := h.service.RestoreProduct(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+func (h *Handler) DeleteVariant(c *gin.Context) {
+	request := &DeleteVariantRequest{
+		ProductID: c.Param("product_id"),
+		VariantID: c.Param("variant_id"),
+	}
+	if err := h.service.DeleteVariant(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+func (h *Handler) RestoreVariant(c *gin.Context) {
+	request := &DeleteVariantRequest{
+		ProductID: c.Param("product_id"),
+		VariantID: c.Param("variant_id"),
+	}
+	if err := h.service.RestoreVariant(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+func (h *Handler) GetDeletedProducts(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetDeletedProductsRequest{
+		PaginationData: *pagination,
+		Name:           c.Query("name"),
+	}
+	response, err := h.service.GetDeletedProducts(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetRepackRecipes(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetRepackRecipesRequest{
+		PaginationData:  *pagination,
+		ParentVariantID: c.Query("parent_variant_id"),
+		ChildVariantID:  c.Query("child_variant_id"),
+		IsActive:        c.Query("is_active"),
+	}
+	response, err := h.service.GetRepackRecipes(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetRepackRecipe(c *gin.Context) {
+	request := &GetRepackRecipeRequest{
+		RecipeID: c.Param("recipe_id"),
+	}
+	response, err := h.service.GetRepackRecipe(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateRepackRecipe(c *gin.Context) {
+	request := &UpdateRepackRecipeRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.RecipeID = c.Param("recipe_id")
+	if err := h.service.UpdateRepackRecipe(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) UpdateRepackRecipeStatus(c *gin.Context) {
+	request := &UpdateRepackRecipeStatusRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.RecipeID = c.Param("recipe_id")
+	if err := h.service.UpdateRepackRecipeStatus(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
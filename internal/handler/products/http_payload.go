@@ -1,6 +1,9 @@
 package products
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
 
 type CreateProductRequest struct {
 	Product  Product         `json:"product"`
@@ -16,6 +19,8 @@ type UpdateSingleProductTypeRequest struct {
 	SizeUnitID      string           `json:"size_unit_id"`
 	CostPrice       *decimal.Decimal `json:"cost_price"`
 	SellPrice       decimal.Decimal  `json:"sell_price"`
+	// PriceChangeReason is recorded in the price history when a price changes
+	PriceChangeReason *string `json:"price_change_reason"`
 }
 
 type UpdateVariantProductTypeRequest struct {
@@ -23,4 +28,94 @@ type UpdateVariantProductTypeRequest struct {
 	BaseName   string          `json:"base_name"`
 	CategoryID string          `json:"category_id"`
 	Variants   []VariantObject `json:"variants"`
+	// PriceChangeReason is recorded in the price history when a price changes
+	PriceChangeReason *string `json:"price_change_reason"`
+}
+
+// PriceQuery is the customer and quantity the effective price of every
+// variant is resolved for
+type PriceQuery struct {
+	// CustomerID is empty for a walk-in customer
+	CustomerID string `json:"customer_id"`
+	// Quantity is 1 when empty
+	Quantity string `json:"quantity"`
+}
+
+type GetProductRequest struct {
+	ProductID string `json:"product_id"`
+	PriceQuery
+}
+
+type GetProductResponse struct {
+	Data ProductDetailObject `json:"data"`
+}
+
+type GetProductsRequest struct {
+	util.PaginationData `json:"pagination"`
+	CategoryID          string `json:"category_id"`
+	ProductType         string `json:"product_type"`
+	IsActive            string `json:"is_active"`
+	Name                string `json:"name"`
+	PriceQuery
+}
+
+type GetProductsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []ProductDetailObject `json:"data"`
+}
+
+type DeleteProductRequest struct {
+	ProductID string `json:"product_id"`
+}
+
+type DeleteVariantRequest struct {
+	ProductID string `json:"product_id"`
+	VariantID string `json:"variant_id"`
+}
+
+type GetDeletedProductsRequest struct {
+	util.PaginationData `json:"pagination"`
+	Name                string `json:"name"`
+}
+
+type GetDeletedProductsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []DeletedProductObject `json:"data"`
+}
+
+type GetRepackRecipeRequest struct {
+	RecipeID string `json:"recipe_id"`
+}
+
+type GetRepackRecipeResponse struct {
+	Data RepackRecipeItemObject `json:"data"`
+}
+
+type GetRepackRecipesRequest struct {
+	util.PaginationData `json:"pagination"`
+	ParentVariantID     string `json:"parent_variant_id"`
+	ChildVariantID      string `json:"child_variant_id"`
+	IsActive            string `json:"is_active"`
+}
+
+type GetRepackRecipesResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []RepackRecipeItemObject `json:"data"`
+}
+
+type UpdateRepackRecipeRequest struct {
+	RecipeID string `json:"recipe_id"`
+	RepackRecipeObject
+}
+
+type UpdateRepackRecipeStatusRequest struct {
+	RecipeID string `json:"recipe_id"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type ExportProductsRequest struct {
+	CategoryID  string `json:"category_id"`
+	ProductType string `json:"product_type"`
+	IsActive    string `json:"is_active"`
+	Name        string `json:"name"`
 }
@@ -1,6 +1,8 @@
 package products
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -26,3 +28,121 @@ type Product struct {
 	ProductType string `json:"product_type"`
 	CategoryID  string `json:"category_id"`
 }
+
+/*Read Data*/
+type CategoryObject struct {
+	CategoryID   string `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	CategoryCode string `json:"category_code"`
+}
+type PackagingTypeObject struct {
+	PackagingTypeID   string `json:"packaging_type_id"`
+	PackagingTypeCode string `json:"packaging_type_code"`
+	PackagingTypeName string `json:"packaging_type_name"`
+}
+type SizeUnitObject struct {
+	SizeUnitID   string `json:"size_unit_id"`
+	SizeUnitCode string `json:"size_unit_code"`
+	SizeUnitName string `json:"size_unit_name"`
+}
+type RepackRecipeDetailObject struct {
+	RecipeID          string          `json:"recipe_id"`
+	ParentVariantID   string          `json:"parent_variant_id"`
+	ParentFullName    string          `json:"parent_full_name"`
+	QuantityRatio     float32         `json:"quantity_ratio"`
+	RepackCostPerUnit decimal.Decimal `json:"repack_cost_per_unit"`
+	RepackTimeMinutes int             `json:"repack_time_minutes"`
+}
+type CostBreakdownObject struct {
+	ParentCostPrice   *decimal.Decimal `json:"parent_cost_price"`
+	QuantityRatio     float32          `json:"quantity_ratio"`
+	ParentCostPerUnit *decimal.Decimal `json:"parent_cost_per_unit"`
+	RepackCostPerUnit decimal.Decimal  `json:"repack_cost_per_unit"`
+	CostPrice         *decimal.Decimal `json:"cost_price"`
+}
+type VariantDetailObject struct {
+	VariantID      string                    `json:"variant_id"`
+	SKU            string                    `json:"sku"`
+	VariantName    *string                   `json:"variant_name"`
+	FullName       string                    `json:"full_name"`
+	PackagingType  PackagingTypeObject       `json:"packaging_type"`
+	SizeValue      float32                   `json:"size_value"`
+	SizeUnit       SizeUnitObject            `json:"size_unit"`
+	CostPrice      *decimal.Decimal          `json:"cost_price"`
+	SellPrice      decimal.Decimal           `json:"sell_price"`
+	IsActive       bool                      `json:"is_active"`
+	RepackRecipe   *RepackRecipeDetailObject `json:"repack_recipe"`
+	CostBreakdown  *CostBreakdownObject      `json:"cost_breakdown"`
+	TierPrices     []TierPriceObject         `json:"tier_prices"`
+	EffectivePrice *EffectivePriceObject     `json:"effective_price"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+type TierPriceObject struct {
+	PriceTier   string          `json:"price_tier"`
+	MinQuantity int             `json:"min_quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+}
+type EffectivePriceObject struct {
+	CustomerPriceTier string          `json:"customer_price_tier"`
+	Quantity          int             `json:"quantity"`
+	UnitPrice         decimal.Decimal `json:"unit_price"`
+	// AppliedTierPrice is nil when the selling price applies
+	AppliedTierPrice *TierPriceObject `json:"applied_tier_price"`
+}
+type ProductDetailObject struct {
+	ProductID   string                `json:"product_id"`
+	BaseName    string                `json:"base_name"`
+	ProductType string                `json:"product_type"`
+	Category    CategoryObject        `json:"category"`
+	Variants    []VariantDetailObject `json:"variants"`
+	CreatedBy   string                `json:"created_by"`
+	UpdatedBy   string                `json:"updated_by"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+type DeletedProductObject struct {
+	ProductID   string         `json:"product_id"`
+	BaseName    string         `json:"base_name"`
+	ProductType string         `json:"product_type"`
+	Category    CategoryObject `json:"category"`
+	DeletedBy   string         `json:"deleted_by"`
+	DeletedAt   time.Time      `json:"deleted_at"`
+}
+type RepackRecipeItemObject struct {
+	RecipeID          string               `json:"recipe_id"`
+	ParentVariantID   string               `json:"parent_variant_id"`
+	ParentFullName    string               `json:"parent_full_name"`
+	ChildVariantID    string               `json:"child_variant_id"`
+	ChildFullName     string               `json:"child_full_name"`
+	QuantityRatio     float32              `json:"quantity_ratio"`
+	RepackCostPerUnit decimal.Decimal      `json:"repack_cost_per_unit"`
+	RepackTimeMinutes int                  `json:"repack_time_minutes"`
+	IsActive          bool                 `json:"is_active"`
+	CostBreakdown     *CostBreakdownObject `json:"cost_breakdown"`
+	CreatedBy         string               `json:"created_by"`
+	UpdatedBy         string               `json:"updated_by"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	DeletedAt         *time.Time           `json:"deleted_at"`
+}
+
+// ProductExportRow is one variant with its product in the product export
+type ProductExportRow struct {
+	ProductID         string
+	ProductName       string
+	ProductType       string
+	CategoryCode      string
+	CategoryName      string
+	VariantID         string
+	SKU               string
+	VariantName       *string
+	FullName          string
+	PackagingTypeCode string
+	SizeValue         float32
+	SizeUnitCode      string
+	CostPrice         *decimal.Decimal
+	SellPrice         decimal.Decimal
+	IsActive          bool
+	UpdatedAt         time.Time
+}
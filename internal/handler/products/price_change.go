@@ -0,0 +1,69 @@
+package products
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+func sanitizePriceChangeReason(reason *string) {
+	if reason != nil {
+		*reason = strings.TrimSpace(*reason)
+	}
+}
+func validatePriceChangeReason(reason *string) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if reason == nil {
+		return fieldValidation
+	}
+	if err := common.ValidateMaxLengthStr(
+		*reason, maxLengthPriceChangeReason); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPriceChangeReason,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// manualPriceChange is the price history entry of a product update, an empty
+// reason is stored as null
+func manualPriceChange(reason *string) *repository.PriceChange {
+	change := &repository.PriceChange{
+		Source: repository.VariantPriceChangeSourceManual,
+	}
+	if reason != nil && *reason != "" {
+		change.Reason = sql.NullString{String: *reason, Valid: true}
+	}
+	return change
+}
+
+// pricesChanged reports whether an update writes a different cost or selling
+// price than the stored one
+func pricesChanged(stored, updated *repository.ProductVariantData) bool {
+	if !stored.SellingPrice.Equal(updated.SellingPrice) {
+		return true
+	}
+	if stored.CostPrice.Valid != updated.CostPrice.Valid {
+		return true
+	}
+	return stored.CostPrice.Valid &&
+		!stored.CostPrice.Decimal.Equal(updated.CostPrice.Decimal)
+}
+
+// checkPricingPermission requires the pricing permission when a product
+// update changes a price, other product fields only need product:write
+func checkPricingPermission(
+	ctx context.Context,
+	stored, updated *repository.ProductVariantData,
+) error {
+	if !pricesChanged(stored, updated) {
+		return nil
+	}
+	return middleware.CheckPermission(ctx, middleware.PermissionPricingWrite)
+}
@@ -0,0 +1,84 @@
+package products
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+// maxRepackChainDepth bounds the repack graph walks of this package
+const maxRepackChainDepth = costing.MaxRepackChainDepth
+
+// findParentCostPrices maps the repack parents to their current cost price,
+// active or not, a deleted parent is a bad request
+func (s *Service) findParentCostPrices(
+	ctx context.Context,
+	tx pgx.Tx,
+	parentVariantIDs []string,
+) (map[string]decimal.NullDecimal, error) {
+	costPrices, err := s.repackCost.FindCostPrices(ctx, tx, parentVariantIDs)
+	if err != nil {
+		if errors.Is(err, costing.ErrVariantNotFound) {
+			return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"parent_variant_not_found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return costPrices, nil
+}
+
+// recomputeRepackDescendantCosts stores the derived cost price of every
+// variant repacked, directly or not, from the given variants
+func (s *Service) recomputeRepackDescendantCosts(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+	userID string,
+) error {
+	if err := s.repackCost.RecomputeDescendants(
+		ctx, tx, variantIDs, userID); err != nil {
+		if errors.Is(err, costing.ErrRepackChainTooDeep) {
+			return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
+
+// applyRepackRecipeCost stores the derived cost of the recipe child and pushes
+// it down to every variant repacked from that child.
+func (s *Service) applyRepackRecipeCost(
+	ctx context.Context,
+	tx pgx.Tx,
+	recipe *repository.RepackRecipeData,
+	parentCostPrice decimal.NullDecimal,
+	userID string,
+) error {
+	costPrice := costing.DeriveRepackCostPrice(
+		parentCostPrice,
+		recipe.QuantityRatio,
+		recipe.RepackCostPerUnit,
+	)
+	if err := s.productVariantRepository.UpdateCostPriceTransaction(
+		ctx, tx, recipe.ChildVariantID, costPrice, userID,
+		&repository.PriceChange{
+			Source: repository.VariantPriceChangeSourceRepackCost,
+		},
+	); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return s.recomputeRepackDescendantCosts(
+		ctx, tx, []string{recipe.ChildVariantID}, userID)
+}
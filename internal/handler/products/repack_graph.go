@@ -0,0 +1,52 @@
+package products
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+// validateRepackGraph checks that repacking childVariantID from
+// parentVariantID keeps product_repack_recipes acyclic. It walks up from the
+// parent through the active recipes, every variant has at most one active
+// recipe so the ancestors form a single chain.
+func (s *Service) validateRepackGraph(
+	ctx context.Context,
+	tx pgx.Tx,
+	parentVariantID string,
+	childVariantID string,
+) ([]httperror.FieldValidation, error) {
+	if parentVariantID == childVariantID {
+		return []httperror.FieldValidation{
+			httperror.NewFieldValidation(fieldValidationFieldParentVariantID,
+				"parent_variant_id must not be the child variant itself"),
+		}, nil
+	}
+	visited := map[string]bool{parentVariantID: true}
+	current := parentVariantID
+	for depth := 0; depth < maxRepackChainDepth; depth++ {
+		recipes, err := s.repackRecipeRepository.FindActiveByChildVariantIDs(
+			ctx, tx, []string{current})
+		if err != nil {
+			return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		if len(recipes) == 0 {
+			return nil, nil
+		}
+		current = recipes[0].ParentVariantID
+		if current == childVariantID || visited[current] {
+			return []httperror.FieldValidation{
+				httperror.NewFieldValidation(fieldValidationFieldParentVariantID,
+					"parent_variant_id creates a repack cycle with "+current),
+			}, nil
+		}
+		visited[current] = true
+	}
+	return []httperror.FieldValidation{
+		httperror.NewFieldValidation(fieldValidationFieldParentVariantID,
+			"parent_variant_id repack chain is too deep"),
+	}, nil
+}
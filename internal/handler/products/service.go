@@ -4,13 +4,28 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/sku"
 )
 
 type ProductService interface {
 	Create(ctx context.Context, request *CreateProductRequest) error
 	UpdateSingleProductType(ctx context.Context, request *UpdateSingleProductTypeRequest) error
 	UpdateVariantProductType(ctx context.Context, request *UpdateVariantProductTypeRequest) error
+	GetProduct(ctx context.Context, request *GetProductRequest) (*GetProductResponse, error)
+	GetProducts(ctx context.Context, request *GetProductsRequest) (*GetProductsResponse, error)
+	ExportProducts(ctx context.Context, request *ExportProductsRequest, write func(row *ProductExportRow) error) error
+	DeleteProduct(ctx context.Context, request *DeleteProductRequest) error
+	RestoreProduct(ctx context.Context, request *DeleteProductRequest) error
+	DeleteVariant(ctx context.Context, request *DeleteVariantRequest) error
+	RestoreVariant(ctx context.Context, request *DeleteVariantRequest) error
+	GetDeletedProducts(ctx context.Context, request *GetDeletedProductsRequest) (*GetDeletedProductsResponse, error)
+	GetRepackRecipe(ctx context.Context, request *GetRepackRecipeRequest) (*GetRepackRecipeResponse, error)
+	GetRepackRecipes(ctx context.Context, request *GetRepackRecipesRequest) (*GetRepackRecipesResponse, error)
+	UpdateRepackRecipe(ctx context.Context, request *UpdateRepackRecipeRequest) error
+	UpdateRepackRecipeStatus(ctx context.Context, request *UpdateRepackRecipeStatusRequest) error
 }
 
 type Service struct {
@@ -20,6 +35,9 @@ type Service struct {
 	categoryPackagingRules   repository.CategoryPackagingRules
 	productVariantRepository repository.ProductVariant
 	repackRecipeRepository   repository.RepackRecipe
+	repackCost               *costing.RepackCost
+	tierPriceResolver        *pricing.TierPriceResolver
+	skuUpdatePolicy          sku.UpdatePolicy
 }
 
 func NewService(
@@ -29,6 +47,8 @@ func NewService(
 	categoryPackagingRules repository.CategoryPackagingRules,
 	productVariantRepository repository.ProductVariant,
 	repackRecipeRepository repository.RepackRecipe,
+	tierPriceResolver *pricing.TierPriceResolver,
+	skuUpdatePolicy sku.UpdatePolicy,
 ) ProductService {
 	return &Service{
 		db:                       db,
@@ -37,5 +57,9 @@ func NewService(
 		categoryPackagingRules:   categoryPackagingRules,
 		productVariantRepository: productVariantRepository,
 		repackRecipeRepository:   repackRecipeRepository,
+		repackCost: costing.NewRepackCost(
+			repackRecipeRepository, productVariantRepository),
+		tierPriceResolver: tierPriceResolver,
+		skuUpdatePolicy:   skuUpdatePolicy,
 	}
 }
@@ -8,7 +8,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/sku"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 	"github.com/shopspring/decimal"
 )
@@ -23,6 +26,8 @@ type requestCreateProduct struct {
 	productCategoryCode  string
 	mapSizeUnitCode      map[string]string
 	mapPackagingTypeCode map[string]string
+	// Required for repack cost price
+	mapParentCostPrice map[string]decimal.NullDecimal
 
 	insertedProduct *repository.ProductData
 	insertedVariant []repository.ProductVariantData
@@ -221,24 +226,32 @@ func (req *requestCreateProduct) validateExistingParentVariantData(
 	tx pgx.Tx,
 	variantRepository repository.ProductVariant,
 ) error {
-	existingVariants, err := variantRepository.FindManyByID(
+	// a new repack recipe needs an active parent
+	parents, err := variantRepository.FindManyByID(
 		ctx, tx, req.uniqueParentVariantIDArray)
 	if err != nil {
 		return httperror.NewInternalServer(ctx, httperror.WithMessage(
 			"internal_server_error: "+err.Error(),
 		))
 	}
-	if len(existingVariants) != len(req.uniqueParentVariantIDArray) {
+	if len(parents) != len(req.uniqueParentVariantIDArray) {
 		return httperror.NewBadRequest(ctx, httperror.WithMessage(
 			"parent_variant_not_found",
 		))
 	}
+	for _, parent := range parents {
+		req.mapParentCostPrice[parent.ID] = parent.CostPrice
+	}
 	return nil
 }
 func (req *requestCreateProduct) setInsertedData(
 	ctx context.Context,
 ) error {
-	userID := ctx.Value("userID").(string)
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	// Insert Product
 	insertedProduct := &repository.ProductData{
 		ID:          uuid.NewString(),
@@ -291,6 +304,12 @@ func (req *requestCreateProduct) setInsertedData(
 			RepackRecipe:    nil,
 		}
 		if variant.RepackRecipe != nil {
+			// Repack cost is derived from the parent, client cost_price is ignored
+			tempVariant.CostPrice = costing.DeriveRepackCostPrice(
+				req.mapParentCostPrice[variant.RepackRecipe.ParentVariantID],
+				variant.RepackRecipe.QuantityRatio,
+				variant.RepackRecipe.RepackCostPerUnit,
+			)
 			tempVariant.RepackRecipe = &repository.RepackRecipeData{
 				ID:                uuid.NewString(),
 				ParentVariantID:   variant.RepackRecipe.ParentVariantID,
@@ -309,6 +328,30 @@ func (req *requestCreateProduct) setInsertedData(
 	return nil
 }
 
+func (req *requestCreateProduct) assignSKU(
+	ctx context.Context,
+	tx pgx.Tx,
+	productVariantRepository repository.ProductVariant,
+) error {
+	builder := sku.NewBuilder(productVariantRepository)
+	for i := range req.insertedVariant {
+		variant := &req.insertedVariant[i]
+		variantSKU, err := builder.Build(ctx, tx, sku.Base(
+			req.productCategoryCode,
+			req.mapPackagingTypeCode[variant.PackagingTypeID],
+			variant.SizeValue,
+			req.mapSizeUnitCode[variant.SizeUnitID],
+		))
+		if err != nil {
+			return httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		variant.SKU = variantSKU
+	}
+	return nil
+}
+
 func (req *requestCreateProduct) insertProduct(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -325,7 +368,7 @@ func (req *requestCreateProduct) insertProduct(
 	for _, variant := range req.insertedVariant {
 		if err := productVariantRepository.InsertTransaction(
 			ctx, tx, &variant); err != nil {
-			return err
+			return handleSKUConflict(ctx, err)
 		}
 		if variant.RepackRecipe != nil {
 			if err := repackRecipeRepository.InsertTransaction(
@@ -350,6 +393,7 @@ func (s *Service) Create(
 		CreateProductRequest:       request,
 		mapSizeUnitCode:            make(map[string]string),
 		mapPackagingTypeCode:       make(map[string]string),
+		mapParentCostPrice:         make(map[string]decimal.NullDecimal),
 		uniqueSizeUnitArray:        make([]string, 0),
 		uniquePackagingTypeArray:   make([]string, 0),
 		uniqueParentVariantIDArray: make([]string, 0),
@@ -390,6 +434,11 @@ func (s *Service) Create(
 	if err = input.setInsertedData(ctx); err != nil {
 		return err
 	}
+	// Generate SKU for every variant
+	if err = input.assignSKU(ctx, tx, s.productVariantRepository); err != nil {
+		// error handled by function assignSKU
+		return err
+	}
 	// Insert product
 	if err = input.insertProduct(
 		ctx, tx,
@@ -0,0 +1,259 @@
+package products
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteProduct struct {
+	*DeleteProductRequest
+}
+
+func (req *requestDeleteProduct) sanitize() {
+	req.ProductID = strings.TrimSpace(req.ProductID)
+}
+
+func (req *requestDeleteProduct) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ProductID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldProductID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) DeleteProduct(ctx context.Context, request *DeleteProductRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteProduct{
+		DeleteProductRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	product, err := findProductForUpdate(ctx, tx, s.productRepository, input.ProductID)
+	if err != nil {
+		// error is already handled by findProductForUpdate
+		return err
+	}
+	if product.DeletedAt.Valid {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"product already deleted",
+		))
+	}
+	variantIDs, err := s.productVariantRepository.FindIDsByProductIDAndDeletedAt(
+		ctx, tx, product.ID, sql.NullTime{})
+	if err != nil {
+		return err
+	}
+	if err := validateNoActiveRepackChildren(
+		ctx, tx, s.repackRecipeRepository, variantIDs); err != nil {
+		// error is already handled by validateNoActiveRepackChildren
+		return err
+	}
+	now := time.Now().UTC()
+	if err := s.repackRecipeRepository.SoftDeleteByChildVariantIDsTransaction(
+		ctx, tx, variantIDs, userID, now); err != nil {
+		return err
+	}
+	if err := s.productVariantRepository.SoftDeleteTransaction(
+		ctx, tx, variantIDs, userID, now); err != nil {
+		return err
+	}
+	product.DeletedBy = sql.NullString{String: userID, Valid: true}
+	product.DeletedAt = sql.NullTime{Time: now, Valid: true}
+	if err := s.productRepository.SoftDeleteTransaction(ctx, tx, product); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) RestoreProduct(ctx context.Context, request *DeleteProductRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteProduct{
+		DeleteProductRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	product, err := findProductForUpdate(ctx, tx, s.productRepository, input.ProductID)
+	if err != nil {
+		// error is already handled by findProductForUpdate
+		return err
+	}
+	if !product.DeletedAt.Valid {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"product is not deleted",
+		))
+	}
+	// Only variants removed together with the product come back,
+	// variants deleted on their own before that stay in the trash
+	variantIDs, err := s.productVariantRepository.FindIDsByProductIDAndDeletedAt(
+		ctx, tx, product.ID, product.DeletedAt)
+	if err != nil {
+		return err
+	}
+	if err := restoreVariants(
+		ctx, tx,
+		s.productVariantRepository,
+		s.repackRecipeRepository,
+		variantIDs, product.DeletedAt.Time, userID,
+	); err != nil {
+		// error is already handled by restoreVariants
+		return err
+	}
+	product.UpdatedBy = userID
+	if err := s.productRepository.RestoreTransaction(ctx, tx, product); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func findProductForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	productRepository repository.ProductRepository,
+	productID string,
+) (*repository.ProductData, error) {
+	product, err := productRepository.FindByIDForUpdate(ctx, tx, productID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"product not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return product, nil
+}
+
+// validateNoActiveRepackChildren refuses deleting variants that are still
+// repacked into another variant outside the deleted set.
+func validateNoActiveRepackChildren(
+	ctx context.Context,
+	tx pgx.Tx,
+	repackRecipeRepository repository.RepackRecipe,
+	variantIDs []string,
+) error {
+	if len(variantIDs) == 0 {
+		return nil
+	}
+	recipes, err := repackRecipeRepository.FindActiveByParentVariantIDs(
+		ctx, tx, variantIDs)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	deleted := make(map[string]bool, len(variantIDs))
+	for _, variantID := range variantIDs {
+		deleted[variantID] = true
+	}
+	childVariantIDs := []string{}
+	for _, recipe := range recipes {
+		if !deleted[recipe.ChildVariantID] {
+			childVariantIDs = append(childVariantIDs, recipe.ChildVariantID)
+		}
+	}
+	if len(childVariantIDs) > 0 {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"variant is parent of active repack recipe : "+
+				strings.Join(childVariantIDs, ", "),
+		))
+	}
+	return nil
+}
+
+// restoreVariants brings back the variants and the repack recipes that were
+// deleted with them, as long as every recipe parent still exists.
+func restoreVariants(
+	ctx context.Context,
+	tx pgx.Tx,
+	productVariantRepository repository.ProductVariant,
+	repackRecipeRepository repository.RepackRecipe,
+	variantIDs []string,
+	deletedAt time.Time,
+	userID string,
+) error {
+	if len(variantIDs) == 0 {
+		return nil
+	}
+	recipes, err := repackRecipeRepository.FindByChildVariantIDsAndDeletedAt(
+		ctx, tx, variantIDs, deletedAt)
+	if err != nil {
+		return err
+	}
+	recipeIDs := make([]string, 0, len(recipes))
+	uniqueParentVariantID := make(map[string]bool)
+	parentVariantIDs := []string{}
+	for _, recipe := range recipes {
+		recipeIDs = append(recipeIDs, recipe.ID)
+		if !uniqueParentVariantID[recipe.ParentVariantID] {
+			uniqueParentVariantID[recipe.ParentVariantID] = true
+			parentVariantIDs = append(parentVariantIDs, recipe.ParentVariantID)
+		}
+	}
+	if len(parentVariantIDs) > 0 {
+		parents, err := productVariantRepository.FindManyByID(
+			ctx, tx, parentVariantIDs)
+		if err != nil {
+			return err
+		}
+		if len(parents) != len(parentVariantIDs) {
+			return httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"parent_variant_not_found",
+			))
+		}
+	}
+	if err := productVariantRepository.RestoreTransaction(
+		ctx, tx, variantIDs, userID); err != nil {
+		return err
+	}
+	if len(recipeIDs) > 0 {
+		if err := repackRecipeRepository.RestoreTransaction(
+			ctx, tx, recipeIDs, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
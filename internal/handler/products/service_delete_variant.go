@@ -0,0 +1,182 @@
+package products
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteVariant struct {
+	*DeleteVariantRequest
+}
+
+func (req *requestDeleteVariant) sanitize() {
+	req.ProductID = strings.TrimSpace(req.ProductID)
+	req.VariantID = strings.TrimSpace(req.VariantID)
+}
+
+func (req *requestDeleteVariant) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ProductID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldProductID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// findProductAndVariantForUpdate locks the product before the variant,
+// the same order DeleteProduct uses.
+func (req *requestDeleteVariant) findProductAndVariantForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	productRepository repository.ProductRepository,
+	productVariantRepository repository.ProductVariant,
+) (*repository.ProductData, *repository.ProductVariantData, error) {
+	product, err := findProductForUpdate(ctx, tx, productRepository, req.ProductID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if product.DeletedAt.Valid {
+		return nil, nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"product already deleted",
+		))
+	}
+	variant, err := productVariantRepository.FindByIDForUpdate(ctx, tx, req.VariantID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err != nil || variant.ProductID != product.ID {
+		return nil, nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"variant not found",
+		))
+	}
+	return product, variant, nil
+}
+
+func (s *Service) DeleteVariant(ctx context.Context, request *DeleteVariantRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteVariant{
+		DeleteVariantRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	product, variant, err := input.findProductAndVariantForUpdate(
+		ctx, tx, s.productRepository, s.productVariantRepository)
+	if err != nil {
+		// error is already handled by findProductAndVariantForUpdate
+		return err
+	}
+	if variant.DeletedAt.Valid {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"variant already deleted",
+		))
+	}
+	if product.ProductType == repository.ProductTypeSingle {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"variant of single product cannot be deleted, delete the product instead",
+		))
+	}
+	remainingVariantIDs, err := s.productVariantRepository.FindIDsByProductIDAndDeletedAt(
+		ctx, tx, product.ID, sql.NullTime{})
+	if err != nil {
+		return err
+	}
+	if len(remainingVariantIDs) <= 1 {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"product must keep at least one variant, delete the product instead",
+		))
+	}
+	if err := validateNoActiveRepackChildren(
+		ctx, tx, s.repackRecipeRepository, []string{variant.ID}); err != nil {
+		// error is already handled by validateNoActiveRepackChildren
+		return err
+	}
+	now := time.Now().UTC()
+	if err := s.repackRecipeRepository.SoftDeleteByChildVariantIDsTransaction(
+		ctx, tx, []string{variant.ID}, userID, now); err != nil {
+		return err
+	}
+	if err := s.productVariantRepository.SoftDeleteTransaction(
+		ctx, tx, []string{variant.ID}, userID, now); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) RestoreVariant(ctx context.Context, request *DeleteVariantRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteVariant{
+		DeleteVariantRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	_, variant, err := input.findProductAndVariantForUpdate(
+		ctx, tx, s.productRepository, s.productVariantRepository)
+	if err != nil {
+		// error is already handled by findProductAndVariantForUpdate
+		return err
+	}
+	if !variant.DeletedAt.Valid {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"variant is not deleted",
+		))
+	}
+	if err := restoreVariants(
+		ctx, tx,
+		s.productVariantRepository,
+		s.repackRecipeRepository,
+		[]string{variant.ID}, variant.DeletedAt.Time, userID,
+	); err != nil {
+		// error is already handled by restoreVariants
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
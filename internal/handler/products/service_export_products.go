@@ -0,0 +1,58 @@
+package products
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestExportProducts struct {
+	*ExportProductsRequest
+}
+
+func (req *requestExportProducts) sanitize() {
+	req.CategoryID = strings.TrimSpace(req.CategoryID)
+	req.ProductType = strings.TrimSpace(strings.ToUpper(req.ProductType))
+	req.IsActive = strings.TrimSpace(strings.ToUpper(req.IsActive))
+	req.Name = strings.TrimSpace(strings.ToUpper(req.Name))
+}
+
+func (req *requestExportProducts) validateField() []httperror.FieldValidation {
+	return validateProductsFilter(
+		req.CategoryID, req.ProductType, req.IsActive, req.Name)
+}
+
+// ExportProducts calls write for every variant of the filtered products,
+// unlike GetProducts the result is not paginated
+func (s *Service) ExportProducts(
+	ctx context.Context,
+	request *ExportProductsRequest,
+	write func(row *ProductExportRow) error,
+) error {
+	input := &requestExportProducts{
+		ExportProductsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	filter := &repository.ProductFilter{
+		CategoryID:  input.CategoryID,
+		ProductType: input.ProductType,
+		IsActive:    input.IsActive,
+		Name:        input.Name,
+	}
+	err := s.productRepository.StreamVariants(ctx, filter,
+		func(variant *repository.ProductVariantData) error {
+			return write(toProductExportRow(variant))
+		})
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package products
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetDeletedProducts struct {
+	*GetDeletedProductsRequest
+}
+
+func (req *requestGetDeletedProducts) sanitize() {
+	req.Name = strings.TrimSpace(strings.ToUpper(req.Name))
+}
+
+func (req *requestGetDeletedProducts) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateMaxLengthStr(
+		req.Name,
+		constants.MaxLengthProductBaseName,
+	); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldName,
+			Message: err.Error(),
+		})
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetDeletedProducts(
+	ctx context.Context,
+	request *GetDeletedProductsRequest,
+) (*GetDeletedProductsResponse, error) {
+	input := &requestGetDeletedProducts{
+		GetDeletedProductsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	products, totalCount, err := s.productRepository.FindPaginated(ctx,
+		&repository.ProductFilter{
+			Name:    input.Name,
+			Deleted: true,
+			Limit:   input.PageSize,
+			Offset:  input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]DeletedProductObject, 0, len(products))
+	for _, product := range products {
+		data = append(data, DeletedProductObject{
+			ProductID:   product.ID,
+			BaseName:    product.BaseName,
+			ProductType: string(product.ProductType),
+			Category: CategoryObject{
+				CategoryID:   product.CategoryID,
+				CategoryName: product.CategoryName,
+				CategoryCode: product.CategoryCode,
+			},
+			DeletedBy: product.DeletedBy.String,
+			DeletedAt: product.DeletedAt.Time,
+		})
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetDeletedProductsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
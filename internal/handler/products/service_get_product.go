@@ -0,0 +1,75 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetProduct struct {
+	*GetProductRequest
+	quantity int
+}
+
+func (req *requestGetProduct) sanitize() {
+	req.ProductID = strings.TrimSpace(req.ProductID)
+	req.PriceQuery.sanitize()
+}
+
+func (req *requestGetProduct) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ProductID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldProductID,
+			Message: err.Error(),
+		})
+	}
+	quantity, priceValidation := req.PriceQuery.validateField()
+	req.quantity = quantity
+	return append(fieldValidation, priceValidation...)
+}
+
+func (s *Service) GetProduct(
+	ctx context.Context,
+	request *GetProductRequest,
+) (*GetProductResponse, error) {
+	input := &requestGetProduct{
+		GetProductRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	product, err := s.productRepository.FindByID(ctx, input.ProductID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"product not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	variants, err := s.productVariantRepository.FindDetailByProductIDs(
+		ctx, []string{product.ID})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := []ProductDetailObject{toProductDetailObject(product, variants)}
+	if err := s.applyEffectivePrices(
+		ctx, input.CustomerID, input.quantity, data); err != nil {
+		// error is already handled by applyEffectivePrices
+		return nil, err
+	}
+	return &GetProductResponse{
+		Data: data[0],
+	}, nil
+}
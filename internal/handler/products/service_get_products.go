@@ -0,0 +1,148 @@
+package products
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetProducts struct {
+	*GetProductsRequest
+	quantity int
+}
+
+func (req *requestGetProducts) sanitize() {
+	req.CategoryID = strings.TrimSpace(req.CategoryID)
+	req.ProductType = strings.TrimSpace(strings.ToUpper(req.ProductType))
+	req.IsActive = strings.TrimSpace(strings.ToUpper(req.IsActive))
+	req.Name = strings.TrimSpace(strings.ToUpper(req.Name))
+	req.PriceQuery.sanitize()
+}
+
+func (req *requestGetProducts) validateField() []httperror.FieldValidation {
+	fieldValidation := validateProductsFilter(
+		req.CategoryID, req.ProductType, req.IsActive, req.Name)
+	quantity, priceValidation := req.PriceQuery.validateField()
+	req.quantity = quantity
+	fieldValidation = append(fieldValidation, priceValidation...)
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+// validateProductsFilter validates the filters shared by listing and
+// exporting products
+func validateProductsFilter(
+	categoryID, productType, isActive, name string,
+) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if categoryID != "" {
+		fieldValidation = append(fieldValidation, ValidateCategoryID(
+			categoryID,
+			fieldValidationFieldCategoryID)...,
+		)
+	}
+	if productType != "" {
+		if err := common.ValidateOneOf(
+			productType,
+			[]string{
+				string(repository.ProductTypeRepack),
+				string(repository.ProductTypeVariant),
+				string(repository.ProductTypeSingle),
+			},
+		); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldProductType,
+				Message: err.Error(),
+			})
+		}
+	}
+	if isActive != "" {
+		if err := common.ValidateOneOf(isActive, []string{
+			constants.IsActiveTrue, constants.IsActiveFalse}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldIsActive,
+				Message: err.Error(),
+			})
+		}
+	}
+	if err := common.ValidateMaxLengthStr(
+		name,
+		constants.MaxLengthProductBaseName,
+	); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldName,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetProducts(
+	ctx context.Context,
+	request *GetProductsRequest,
+) (*GetProductsResponse, error) {
+	input := &requestGetProducts{
+		GetProductsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	filter := &repository.ProductFilter{
+		CategoryID:  input.CategoryID,
+		ProductType: input.ProductType,
+		IsActive:    input.IsActive,
+		Name:        input.Name,
+		Limit:       input.PageSize,
+		Offset:      input.GetOffset(),
+	}
+	products, totalCount, err := s.productRepository.FindPaginated(ctx, filter)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	productIDs := make([]string, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+	variantsByProductID := make(map[string][]repository.ProductVariantData)
+	if len(productIDs) > 0 {
+		variants, err := s.productVariantRepository.FindDetailByProductIDs(
+			ctx, productIDs)
+		if err != nil {
+			return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		for _, variant := range variants {
+			variantsByProductID[variant.ProductID] = append(
+				variantsByProductID[variant.ProductID], variant)
+		}
+	}
+	data := make([]ProductDetailObject, 0, len(products))
+	for i := range products {
+		data = append(data, toProductDetailObject(
+			&products[i], variantsByProductID[products[i].ID]))
+	}
+	if err := s.applyEffectivePrices(
+		ctx, input.CustomerID, input.quantity, data); err != nil {
+		// error is already handled by applyEffectivePrices
+		return nil, err
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetProductsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
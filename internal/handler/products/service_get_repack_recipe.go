@@ -0,0 +1,37 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+func (s *Service) GetRepackRecipe(
+	ctx context.Context,
+	request *GetRepackRecipeRequest,
+) (*GetRepackRecipeResponse, error) {
+	request.RecipeID = strings.TrimSpace(request.RecipeID)
+	if err := common.ValidateUUIDFormat(request.RecipeID); err != nil {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{
+			httperror.NewFieldValidation(fieldValidationFieldRecipeID, err.Error()),
+		})
+	}
+	recipe, err := s.repackRecipeRepository.FindByID(ctx, request.RecipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"repack recipe not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetRepackRecipeResponse{
+		Data: toRepackRecipeItemObject(recipe),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package products
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetRepackRecipes struct {
+	*GetRepackRecipesRequest
+}
+
+func (req *requestGetRepackRecipes) sanitize() {
+	req.ParentVariantID = strings.TrimSpace(req.ParentVariantID)
+	req.ChildVariantID = strings.TrimSpace(req.ChildVariantID)
+	req.IsActive = strings.TrimSpace(strings.ToUpper(req.IsActive))
+}
+
+func (req *requestGetRepackRecipes) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.ParentVariantID != "" {
+		if err := common.ValidateUUIDFormat(req.ParentVariantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldParentVariantID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.ChildVariantID != "" {
+		if err := common.ValidateUUIDFormat(req.ChildVariantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldChildVariantID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.IsActive != "" {
+		if err := common.ValidateOneOf(req.IsActive, []string{"TRUE", "FALSE"}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldIsActive,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetRepackRecipes(
+	ctx context.Context,
+	request *GetRepackRecipesRequest,
+) (*GetRepackRecipesResponse, error) {
+	input := &requestGetRepackRecipes{
+		GetRepackRecipesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	recipes, totalCount, err := s.repackRecipeRepository.FindPaginated(ctx,
+		&repository.RepackRecipeFilter{
+			ParentVariantID: input.ParentVariantID,
+			ChildVariantID:  input.ChildVariantID,
+			IsActive:        input.IsActive,
+			Limit:           input.PageSize,
+			Offset:          input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]RepackRecipeItemObject, 0, len(recipes))
+	for i := range recipes {
+		data = append(data, toRepackRecipeItemObject(&recipes[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetRepackRecipesResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
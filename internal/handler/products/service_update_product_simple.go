@@ -8,6 +8,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/sku"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 	"github.com/shopspring/decimal"
 )
@@ -20,6 +22,7 @@ func (req *requestUpdateSingleProductType) sanitize() {
 	req.PackagingTypeID = strings.TrimSpace(req.PackagingTypeID)
 	req.BaseName = strings.TrimSpace(req.BaseName)
 	req.SizeUnitID = strings.TrimSpace(req.SizeUnitID)
+	sanitizePriceChangeReason(req.PriceChangeReason)
 }
 
 func (req *requestUpdateSingleProductType) validateField() []httperror.FieldValidation {
@@ -48,11 +51,17 @@ func (req *requestUpdateSingleProductType) validateField() []httperror.FieldVali
 		RepackRecipe:    nil, // since simple product doesn't have repack recipe
 	}
 	fieldValidation = append(fieldValidation, validateFieldVariant(&convertToVariant)...)
+	fieldValidation = append(fieldValidation,
+		validatePriceChangeReason(req.PriceChangeReason)...)
 	return fieldValidation
 }
 
 func (s *Service) UpdateSingleProductType(ctx context.Context, request *UpdateSingleProductTypeRequest) error {
-	userID := ctx.Value("userID").(string)
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &requestUpdateSingleProductType{
 		UpdateSingleProductTypeRequest: request,
 	}
@@ -100,7 +109,16 @@ func (s *Service) UpdateSingleProductType(ctx context.Context, request *UpdateSi
 			"size_unit_rule_not_found",
 		))
 	}
-	// Validate size unit rule
+	variantSKU, err := sku.NewBuilder(s.productVariantRepository).Resolve(
+		ctx, tx, s.skuUpdatePolicy, variantProduct.SKU, sku.Base(
+			sizeUnitRule[0].ProductCategoryCode,
+			packagingTypeRule[0].PackagingTypeCode,
+			input.SizeValue,
+			sizeUnitRule[0].SizeUnitCode,
+		))
+	if err != nil {
+		return err
+	}
 	setBaseProductUpdatedData := &repository.ProductData{
 		ID:         input.ProductID,
 		BaseName:   strings.ToUpper(input.BaseName),
@@ -109,6 +127,7 @@ func (s *Service) UpdateSingleProductType(ctx context.Context, request *UpdateSi
 	}
 	setVariantUpdatedData := &repository.ProductVariantData{
 		ID:              variantProduct.ID,
+		SKU:             variantSKU,
 		PackagingTypeID: input.PackagingTypeID,
 		SizeValue:       input.SizeValue,
 		SizeUnitID:      input.SizeUnitID,
@@ -121,10 +140,22 @@ func (s *Service) UpdateSingleProductType(ctx context.Context, request *UpdateSi
 		setVariantUpdatedData.CostPrice = decimal.NullDecimal{
 			Decimal: *input.CostPrice, Valid: true}
 	}
+	if err := checkPricingPermission(
+		ctx, variantProduct, setVariantUpdatedData); err != nil {
+		// error is already handled by checkPricingPermission
+		return err
+	}
 	if err := s.productRepository.UpdateTransaction(ctx, tx, setBaseProductUpdatedData); err != nil {
 		return err
 	}
-	if err := s.productVariantRepository.UpdateVariantForProductTypeSingleTransaction(ctx, tx, setVariantUpdatedData); err != nil {
+	if err := s.productVariantRepository.UpdateVariantForProductTypeSingleTransaction(
+		ctx, tx, setVariantUpdatedData, manualPriceChange(input.PriceChangeReason)); err != nil {
+		return handleSKUConflict(ctx, err)
+	}
+	// Variants repacked from this one follow its new cost price
+	if err := s.recomputeRepackDescendantCosts(
+		ctx, tx, []string{variantProduct.ID}, userID); err != nil {
+		// error is already handled by recomputeRepackDescendantCosts
 		return err
 	}
 	if err := tx.Commit(ctx); err != nil {
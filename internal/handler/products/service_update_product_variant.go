@@ -8,7 +8,10 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/sku"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 	"github.com/shopspring/decimal"
 )
@@ -23,6 +26,7 @@ func (req *requestUpdateVariantProductType) sanitizeProduct() {
 	req.BaseName = strings.TrimSpace(req.BaseName)
 	req.CategoryID = strings.TrimSpace(req.CategoryID)
 	req.ProductID = strings.TrimSpace(req.ProductID)
+	sanitizePriceChangeReason(req.PriceChangeReason)
 }
 
 func (req *requestUpdateVariantProductType) validateFieldProduct() []httperror.FieldValidation {
@@ -41,6 +45,8 @@ func (req *requestUpdateVariantProductType) validateFieldProduct() []httperror.F
 		req.CategoryID,
 		fieldValidationFieldCategoryID)...,
 	)
+	fieldValidation = append(fieldValidation,
+		validatePriceChangeReason(req.PriceChangeReason)...)
 	return fieldValidation
 }
 func (req *requestUpdateVariantProductType) sanitizeFieldVariant(variant *VariantObject) []httperror.FieldValidation {
@@ -68,7 +74,11 @@ func (req *requestUpdateVariantProductType) validateFieldVariant(variant *Varian
 }
 func (s *Service) UpdateVariantProductType(ctx context.Context,
 	request *UpdateVariantProductTypeRequest) error {
-	userID := ctx.Value("userID").(string)
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &requestUpdateVariantProductType{
 		UpdateVariantProductTypeRequest: request,
 	}
@@ -161,6 +171,38 @@ func (s *Service) UpdateVariantProductType(ctx context.Context,
 			"packaging_type_rule_not_found",
 		))
 	}
+	productCategoryCode := sizeUnitRule[0].ProductCategoryCode
+	mapSizeUnitCode := make(map[string]string)
+	for _, rule := range sizeUnitRule {
+		mapSizeUnitCode[rule.SizeUnitID] = rule.SizeUnitCode
+	}
+	mapPackagingTypeCode := make(map[string]string)
+	for _, rule := range packagingRule {
+		mapPackagingTypeCode[rule.PackagingTypeID] = rule.PackagingTypeCode
+	}
+	variantIDs := make([]string, 0, len(input.Variants))
+	for _, variant := range input.Variants {
+		variantIDs = append(variantIDs, variant.VariantID)
+	}
+	recipes, err := s.repackRecipeRepository.FindActiveByChildVariantIDs(
+		ctx, tx, variantIDs)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	mapChildIDWithRecipe := make(map[string]repository.RepackRecipeData)
+	parentVariantIDs := make([]string, 0, len(recipes))
+	for _, recipe := range recipes {
+		mapChildIDWithRecipe[recipe.ChildVariantID] = recipe
+		parentVariantIDs = append(parentVariantIDs, recipe.ParentVariantID)
+	}
+	parentCostPrices, err := s.findParentCostPrices(ctx, tx, parentVariantIDs)
+	if err != nil {
+		// error is already handled by findParentCostPrices
+		return err
+	}
+	skuBuilder := sku.NewBuilder(s.productVariantRepository)
 	setUpdatedProductData := &repository.ProductData{
 		ID:         input.ProductID,
 		BaseName:   strings.ToUpper(input.BaseName),
@@ -183,6 +225,18 @@ func (s *Service) UpdateVariantProductType(ctx context.Context,
 			SellingPrice:    variant.SellPrice,
 			UpdatedBy:       userID,
 		}
+		temp.SKU, err = skuBuilder.Resolve(
+			ctx, tx, s.skuUpdatePolicy,
+			mapVariantIDWithData[variant.VariantID].SKU,
+			sku.Base(
+				productCategoryCode,
+				mapPackagingTypeCode[variant.PackagingTypeID],
+				variant.SizeValue,
+				mapSizeUnitCode[variant.SizeUnitID],
+			))
+		if err != nil {
+			return err
+		}
 		if variant.VariantName != nil {
 			temp.VariantName = sql.NullString{
 				String: strings.ToUpper(*variant.VariantName),
@@ -192,10 +246,23 @@ func (s *Service) UpdateVariantProductType(ctx context.Context,
 		}
 		if variant.CostPrice != nil {
 			temp.CostPrice = decimal.NullDecimal{
-				Decimal: temp.CostPrice.Decimal,
+				Decimal: *variant.CostPrice,
 				Valid:   true,
 			}
 		}
+		// Repack cost is derived from the parent, client cost_price is ignored
+		if recipe, exist := mapChildIDWithRecipe[variant.VariantID]; exist {
+			temp.CostPrice = costing.DeriveRepackCostPrice(
+				parentCostPrices[recipe.ParentVariantID],
+				recipe.QuantityRatio,
+				recipe.RepackCostPerUnit,
+			)
+		}
+		if err := checkPricingPermission(
+			ctx, mapVariantIDWithData[variant.VariantID], &temp); err != nil {
+			// error is already handled by checkPricingPermission
+			return err
+		}
 		setUpdatedProductVariantData = append(
 			setUpdatedProductVariantData,
 			temp,
@@ -205,12 +272,19 @@ func (s *Service) UpdateVariantProductType(ctx context.Context,
 		ctx, tx, setUpdatedProductData); err != nil {
 		return err
 	}
+	priceChange := manualPriceChange(input.PriceChangeReason)
 	for _, data := range setUpdatedProductVariantData {
 		if err := s.productVariantRepository.UpdateVariantForProductTypeSingleTransaction(
-			ctx, tx, &data); err != nil {
-			return err
+			ctx, tx, &data, priceChange); err != nil {
+			return handleSKUConflict(ctx, err)
 		}
 	}
+	// Variants repacked from these ones follow their new cost price
+	if err := s.recomputeRepackDescendantCosts(
+		ctx, tx, variantIDs, userID); err != nil {
+		// error is already handled by recomputeRepackDescendantCosts
+		return err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		return err
 	}
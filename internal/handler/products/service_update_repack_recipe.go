@@ -0,0 +1,255 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateRepackRecipe struct {
+	*UpdateRepackRecipeRequest
+}
+
+func (req *requestUpdateRepackRecipe) sanitize() {
+	req.RecipeID = strings.TrimSpace(req.RecipeID)
+	sanitizeRepackRecipe(&req.RepackRecipeObject)
+}
+
+func (req *requestUpdateRepackRecipe) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.RecipeID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldRecipeID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateUUIDFormat(req.ParentVariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldParentVariantID,
+			Message: err.Error(),
+		})
+	}
+	fieldValidation = append(fieldValidation,
+		validateFieldRepackRecipe(&req.RepackRecipeObject)...)
+	return fieldValidation
+}
+
+func (s *Service) UpdateRepackRecipe(
+	ctx context.Context,
+	request *UpdateRepackRecipeRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateRepackRecipe{
+		UpdateRepackRecipeRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	recipe, err := s.findRepackRecipeForUpdate(ctx, tx, input.RecipeID)
+	if err != nil {
+		// error is already handled by findRepackRecipeForUpdate
+		return err
+	}
+	if recipe.DeletedAt.Valid {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"repack recipe is inactive",
+		))
+	}
+	parents, err := s.productVariantRepository.FindManyByID(
+		ctx, tx, []string{input.ParentVariantID})
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(parents) != 1 {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"parent_variant_not_found",
+		))
+	}
+	graphValidation, err := s.validateRepackGraph(
+		ctx, tx, input.ParentVariantID, recipe.ChildVariantID)
+	if err != nil {
+		// error is already handled by validateRepackGraph
+		return err
+	}
+	if len(graphValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, graphValidation)
+	}
+	recipe.ParentVariantID = input.ParentVariantID
+	recipe.QuantityRatio = input.QuantityRatio
+	recipe.RepackCostPerUnit = input.RepackCostPerUnit
+	recipe.RepackTimeMinutes = input.RepackTimeMinutes
+	recipe.UpdatedBy = userID
+	if err := s.repackRecipeRepository.UpdateTransaction(ctx, tx, recipe); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err := s.applyRepackRecipeCost(
+		ctx, tx, recipe, parents[0].CostPrice, userID); err != nil {
+		// error is already handled by applyRepackRecipeCost
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) UpdateRepackRecipeStatus(
+	ctx context.Context,
+	request *UpdateRepackRecipeStatusRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	request.RecipeID = strings.TrimSpace(request.RecipeID)
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(request.RecipeID); err != nil {
+		fieldValidation = append(fieldValidation,
+			httperror.NewFieldValidation(fieldValidationFieldRecipeID, err.Error()))
+	}
+	if request.IsActive == nil {
+		fieldValidation = append(fieldValidation,
+			httperror.NewFieldValidation(fieldValidationFieldIsActive, "is_active is required"))
+	}
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	recipe, err := s.findRepackRecipeForUpdate(ctx, tx, request.RecipeID)
+	if err != nil {
+		// error is already handled by findRepackRecipeForUpdate
+		return err
+	}
+	if *request.IsActive == !recipe.DeletedAt.Valid {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"repack recipe already has the requested status",
+		))
+	}
+	if !*request.IsActive {
+		// The child keeps its last derived cost price
+		if err := s.repackRecipeRepository.DeactivateTransaction(
+			ctx, tx, recipe.ID, userID, time.Now()); err != nil {
+			return httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return err
+		}
+		return nil
+	}
+	if err := s.validateRepackRecipeActivation(ctx, tx, recipe); err != nil {
+		// error is already handled by validateRepackRecipeActivation
+		return err
+	}
+	if err := s.repackRecipeRepository.RestoreTransaction(
+		ctx, tx, []string{recipe.ID}, userID); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	parentCostPrices, err := s.findParentCostPrices(
+		ctx, tx, []string{recipe.ParentVariantID})
+	if err != nil {
+		// error is already handled by findParentCostPrices
+		return err
+	}
+	if err := s.applyRepackRecipeCost(
+		ctx, tx, recipe, parentCostPrices[recipe.ParentVariantID], userID); err != nil {
+		// error is already handled by applyRepackRecipeCost
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) findRepackRecipeForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	recipeID string,
+) (*repository.RepackRecipeData, error) {
+	recipe, err := s.repackRecipeRepository.FindByIDForUpdate(ctx, tx, recipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"repack recipe not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return recipe, nil
+}
+
+// validateRepackRecipeActivation makes sure both variants are still active,
+// the child has no other active recipe and the recipe does not close a cycle.
+func (s *Service) validateRepackRecipeActivation(
+	ctx context.Context,
+	tx pgx.Tx,
+	recipe *repository.RepackRecipeData,
+) error {
+	variants, err := s.productVariantRepository.FindManyByID(ctx, tx,
+		[]string{recipe.ParentVariantID, recipe.ChildVariantID})
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(variants) != 2 {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"parent or child variant is no longer active",
+		))
+	}
+	activeRecipes, err := s.repackRecipeRepository.FindActiveByChildVariantIDs(
+		ctx, tx, []string{recipe.ChildVariantID})
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(activeRecipes) > 0 {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"child variant already has an active repack recipe",
+		))
+	}
+	graphValidation, err := s.validateRepackGraph(
+		ctx, tx, recipe.ParentVariantID, recipe.ChildVariantID)
+	if err != nil {
+		// error is already handled by validateRepackGraph
+		return err
+	}
+	if len(graphValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, graphValidation)
+	}
+	return nil
+}
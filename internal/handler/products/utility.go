@@ -1,12 +1,28 @@
 package products
 
 import (
+	"context"
+	"errors"
+
 	"github.com/rizkysr90/rizkiplastik-be/internal/common"
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
+// handleSKUConflict answers a SKU taken by a concurrent request between
+// building it and saving it with a conflict, the request can be retried as is
+func handleSKUConflict(ctx context.Context, err error) error {
+	if errors.Is(err, pg.ErrSKUAlreadyExists) {
+		return httperror.NewConflict(ctx, httperror.WithMessage(
+			"sku was taken by another request, please retry",
+		))
+	}
+	return err
+}
+
 func validateFieldProduct(product *Product) []httperror.FieldValidation {
 	fieldValidation := []httperror.FieldValidation{}
 	if err := common.ValidateStringRequired(product.ProductType, fieldValidationFieldProductType); err != nil {
@@ -112,3 +128,182 @@ func validateFieldVariant(variant *VariantObject) []httperror.FieldValidation {
 	}
 	return fieldValidation
 }
+
+func toProductDetailObject(
+	product *repository.ProductData,
+	variants []repository.ProductVariantData,
+) ProductDetailObject {
+	result := ProductDetailObject{
+		ProductID:   product.ID,
+		BaseName:    product.BaseName,
+		ProductType: string(product.ProductType),
+		Category: CategoryObject{
+			CategoryID:   product.CategoryID,
+			CategoryName: product.CategoryName,
+			CategoryCode: product.CategoryCode,
+		},
+		Variants:  make([]VariantDetailObject, 0, len(variants)),
+		CreatedBy: product.CreatedBy,
+		UpdatedBy: product.UpdatedBy,
+		CreatedAt: product.CreatedAt,
+		UpdatedAt: product.UpdatedAt,
+	}
+	for _, variant := range variants {
+		result.Variants = append(result.Variants, toVariantDetailObject(&variant))
+	}
+	return result
+}
+
+func toVariantDetailObject(variant *repository.ProductVariantData) VariantDetailObject {
+	result := VariantDetailObject{
+		VariantID: variant.ID,
+		SKU:       variant.SKU,
+		FullName:  variant.FullName,
+		PackagingType: PackagingTypeObject{
+			PackagingTypeID:   variant.PackagingTypeID,
+			PackagingTypeCode: variant.PackagingTypeCode,
+			PackagingTypeName: variant.PackagingTypeName,
+		},
+		SizeValue: variant.SizeValue,
+		SizeUnit: SizeUnitObject{
+			SizeUnitID:   variant.SizeUnitID,
+			SizeUnitCode: variant.SizeUnitCode,
+			SizeUnitName: variant.SizeUnitName,
+		},
+		SellPrice: variant.SellingPrice,
+		IsActive:  variant.IsActive,
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+	}
+	if variant.VariantName.Valid {
+		variantName := variant.VariantName.String
+		result.VariantName = &variantName
+	}
+	if variant.CostPrice.Valid {
+		costPrice := variant.CostPrice.Decimal
+		result.CostPrice = &costPrice
+	}
+	if variant.RepackRecipe != nil {
+		result.RepackRecipe = &RepackRecipeDetailObject{
+			RecipeID:          variant.RepackRecipe.ID,
+			ParentVariantID:   variant.RepackRecipe.ParentVariantID,
+			ParentFullName:    variant.RepackRecipe.ParentFullName,
+			QuantityRatio:     variant.RepackRecipe.QuantityRatio,
+			RepackCostPerUnit: variant.RepackRecipe.RepackCostPerUnit,
+			RepackTimeMinutes: variant.RepackRecipe.RepackTimeMinutes,
+		}
+		result.CostBreakdown = toCostBreakdownObject(variant.RepackRecipe)
+	}
+	return result
+}
+
+func toCostBreakdownObject(recipe *repository.RepackRecipeData) *CostBreakdownObject {
+	result := &CostBreakdownObject{
+		QuantityRatio:     recipe.QuantityRatio,
+		RepackCostPerUnit: recipe.RepackCostPerUnit,
+	}
+	costPrice := costing.DeriveRepackCostPrice(
+		recipe.ParentCostPrice,
+		recipe.QuantityRatio,
+		recipe.RepackCostPerUnit,
+	)
+	if costPrice.Valid {
+		parentCostPrice := recipe.ParentCostPrice.Decimal
+		parentCostPerUnit := costPrice.Decimal.Sub(recipe.RepackCostPerUnit)
+		result.ParentCostPrice = &parentCostPrice
+		result.ParentCostPerUnit = &parentCostPerUnit
+		result.CostPrice = &costPrice.Decimal
+	}
+	return result
+}
+
+func toRepackRecipeItemObject(recipe *repository.RepackRecipeData) RepackRecipeItemObject {
+	result := RepackRecipeItemObject{
+		RecipeID:          recipe.ID,
+		ParentVariantID:   recipe.ParentVariantID,
+		ParentFullName:    recipe.ParentFullName,
+		ChildVariantID:    recipe.ChildVariantID,
+		ChildFullName:     recipe.ChildFullName,
+		QuantityRatio:     recipe.QuantityRatio,
+		RepackCostPerUnit: recipe.RepackCostPerUnit,
+		RepackTimeMinutes: recipe.RepackTimeMinutes,
+		IsActive:          !recipe.DeletedAt.Valid,
+		CostBreakdown:     toCostBreakdownObject(recipe),
+		CreatedBy:         recipe.CreatedBy,
+		UpdatedBy:         recipe.UpdatedBy,
+		CreatedAt:         recipe.CreatedAt,
+		UpdatedAt:         recipe.UpdatedAt,
+	}
+	if recipe.DeletedAt.Valid {
+		deletedAt := recipe.DeletedAt.Time
+		result.DeletedAt = &deletedAt
+	}
+	return result
+}
+
+func toProductExportRow(variant *repository.ProductVariantData) *ProductExportRow {
+	row := &ProductExportRow{
+		ProductID:         variant.ProductID,
+		ProductName:       variant.ProductName,
+		ProductType:       string(variant.Parent.ProductType),
+		CategoryCode:      variant.Parent.CategoryCode,
+		CategoryName:      variant.Parent.CategoryName,
+		VariantID:         variant.ID,
+		SKU:               variant.SKU,
+		FullName:          variant.FullName,
+		PackagingTypeCode: variant.PackagingTypeCode,
+		SizeValue:         variant.SizeValue,
+		SizeUnitCode:      variant.SizeUnitCode,
+		SellPrice:         variant.SellingPrice,
+		IsActive:          variant.IsActive,
+		UpdatedAt:         variant.UpdatedAt,
+	}
+	if variant.VariantName.Valid {
+		row.VariantName = &variant.VariantName.String
+	}
+	if variant.CostPrice.Valid {
+		row.CostPrice = &variant.CostPrice.Decimal
+	}
+	return row
+}
+
+// productExportHeader names the columns of ProductExportRow.values
+var productExportHeader = []string{
+	"product_id",
+	"product_name",
+	"product_type",
+	"category_code",
+	"category_name",
+	"variant_id",
+	"sku",
+	"variant_name",
+	"full_name",
+	"packaging_type_code",
+	"size_value",
+	"size_unit_code",
+	"cost_price",
+	"sell_price",
+	"is_active",
+	"updated_at",
+}
+
+func (row *ProductExportRow) values() []any {
+	return []any{
+		row.ProductID,
+		row.ProductName,
+		row.ProductType,
+		row.CategoryCode,
+		row.CategoryName,
+		row.VariantID,
+		row.SKU,
+		row.VariantName,
+		row.FullName,
+		row.PackagingTypeCode,
+		row.SizeValue,
+		row.SizeUnitCode,
+		row.CostPrice,
+		row.SellPrice,
+		row.IsActive,
+		row.UpdatedAt,
+	}
+}
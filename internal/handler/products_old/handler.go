@@ -13,16 +13,19 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
 )
 
 // ProductHandler handles HTTP requests for products
 type ProductHandler struct {
-	db *pgxpool.Pool
+	db            *pgxpool.Pool
+	pricingEngine *pricing.Engine
 }
 
 // NewProductHandler creates a new product handler
-func NewProductHandler(db *pgxpool.Pool) *ProductHandler {
-	return &ProductHandler{db: db}
+func NewProductHandler(db *pgxpool.Pool, pricingEngine *pricing.Engine) *ProductHandler {
+	return &ProductHandler{db: db, pricingEngine: pricingEngine}
 }
 
 // RegisterRoutes registers all product-related routes
@@ -243,12 +246,23 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 	}
 
 	// Calculate shopee sale price and fee
-	product.ShopeeSalePrice, product.ShopeeFee = CalculateShopeePricing(
+	schedule, err := h.pricingEngine.ActiveSchedule(
+		c, repository.OnlineChannelShopee, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve shopee fee schedule"})
+		return
+	}
+	product.ShopeeSalePrice, product.ShopeeFee, err = CalculateShopeePricing(
+		schedule,
 		product.CostPrice,
 		product.GrossProfitPercentage,
 		product.ShopeeFreeDeliveryFee,
 		product.ShopeeCategory,
 	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate shopee pricing"})
+		return
+	}
 
 	response := GetProductResponse{
 		Data: product,
@@ -305,6 +319,14 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		LIMIT $2 OFFSET $3
 	`
 
+	// The fee schedule is resolved once for the whole page
+	schedule, err := h.pricingEngine.ActiveSchedule(
+		c, repository.OnlineChannelShopee, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve shopee fee schedule"})
+		return
+	}
+
 	rows, err := h.db.Query(c, query, nameFilter, pageSize, offset)
 	if err != nil {
 		log.Println(err.Error())
@@ -344,12 +366,17 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		}
 
 		// Calculate shopee sale price and fee
-		product.ShopeeSalePrice, product.ShopeeFee = CalculateShopeePricing(
+		product.ShopeeSalePrice, product.ShopeeFee, err = CalculateShopeePricing(
+			schedule,
 			product.CostPrice,
 			product.GrossProfitPercentage,
 			product.ShopeeFreeDeliveryFee,
 			product.ShopeeCategory,
 		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate shopee pricing"})
+			return
+		}
 
 		products = append(products, product)
 	}
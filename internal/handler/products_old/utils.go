@@ -1,46 +1,48 @@
 package products_old
 
-import "math"
+import (
+	"errors"
+	"math"
 
-// CalculateShopeePricing calculates the Shopee sale price and fee based on the formula
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// fallbackShopeeCategory is used for products whose category has no rate in
+// the schedule
+const fallbackShopeeCategory = "C"
+
+// CalculateShopeePricing calculates the Shopee sale price and fee based on the formula,
+// the fee rates and caps come from the active shopee fee schedule
 func CalculateShopeePricing(
+	schedule *repository.ChannelFeeScheduleData,
 	costPrice, grossProfitPercentage, shopeeFreeDeliveryFee float32,
 	category string,
-) (salePrice, fee float32) {
+) (salePrice, fee float32, err error) {
 	// 1. Find gross_profit_price_total = ROUND(cost_price * (gross_profit_percentage / 100) + cost_price)
 	grossProfitPriceTotal := float32(math.Round(float64(costPrice*(grossProfitPercentage/100) + costPrice)))
 
-	// 2. Find shopee fee SUM(admin fee + service fee)
-	var adminFee float32
-
-	// a. Calculate admin fee based on category
-	switch category {
-	case "A":
-		adminFee = 0.1 * grossProfitPriceTotal
-	case "B":
-		adminFee = 0.075 * grossProfitPriceTotal
-	case "C":
-		adminFee = 0.0575 * grossProfitPriceTotal
-	default: // D and E
-		adminFee = 0.0575 * grossProfitPriceTotal // Using C rate as fallback
+	// 2. Find shopee fee SUM(admin fee + service fee), the product keeps its
+	// own free delivery percentage while the cap comes from the schedule
+	productSchedule := *schedule
+	productSchedule.FreeDeliveryFeePercentage = decimal.NewFromFloat32(shopeeFreeDeliveryFee)
+	amount := decimal.NewFromFloat32(grossProfitPriceTotal)
+	shopeeFee, err := pricing.CalculateFee(&productSchedule, category, amount)
+	if errors.Is(err, pricing.ErrCategoryNotFound) {
+		shopeeFee, err = pricing.CalculateFee(&productSchedule, fallbackShopeeCategory, amount)
 	}
-
-	// b. Calculate service fee with max 10,000
-	deliveryFreeServiceFee := shopeeFreeDeliveryFee / 100
-	serviceFee := deliveryFreeServiceFee * grossProfitPriceTotal
-	if serviceFee > 20000 {
-		serviceFee = 20000
+	if err != nil {
+		return 0, 0, err
 	}
 
-	// 3. Calculate total shopee fee
-	fee = adminFee + serviceFee
-
-	// 4. Calculate sale price
+	// 3. Calculate sale price
+	fee = float32(shopeeFee.Total.InexactFloat64())
 	salePrice = fee + grossProfitPriceTotal
 
-	// 5. Round to 2 decimal places
+	// 4. Round to 2 decimal places
 	fee = float32(math.Round(float64(fee)*100) / 100)
 	salePrice = float32(math.Round(float64(salePrice)*100) / 100)
 
-	return salePrice, fee
+	return salePrice, fee, nil
 }
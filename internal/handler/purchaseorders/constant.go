@@ -0,0 +1,25 @@
+package purchaseorders
+
+const (
+	fieldValidationFieldPurchaseOrderID = "purchase_order_id"
+	fieldValidationFieldPONumber        = "po_number"
+	fieldValidationFieldSupplierID      = "supplier_id"
+	fieldValidationFieldOrderDate       = "order_date"
+	fieldValidationFieldExpectedDate    = "expected_date"
+	fieldValidationFieldStatus          = "status"
+	fieldValidationFieldItems           = "items"
+	fieldValidationFieldNote            = "note"
+	fieldValidationFieldReceiptDate     = "receipt_date"
+	fieldValidationFieldCostPolicy      = "cost_policy"
+	fieldValidationFieldStartDate       = "start_date"
+	fieldValidationFieldEndDate         = "end_date"
+
+	maxLengthNote     = 255
+	maxLengthPONumber = 30
+	maxItems          = 100
+
+	dateLayout = "2006-01-02"
+
+	// stockReferenceType marks stock movements posted by a goods receipt
+	stockReferenceType = "GOODS_RECEIPT"
+)
@@ -0,0 +1,121 @@
+package purchaseorders
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service PurchaseOrderService
+}
+
+func NewHandler(service PurchaseOrderService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/purchase-orders")
+	endpoint.POST("/", h.CreatePurchaseOrder)
+	endpoint.GET("/", h.GetPurchaseOrders)
+	endpoint.GET("/:purchase_order_id", h.GetPurchaseOrder)
+	endpoint.PUT("/:purchase_order_id", h.UpdatePurchaseOrder)
+	endpoint.PATCH("/:purchase_order_id/status", h.UpdatePurchaseOrderStatus)
+	endpoint.POST("/:purchase_order_id/receipts", h.CreateGoodsReceipt)
+}
+
+func (h *Handler) CreatePurchaseOrder(c *gin.Context) {
+	request := &CreatePurchaseOrderRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreatePurchaseOrder(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetPurchaseOrders(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetPurchaseOrdersRequest{
+		PaginationData: *pagination,
+		PONumber:       c.Query("po_number"),
+		SupplierID:     c.Query("supplier_id"),
+		Status:         c.Query("status"),
+		StartDate:      c.Query("start_date"),
+		EndDate:        c.Query("end_date"),
+	}
+	response, err := h.service.GetPurchaseOrders(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetPurchaseOrder(c *gin.Context) {
+	request := &GetPurchaseOrderRequest{
+		PurchaseOrderID: c.Param("purchase_order_id"),
+	}
+	response, err := h.service.GetPurchaseOrder(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdatePurchaseOrder(c *gin.Context) {
+	request := &UpdatePurchaseOrderRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.PurchaseOrderID = c.Param("purchase_order_id")
+	if err := h.service.UpdatePurchaseOrder(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) UpdatePurchaseOrderStatus(c *gin.Context) {
+	request := &UpdatePurchaseOrderStatusRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.PurchaseOrderID = c.Param("purchase_order_id")
+	if err := h.service.UpdatePurchaseOrderStatus(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) CreateGoodsReceipt(c *gin.Context) {
+	request := &CreateGoodsReceiptRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.PurchaseOrderID = c.Param("purchase_order_id")
+	response, err := h.service.CreateGoodsReceipt(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,97 @@
+package purchaseorders
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderPayload struct {
+	SupplierID string `json:"supplier_id"`
+	// OrderDate is in YYYY-MM-DD format, today when empty
+	OrderDate string `json:"order_date"`
+	// ExpectedDate is the expected delivery date in YYYY-MM-DD format
+	ExpectedDate *string                    `json:"expected_date"`
+	Note         *string                    `json:"note"`
+	Items        []PurchaseOrderItemPayload `json:"items"`
+
+	// orderDate and expectedDate are parsed by validateField
+	orderDate    time.Time
+	expectedDate sql.NullTime
+}
+
+type PurchaseOrderItemPayload struct {
+	VariantID string          `json:"variant_id"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	UnitCost  decimal.Decimal `json:"unit_cost"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	PurchaseOrderPayload
+}
+
+type CreatePurchaseOrderResponse struct {
+	PurchaseOrderID string          `json:"purchase_order_id"`
+	PONumber        string          `json:"po_number"`
+	TotalAmount     decimal.Decimal `json:"total_amount"`
+}
+
+type UpdatePurchaseOrderRequest struct {
+	PurchaseOrderID string `json:"-"`
+	PurchaseOrderPayload
+}
+
+type UpdatePurchaseOrderStatusRequest struct {
+	PurchaseOrderID string `json:"-"`
+	// Status is SENT or CLOSED, the received statuses are set by goods
+	// receipts
+	Status string `json:"status"`
+}
+
+type GetPurchaseOrderRequest struct {
+	PurchaseOrderID string `json:"purchase_order_id"`
+}
+
+type GetPurchaseOrderResponse struct {
+	Data PurchaseOrderObject `json:"data"`
+}
+
+type GetPurchaseOrdersRequest struct {
+	util.PaginationData `json:"pagination"`
+	PONumber            string `json:"po_number"`
+	SupplierID          string `json:"supplier_id"`
+	Status              string `json:"status"`
+	StartDate           string `json:"start_date"`
+	EndDate             string `json:"end_date"`
+}
+
+type GetPurchaseOrdersResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []PurchaseOrderObject `json:"data"`
+}
+
+type CreateGoodsReceiptRequest struct {
+	PurchaseOrderID string `json:"-"`
+	// ReceiptDate is in YYYY-MM-DD format, today when empty
+	ReceiptDate string `json:"receipt_date"`
+	// CostPolicy is LAST_COST or WEIGHTED_AVERAGE, the variant cost price is
+	// kept unchanged when empty
+	CostPolicy string                          `json:"cost_policy"`
+	Note       *string                         `json:"note"`
+	Items      []CreateGoodsReceiptItemRequest `json:"items"`
+}
+
+type CreateGoodsReceiptItemRequest struct {
+	PurchaseOrderItemID string          `json:"purchase_order_item_id"`
+	Quantity            decimal.Decimal `json:"quantity"`
+	// UnitCost defaults to the unit cost of the order item
+	UnitCost *decimal.Decimal `json:"unit_cost"`
+}
+
+type CreateGoodsReceiptResponse struct {
+	ReceiptID string `json:"receipt_id"`
+	// Status is the purchase order status after the receipt
+	Status string `json:"status"`
+}
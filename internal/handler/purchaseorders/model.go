@@ -0,0 +1,61 @@
+package purchaseorders
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrderObject struct {
+	PurchaseOrderID string  `json:"purchase_order_id"`
+	PONumber        string  `json:"po_number"`
+	SupplierID      string  `json:"supplier_id"`
+	SupplierName    string  `json:"supplier_name"`
+	OrderDate       string  `json:"order_date"`
+	ExpectedDate    *string `json:"expected_date"`
+	// DueDate is the order date plus the payment terms
+	DueDate         string                    `json:"due_date"`
+	Status          string                    `json:"status"`
+	PaymentTermDays int                       `json:"payment_term_days"`
+	TotalAmount     decimal.Decimal           `json:"total_amount"`
+	Note            *string                   `json:"note"`
+	SentAt          *time.Time                `json:"sent_at"`
+	ClosedAt        *time.Time                `json:"closed_at"`
+	CreatedBy       string                    `json:"created_by"`
+	UpdatedBy       string                    `json:"updated_by"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+	Items           []PurchaseOrderItemObject `json:"items,omitempty"`
+	Receipts        []GoodsReceiptObject      `json:"receipts,omitempty"`
+}
+
+type PurchaseOrderItemObject struct {
+	PurchaseOrderItemID string          `json:"purchase_order_item_id"`
+	VariantID           string          `json:"variant_id"`
+	ProductName         string          `json:"product_name"`
+	Quantity            decimal.Decimal `json:"quantity"`
+	UnitCost            decimal.Decimal `json:"unit_cost"`
+	LineAmount          decimal.Decimal `json:"line_amount"`
+	ReceivedQuantity    decimal.Decimal `json:"received_quantity"`
+	RemainingQuantity   decimal.Decimal `json:"remaining_quantity"`
+}
+
+type GoodsReceiptObject struct {
+	ReceiptID   string                   `json:"receipt_id"`
+	ReceiptDate string                   `json:"receipt_date"`
+	CostPolicy  *string                  `json:"cost_policy"`
+	Note        *string                  `json:"note"`
+	CreatedBy   string                   `json:"created_by"`
+	CreatedAt   time.Time                `json:"created_at"`
+	Items       []GoodsReceiptItemObject `json:"items"`
+}
+
+type GoodsReceiptItemObject struct {
+	PurchaseOrderItemID string           `json:"purchase_order_item_id"`
+	VariantID           string           `json:"variant_id"`
+	ProductName         string           `json:"product_name"`
+	Quantity            decimal.Decimal  `json:"quantity"`
+	UnitCost            decimal.Decimal  `json:"unit_cost"`
+	PreviousCostPrice   *decimal.Decimal `json:"previous_cost_price"`
+	NewCostPrice        *decimal.Decimal `json:"new_cost_price"`
+}
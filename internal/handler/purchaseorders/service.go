@@ -0,0 +1,60 @@
+package purchaseorders
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(
+		ctx context.Context,
+		request *CreatePurchaseOrderRequest,
+	) (*CreatePurchaseOrderResponse, error)
+	UpdatePurchaseOrder(ctx context.Context, request *UpdatePurchaseOrderRequest) error
+	UpdatePurchaseOrderStatus(ctx context.Context, request *UpdatePurchaseOrderStatusRequest) error
+	GetPurchaseOrder(
+		ctx context.Context,
+		request *GetPurchaseOrderRequest,
+	) (*GetPurchaseOrderResponse, error)
+	GetPurchaseOrders(
+		ctx context.Context,
+		request *GetPurchaseOrdersRequest,
+	) (*GetPurchaseOrdersResponse, error)
+	CreateGoodsReceipt(
+		ctx context.Context,
+		request *CreateGoodsReceiptRequest,
+	) (*CreateGoodsReceiptResponse, error)
+}
+
+type Service struct {
+	db                       *pgxpool.Pool
+	purchaseOrderRepository  repository.PurchaseOrder
+	supplierRepository       repository.Supplier
+	productVariantRepository repository.ProductVariant
+	stockLedgerRepository    repository.StockLedger
+	repackRecipeRepository   repository.RepackRecipe
+	repackCost               *costing.RepackCost
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	purchaseOrderRepository repository.PurchaseOrder,
+	supplierRepository repository.Supplier,
+	productVariantRepository repository.ProductVariant,
+	stockLedgerRepository repository.StockLedger,
+	repackRecipeRepository repository.RepackRecipe,
+) PurchaseOrderService {
+	return &Service{
+		db:                       db,
+		purchaseOrderRepository:  purchaseOrderRepository,
+		supplierRepository:       supplierRepository,
+		productVariantRepository: productVariantRepository,
+		stockLedgerRepository:    stockLedgerRepository,
+		repackRecipeRepository:   repackRecipeRepository,
+		repackCost: costing.NewRepackCost(
+			repackRecipeRepository, productVariantRepository),
+	}
+}
@@ -0,0 +1,421 @@
+package purchaseorders
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestCreateGoodsReceipt struct {
+	*CreateGoodsReceiptRequest
+	receiptDate time.Time
+}
+
+func (req *requestCreateGoodsReceipt) sanitize() {
+	req.PurchaseOrderID = strings.TrimSpace(req.PurchaseOrderID)
+	req.ReceiptDate = strings.TrimSpace(req.ReceiptDate)
+	req.CostPolicy = strings.TrimSpace(strings.ToUpper(req.CostPolicy))
+	req.Note = trimOptional(req.Note)
+	for i := range req.Items {
+		req.Items[i].PurchaseOrderItemID = strings.TrimSpace(
+			req.Items[i].PurchaseOrderItemID)
+	}
+}
+
+func (req *requestCreateGoodsReceipt) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.PurchaseOrderID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPurchaseOrderID,
+			Message: err.Error(),
+		})
+	}
+	if req.ReceiptDate == "" {
+		req.ReceiptDate = time.Now().Format(dateLayout)
+	}
+	receiptDate, err := time.Parse(dateLayout, req.ReceiptDate)
+	if err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldReceiptDate,
+			Message: "invalid date format, use YYYY-MM-DD",
+		})
+	}
+	req.receiptDate = receiptDate
+	if req.CostPolicy != "" {
+		if err := common.ValidateOneOf(req.CostPolicy, allowedCostPolicies); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldCostPolicy,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.Note != nil {
+		if err := common.ValidateMaxLengthStr(*req.Note, maxLengthNote); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldNote,
+				Message: err.Error(),
+			})
+		}
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxItems {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldItems,
+			Message: fmt.Sprintf("items must contain 1 to %d items", maxItems),
+		})
+	}
+	seen := make(map[string]bool, len(req.Items))
+	for i, item := range req.Items {
+		field := func(name string) string {
+			return fmt.Sprintf("%s[%d].%s", fieldValidationFieldItems, i, name)
+		}
+		if err := common.ValidateUUIDFormat(item.PurchaseOrderItemID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("purchase_order_item_id"),
+				Message: err.Error(),
+			})
+		} else if seen[item.PurchaseOrderItemID] {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("purchase_order_item_id"),
+				Message: "order item is already received in another item",
+			})
+		}
+		seen[item.PurchaseOrderItemID] = true
+		if !item.Quantity.IsPositive() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("quantity"),
+				Message: "quantity must be greater than 0",
+			})
+		}
+		if item.UnitCost != nil && item.UnitCost.IsNegative() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("unit_cost"),
+				Message: "unit_cost must not be negative",
+			})
+		}
+	}
+	return fieldValidation
+}
+
+// CreateGoodsReceipt receives goods against a SENT or PARTIALLY_RECEIVED
+// order. Every received item posts a PURCHASE_RECEIPT stock movement and,
+// when a cost policy is given, updates the variant cost price and the cost
+// price of the variants repacked from it.
+func (s *Service) CreateGoodsReceipt(
+	ctx context.Context,
+	request *CreateGoodsReceiptRequest,
+) (*CreateGoodsReceiptResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateGoodsReceipt{
+		CreateGoodsReceiptRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if input.CostPolicy != "" {
+		if err := middleware.CheckPermission(
+			ctx, middleware.PermissionPricingWrite); err != nil {
+			// error is already handled by CheckPermission
+			return nil, err
+		}
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	order, err := s.findPurchaseOrderForUpdate(ctx, tx, input.PurchaseOrderID)
+	if err != nil {
+		// error is already handled by findPurchaseOrderForUpdate
+		return nil, err
+	}
+	if order.Status != repository.PurchaseOrderStatusSent &&
+		order.Status != repository.PurchaseOrderStatusPartiallyReceived {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"goods can only be received for SENT or PARTIALLY_RECEIVED "+
+				"purchase orders, current status is "+string(order.Status),
+		))
+	}
+	receipt, err := buildGoodsReceipt(ctx, order, input)
+	if err != nil {
+		// error is already handled by buildGoodsReceipt
+		return nil, err
+	}
+	receipt.CreatedBy = userID
+	balancesBefore, err := s.postReceiptStockMovements(ctx, tx, order, receipt)
+	if err != nil {
+		// error is already handled by postReceiptStockMovements
+		return nil, err
+	}
+	if receipt.CostPolicy.Valid {
+		if err := s.applyReceiptCostPolicy(
+			ctx, tx, order, receipt, balancesBefore); err != nil {
+			// error is already handled by applyReceiptCostPolicy
+			return nil, err
+		}
+	}
+	if err := s.purchaseOrderRepository.InsertReceiptTransaction(
+		ctx, tx, receipt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"received quantity exceeds the ordered quantity",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	order.Status = receivedStatus(order, receipt)
+	order.UpdatedBy = userID
+	if err := s.purchaseOrderRepository.UpdateStatusTransaction(
+		ctx, tx, order); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateGoodsReceiptResponse{
+		ReceiptID: receipt.ID,
+		Status:    string(order.Status),
+	}, nil
+}
+
+// buildGoodsReceipt matches the received items with the order items, an
+// item can not receive more than its remaining quantity
+func buildGoodsReceipt(
+	ctx context.Context,
+	order *repository.PurchaseOrderData,
+	input *requestCreateGoodsReceipt,
+) (*repository.GoodsReceiptData, error) {
+	orderItems := make(map[string]*repository.PurchaseOrderItemData, len(order.Items))
+	for i := range order.Items {
+		orderItems[order.Items[i].ID] = &order.Items[i]
+	}
+	receipt := &repository.GoodsReceiptData{
+		ID:              uuid.NewString(),
+		PurchaseOrderID: order.ID,
+		ReceiptDate:     input.receiptDate,
+		Items:           make([]repository.GoodsReceiptItemData, 0, len(input.Items)),
+	}
+	if input.CostPolicy != "" {
+		receipt.CostPolicy = sql.NullString{String: input.CostPolicy, Valid: true}
+	}
+	if input.Note != nil {
+		receipt.Note = sql.NullString{String: *input.Note, Valid: true}
+	}
+	fieldValidation := []httperror.FieldValidation{}
+	for i, item := range input.Items {
+		field := func(name string) string {
+			return fmt.Sprintf("%s[%d].%s", fieldValidationFieldItems, i, name)
+		}
+		orderItem, ok := orderItems[item.PurchaseOrderItemID]
+		if !ok {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("purchase_order_item_id"),
+				Message: "item does not belong to the purchase order",
+			})
+			continue
+		}
+		quantity := item.Quantity.Round(4)
+		remaining := orderItem.Quantity.Sub(orderItem.ReceivedQuantity)
+		if quantity.GreaterThan(remaining) {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field: field("quantity"),
+				Message: "quantity must not exceed the remaining quantity " +
+					remaining.String(),
+			})
+			continue
+		}
+		unitCost := orderItem.UnitCost
+		if item.UnitCost != nil {
+			unitCost = item.UnitCost.Round(2)
+		}
+		receipt.Items = append(receipt.Items, repository.GoodsReceiptItemData{
+			ID:                  uuid.NewString(),
+			ReceiptID:           receipt.ID,
+			PurchaseOrderItemID: orderItem.ID,
+			VariantID:           orderItem.VariantID,
+			ProductName:         orderItem.ProductName,
+			Quantity:            quantity,
+			UnitCost:            unitCost,
+		})
+	}
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	return receipt, nil
+}
+
+// postReceiptStockMovements adds the received quantities to the stock and
+// returns the stock of each variant before the receipt. Variants are locked
+// in id order so concurrent receipts and sales can not deadlock.
+func (s *Service) postReceiptStockMovements(
+	ctx context.Context,
+	tx pgx.Tx,
+	order *repository.PurchaseOrderData,
+	receipt *repository.GoodsReceiptData,
+) (map[string]decimal.Decimal, error) {
+	items := make([]*repository.GoodsReceiptItemData, 0, len(receipt.Items))
+	for i := range receipt.Items {
+		items = append(items, &receipt.Items[i])
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].VariantID < items[j].VariantID
+	})
+	balancesBefore := make(map[string]decimal.Decimal, len(items))
+	for _, item := range items {
+		data := &repository.StockMovementData{
+			ID:           uuid.NewString(),
+			VariantID:    item.VariantID,
+			MovementType: repository.StockMovementTypePurchaseReceipt,
+			Quantity:     item.Quantity,
+			CreatedBy:    receipt.CreatedBy,
+		}
+		data.ReferenceType = sql.NullString{String: stockReferenceType, Valid: true}
+		data.ReferenceID = sql.NullString{String: receipt.ID, Valid: true}
+		data.Note = sql.NullString{String: order.PONumber, Valid: true}
+		if err := s.stockLedgerRepository.InsertMovementTransaction(
+			ctx, tx, data); err != nil {
+			return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		// the stock row stays locked until commit, so the balance is safe to
+		// use for the weighted average
+		balancesBefore[item.VariantID] = data.BalanceAfter.Sub(item.Quantity)
+	}
+	return balancesBefore, nil
+}
+
+// applyReceiptCostPolicy updates the cost price of the received variants and
+// records the previous and the new cost price on the receipt items. The
+// weighted average falls back to the received unit cost when there was no
+// stock or no cost price before the receipt.
+func (s *Service) applyReceiptCostPolicy(
+	ctx context.Context,
+	tx pgx.Tx,
+	order *repository.PurchaseOrderData,
+	receipt *repository.GoodsReceiptData,
+	balancesBefore map[string]decimal.Decimal,
+) error {
+	variantIDs := make([]string, 0, len(receipt.Items))
+	for _, item := range receipt.Items {
+		variantIDs = append(variantIDs, item.VariantID)
+	}
+	variants, err := s.productVariantRepository.FindManyByID(ctx, tx, variantIDs)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	costPrices := make(map[string]decimal.NullDecimal, len(variants))
+	for _, variant := range variants {
+		costPrices[variant.ID] = variant.CostPrice
+	}
+	// the cost price of a repacked variant follows its parent, a received cost
+	// would be overwritten by the next recompute
+	recipes, err := s.repackRecipeRepository.FindActiveByChildVariantIDs(
+		ctx, tx, variantIDs)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	repackedVariantIDs := make(map[string]bool, len(recipes))
+	for _, recipe := range recipes {
+		repackedVariantIDs[recipe.ChildVariantID] = true
+	}
+	policy := repository.GoodsReceiptCostPolicy(receipt.CostPolicy.String)
+	priceChange := &repository.PriceChange{
+		Source: repository.VariantPriceChangeSourceGoodsReceipt,
+		Reason: sql.NullString{
+			String: "goods receipt of " + order.PONumber,
+			Valid:  true,
+		},
+	}
+	updatedVariantIDs := make([]string, 0, len(receipt.Items))
+	for i := range receipt.Items {
+		item := &receipt.Items[i]
+		previousCostPrice, ok := costPrices[item.VariantID]
+		if !ok {
+			return httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"cost price of "+item.ProductName+
+					" can not be updated, the variant is no longer active",
+			))
+		}
+		if repackedVariantIDs[item.VariantID] {
+			return httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"cost price of "+item.ProductName+" is derived from its repack "+
+					"parent, receive it without a cost policy",
+			))
+		}
+		balanceBefore := balancesBefore[item.VariantID]
+		newCostPrice := item.UnitCost
+		if policy == repository.GoodsReceiptCostPolicyWeightedAverage &&
+			previousCostPrice.Valid && balanceBefore.IsPositive() {
+			newCostPrice = balanceBefore.Mul(previousCostPrice.Decimal).
+				Add(item.Quantity.Mul(item.UnitCost)).
+				Div(balanceBefore.Add(item.Quantity)).
+				Round(2)
+		}
+		item.PreviousCostPrice = previousCostPrice
+		item.NewCostPrice = decimal.NewNullDecimal(newCostPrice)
+		if err := s.productVariantRepository.UpdateCostPriceTransaction(
+			ctx, tx, item.VariantID, item.NewCostPrice, receipt.CreatedBy,
+			priceChange); err != nil {
+			return httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+		updatedVariantIDs = append(updatedVariantIDs, item.VariantID)
+	}
+	if err := s.repackCost.RecomputeDescendants(
+		ctx, tx, updatedVariantIDs, receipt.CreatedBy); err != nil {
+		if errors.Is(err, costing.ErrRepackChainTooDeep) {
+			return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
+
+// receivedStatus is RECEIVED once every order item is fully received
+func receivedStatus(
+	order *repository.PurchaseOrderData,
+	receipt *repository.GoodsReceiptData,
+) repository.PurchaseOrderStatus {
+	received := make(map[string]decimal.Decimal, len(receipt.Items))
+	for _, item := range receipt.Items {
+		received[item.PurchaseOrderItemID] = item.Quantity
+	}
+	for _, item := range order.Items {
+		total := item.ReceivedQuantity.Add(received[item.ID])
+		if total.LessThan(item.Quantity) {
+			return repository.PurchaseOrderStatusPartiallyReceived
+		}
+	}
+	return repository.PurchaseOrderStatusReceived
+}
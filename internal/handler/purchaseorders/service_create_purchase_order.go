@@ -0,0 +1,70 @@
+package purchaseorders
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreatePurchaseOrder struct {
+	*CreatePurchaseOrderRequest
+}
+
+func (req *requestCreatePurchaseOrder) sanitize() {
+	req.PurchaseOrderPayload.sanitize()
+}
+
+func (req *requestCreatePurchaseOrder) validateField() []httperror.FieldValidation {
+	return req.PurchaseOrderPayload.validateField()
+}
+
+// CreatePurchaseOrder records a DRAFT order, no stock moves until goods are
+// received against it
+func (s *Service) CreatePurchaseOrder(
+	ctx context.Context,
+	request *CreatePurchaseOrderRequest,
+) (*CreatePurchaseOrderResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreatePurchaseOrder{
+		CreatePurchaseOrderRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	order, err := s.buildPurchaseOrder(ctx, tx, &input.PurchaseOrderPayload)
+	if err != nil {
+		// error is already handled by buildPurchaseOrder
+		return nil, err
+	}
+	order.ID = uuid.NewString()
+	order.Status = repository.PurchaseOrderStatusDraft
+	order.CreatedBy = userID
+	if err := s.purchaseOrderRepository.InsertTransaction(ctx, tx, order); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreatePurchaseOrderResponse{
+		PurchaseOrderID: order.ID,
+		PONumber:        order.PONumber,
+		TotalAmount:     order.TotalAmount,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package purchaseorders
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetPurchaseOrder struct {
+	*GetPurchaseOrderRequest
+}
+
+func (req *requestGetPurchaseOrder) sanitize() {
+	req.PurchaseOrderID = strings.TrimSpace(req.PurchaseOrderID)
+}
+
+func (req *requestGetPurchaseOrder) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.PurchaseOrderID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPurchaseOrderID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetPurchaseOrder(
+	ctx context.Context,
+	request *GetPurchaseOrderRequest,
+) (*GetPurchaseOrderResponse, error) {
+	input := &requestGetPurchaseOrder{
+		GetPurchaseOrderRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	order, err := s.purchaseOrderRepository.FindByID(ctx, input.PurchaseOrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"purchase order not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetPurchaseOrderResponse{
+		Data: toPurchaseOrderObject(order),
+	}, nil
+}
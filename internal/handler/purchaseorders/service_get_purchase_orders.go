@@ -0,0 +1,118 @@
+package purchaseorders
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetPurchaseOrders struct {
+	*GetPurchaseOrdersRequest
+}
+
+func (req *requestGetPurchaseOrders) sanitize() {
+	req.PONumber = strings.TrimSpace(req.PONumber)
+	req.SupplierID = strings.TrimSpace(req.SupplierID)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+	req.StartDate = strings.TrimSpace(req.StartDate)
+	req.EndDate = strings.TrimSpace(req.EndDate)
+}
+
+func (req *requestGetPurchaseOrders) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateMaxLengthStr(req.PONumber, maxLengthPONumber); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPONumber,
+			Message: err.Error(),
+		})
+	}
+	if req.SupplierID != "" {
+		if err := common.ValidateUUIDFormat(req.SupplierID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldSupplierID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, allowedStatuses); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	var startDate, endDate time.Time
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStartDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEndDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEndDate,
+			Message: "end_date must not be before start_date",
+		})
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetPurchaseOrders(
+	ctx context.Context,
+	request *GetPurchaseOrdersRequest,
+) (*GetPurchaseOrdersResponse, error) {
+	input := &requestGetPurchaseOrders{
+		GetPurchaseOrdersRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	orders, totalCount, err := s.purchaseOrderRepository.FindPaginated(ctx,
+		&repository.PurchaseOrderFilter{
+			PONumber:   input.PONumber,
+			SupplierID: input.SupplierID,
+			Status:     input.Status,
+			StartDate:  input.StartDate,
+			EndDate:    input.EndDate,
+			Limit:      input.PageSize,
+			Offset:     input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]PurchaseOrderObject, 0, len(orders))
+	for i := range orders {
+		data = append(data, toPurchaseOrderObject(&orders[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetPurchaseOrdersResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package purchaseorders
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdatePurchaseOrder struct {
+	*UpdatePurchaseOrderRequest
+}
+
+func (req *requestUpdatePurchaseOrder) sanitize() {
+	req.PurchaseOrderID = strings.TrimSpace(req.PurchaseOrderID)
+	req.PurchaseOrderPayload.sanitize()
+}
+
+func (req *requestUpdatePurchaseOrder) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.PurchaseOrderID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPurchaseOrderID,
+			Message: err.Error(),
+		})
+	}
+	return append(fieldValidation, req.PurchaseOrderPayload.validateField()...)
+}
+
+// UpdatePurchaseOrder replaces the header and the items of a DRAFT order.
+// The payment terms are copied from the supplier again.
+func (s *Service) UpdatePurchaseOrder(
+	ctx context.Context,
+	request *UpdatePurchaseOrderRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdatePurchaseOrder{
+		UpdatePurchaseOrderRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	current, err := s.findPurchaseOrderForUpdate(ctx, tx, input.PurchaseOrderID)
+	if err != nil {
+		// error is already handled by findPurchaseOrderForUpdate
+		return err
+	}
+	if current.Status != repository.PurchaseOrderStatusDraft {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"only DRAFT purchase orders can be changed, current status is "+
+				string(current.Status),
+		))
+	}
+	order, err := s.buildPurchaseOrder(ctx, tx, &input.PurchaseOrderPayload)
+	if err != nil {
+		// error is already handled by buildPurchaseOrder
+		return err
+	}
+	order.ID = current.ID
+	order.UpdatedBy = userID
+	if err := s.purchaseOrderRepository.UpdateTransaction(ctx, tx, order); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return tx.Commit(ctx)
+}
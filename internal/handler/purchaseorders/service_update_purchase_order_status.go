@@ -0,0 +1,98 @@
+package purchaseorders
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdatePurchaseOrderStatus struct {
+	*UpdatePurchaseOrderStatusRequest
+}
+
+func (req *requestUpdatePurchaseOrderStatus) sanitize() {
+	req.PurchaseOrderID = strings.TrimSpace(req.PurchaseOrderID)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+}
+
+func (req *requestUpdatePurchaseOrderStatus) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.PurchaseOrderID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPurchaseOrderID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateOneOf(req.Status, []string{
+		string(repository.PurchaseOrderStatusSent),
+		string(repository.PurchaseOrderStatusClosed),
+	}); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldStatus,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// UpdatePurchaseOrderStatus sends a draft to the supplier or closes an
+// order. Closing a partially received order gives up on the remaining
+// quantities.
+func (s *Service) UpdatePurchaseOrderStatus(
+	ctx context.Context,
+	request *UpdatePurchaseOrderStatusRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdatePurchaseOrderStatus{
+		UpdatePurchaseOrderStatusRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	order, err := s.findPurchaseOrderForUpdate(ctx, tx, input.PurchaseOrderID)
+	if err != nil {
+		// error is already handled by findPurchaseOrderForUpdate
+		return err
+	}
+	nextStatus := repository.PurchaseOrderStatus(input.Status)
+	if !canTransition(order.Status, nextStatus) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"cannot change purchase order status from "+
+				string(order.Status)+" to "+string(nextStatus),
+		))
+	}
+	now := time.Now()
+	switch nextStatus {
+	case repository.PurchaseOrderStatusSent:
+		order.SentAt.Time = now
+		order.SentAt.Valid = true
+	case repository.PurchaseOrderStatusClosed:
+		order.ClosedAt.Time = now
+		order.ClosedAt.Valid = true
+	}
+	order.Status = nextStatus
+	order.UpdatedBy = userID
+	if err := s.purchaseOrderRepository.UpdateStatusTransaction(ctx, tx, order); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,346 @@
+package purchaseorders
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+var allowedStatuses = []string{
+	string(repository.PurchaseOrderStatusDraft),
+	string(repository.PurchaseOrderStatusSent),
+	string(repository.PurchaseOrderStatusPartiallyReceived),
+	string(repository.PurchaseOrderStatusReceived),
+	string(repository.PurchaseOrderStatusClosed),
+}
+
+var allowedCostPolicies = []string{
+	string(repository.GoodsReceiptCostPolicyLastCost),
+	string(repository.GoodsReceiptCostPolicyWeightedAverage),
+}
+
+// allowedStatusTransitions lists the next statuses an order may move to.
+// PARTIALLY_RECEIVED and RECEIVED are only reached through goods receipts,
+// CLOSED is final.
+var allowedStatusTransitions = map[repository.PurchaseOrderStatus][]repository.PurchaseOrderStatus{
+	repository.PurchaseOrderStatusDraft: {
+		repository.PurchaseOrderStatusSent,
+		repository.PurchaseOrderStatusClosed,
+	},
+	repository.PurchaseOrderStatusSent: {
+		repository.PurchaseOrderStatusPartiallyReceived,
+		repository.PurchaseOrderStatusReceived,
+		repository.PurchaseOrderStatusClosed,
+	},
+	repository.PurchaseOrderStatusPartiallyReceived: {
+		repository.PurchaseOrderStatusPartiallyReceived,
+		repository.PurchaseOrderStatusReceived,
+		repository.PurchaseOrderStatusClosed,
+	},
+	repository.PurchaseOrderStatusReceived: {
+		repository.PurchaseOrderStatusClosed,
+	},
+}
+
+func canTransition(from, to repository.PurchaseOrderStatus) bool {
+	for _, next := range allowedStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitize trims the payload, an empty note is treated as not set
+func (payload *PurchaseOrderPayload) sanitize() {
+	payload.SupplierID = strings.TrimSpace(payload.SupplierID)
+	payload.OrderDate = strings.TrimSpace(payload.OrderDate)
+	payload.ExpectedDate = trimOptional(payload.ExpectedDate)
+	payload.Note = trimOptional(payload.Note)
+	for i := range payload.Items {
+		payload.Items[i].VariantID = strings.TrimSpace(payload.Items[i].VariantID)
+	}
+}
+
+// validateField also parses the dates, an empty order date is today
+func (payload *PurchaseOrderPayload) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(payload.SupplierID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSupplierID,
+			Message: err.Error(),
+		})
+	}
+	if payload.OrderDate == "" {
+		payload.OrderDate = time.Now().Format(dateLayout)
+	}
+	orderDate, err := time.Parse(dateLayout, payload.OrderDate)
+	if err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldOrderDate,
+			Message: "invalid date format, use YYYY-MM-DD",
+		})
+	}
+	payload.orderDate = orderDate
+	payload.expectedDate = sql.NullTime{}
+	if payload.ExpectedDate != nil {
+		expectedDate, err := time.Parse(dateLayout, *payload.ExpectedDate)
+		if err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldExpectedDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		} else if !payload.orderDate.IsZero() && expectedDate.Before(payload.orderDate) {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldExpectedDate,
+				Message: "expected_date must not be before order_date",
+			})
+		}
+		payload.expectedDate = sql.NullTime{Time: expectedDate, Valid: err == nil}
+	}
+	if payload.Note != nil {
+		if err := common.ValidateMaxLengthStr(*payload.Note, maxLengthNote); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldNote,
+				Message: err.Error(),
+			})
+		}
+	}
+	if len(payload.Items) == 0 || len(payload.Items) > maxItems {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldItems,
+			Message: fmt.Sprintf("items must contain 1 to %d items", maxItems),
+		})
+	}
+	seen := make(map[string]bool, len(payload.Items))
+	for i, item := range payload.Items {
+		field := func(name string) string {
+			return fmt.Sprintf("%s[%d].%s", fieldValidationFieldItems, i, name)
+		}
+		if err := common.ValidateUUIDFormat(item.VariantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("variant_id"),
+				Message: err.Error(),
+			})
+		} else if seen[item.VariantID] {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("variant_id"),
+				Message: "variant is already ordered in another item",
+			})
+		}
+		seen[item.VariantID] = true
+		if !item.Quantity.IsPositive() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("quantity"),
+				Message: "quantity must be greater than 0",
+			})
+		}
+		if item.UnitCost.IsNegative() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("unit_cost"),
+				Message: "unit_cost must not be negative",
+			})
+		}
+	}
+	return fieldValidation
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// lineAmount is the ordered quantity at the item unit cost
+func lineAmount(item *repository.PurchaseOrderItemData) decimal.Decimal {
+	return item.Quantity.Mul(item.UnitCost).Round(2)
+}
+
+// buildPurchaseOrder resolves the supplier and the variants of the payload.
+// The payment terms are copied from the supplier so later supplier edits do
+// not change existing orders.
+func (s *Service) buildPurchaseOrder(
+	ctx context.Context,
+	tx pgx.Tx,
+	payload *PurchaseOrderPayload,
+) (*repository.PurchaseOrderData, error) {
+	supplier, err := s.supplierRepository.FindByID(ctx, payload.SupplierID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if err != nil || !supplier.IsActive {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+			Field:   fieldValidationFieldSupplierID,
+			Message: "supplier not found or inactive",
+		}})
+	}
+	variantIDs := make([]string, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		variantIDs = append(variantIDs, item.VariantID)
+	}
+	variants, err := s.productVariantRepository.FindManyByID(ctx, tx, variantIDs)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	variantByID := make(map[string]repository.ProductVariantData, len(variants))
+	for _, variant := range variants {
+		variantByID[variant.ID] = variant
+	}
+	order := &repository.PurchaseOrderData{
+		SupplierID:      supplier.ID,
+		SupplierName:    supplier.Name,
+		OrderDate:       payload.orderDate,
+		ExpectedDate:    payload.expectedDate,
+		PaymentTermDays: supplier.PaymentTermDays,
+		TotalAmount:     decimal.Zero,
+		Items:           make([]repository.PurchaseOrderItemData, 0, len(payload.Items)),
+	}
+	if payload.Note != nil {
+		order.Note = sql.NullString{String: *payload.Note, Valid: true}
+	}
+	fieldValidation := []httperror.FieldValidation{}
+	for i, request := range payload.Items {
+		variant, ok := variantByID[request.VariantID]
+		if !ok {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fmt.Sprintf("%s[%d].variant_id", fieldValidationFieldItems, i),
+				Message: "variant not found",
+			})
+			continue
+		}
+		item := repository.PurchaseOrderItemData{
+			ID:          uuid.NewString(),
+			VariantID:   variant.ID,
+			ProductName: variant.FullName,
+			Quantity:    request.Quantity.Round(4),
+			UnitCost:    request.UnitCost.Round(2),
+		}
+		order.TotalAmount = order.TotalAmount.Add(lineAmount(&item))
+		order.Items = append(order.Items, item)
+	}
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	return order, nil
+}
+
+// findPurchaseOrderForUpdate locks the order and maps a missing order to
+// not found
+func (s *Service) findPurchaseOrderForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	purchaseOrderID string,
+) (*repository.PurchaseOrderData, error) {
+	order, err := s.purchaseOrderRepository.FindByIDForUpdate(ctx, tx, purchaseOrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"purchase order not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return order, nil
+}
+
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+func fromNullDecimal(value decimal.NullDecimal) *decimal.Decimal {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Decimal
+}
+
+func toPurchaseOrderObject(order *repository.PurchaseOrderData) PurchaseOrderObject {
+	object := PurchaseOrderObject{
+		PurchaseOrderID: order.ID,
+		PONumber:        order.PONumber,
+		SupplierID:      order.SupplierID,
+		SupplierName:    order.SupplierName,
+		OrderDate:       order.OrderDate.Format(dateLayout),
+		DueDate:         order.OrderDate.AddDate(0, 0, order.PaymentTermDays).Format(dateLayout),
+		Status:          string(order.Status),
+		PaymentTermDays: order.PaymentTermDays,
+		TotalAmount:     order.TotalAmount,
+		Note:            fromNullString(order.Note),
+		CreatedBy:       order.CreatedBy,
+		UpdatedBy:       order.UpdatedBy,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+	}
+	if order.ExpectedDate.Valid {
+		expectedDate := order.ExpectedDate.Time.Format(dateLayout)
+		object.ExpectedDate = &expectedDate
+	}
+	if order.SentAt.Valid {
+		object.SentAt = &order.SentAt.Time
+	}
+	if order.ClosedAt.Valid {
+		object.ClosedAt = &order.ClosedAt.Time
+	}
+	for i := range order.Items {
+		item := &order.Items[i]
+		object.Items = append(object.Items, PurchaseOrderItemObject{
+			PurchaseOrderItemID: item.ID,
+			VariantID:           item.VariantID,
+			ProductName:         item.ProductName,
+			Quantity:            item.Quantity,
+			UnitCost:            item.UnitCost,
+			LineAmount:          lineAmount(item),
+			ReceivedQuantity:    item.ReceivedQuantity,
+			RemainingQuantity:   item.Quantity.Sub(item.ReceivedQuantity),
+		})
+	}
+	for i := range order.Receipts {
+		receipt := &order.Receipts[i]
+		receiptObject := GoodsReceiptObject{
+			ReceiptID:   receipt.ID,
+			ReceiptDate: receipt.ReceiptDate.Format(dateLayout),
+			CostPolicy:  fromNullString(receipt.CostPolicy),
+			Note:        fromNullString(receipt.Note),
+			CreatedBy:   receipt.CreatedBy,
+			CreatedAt:   receipt.CreatedAt,
+			Items:       make([]GoodsReceiptItemObject, 0, len(receipt.Items)),
+		}
+		for _, item := range receipt.Items {
+			receiptObject.Items = append(receiptObject.Items, GoodsReceiptItemObject{
+				PurchaseOrderItemID: item.PurchaseOrderItemID,
+				VariantID:           item.VariantID,
+				ProductName:         item.ProductName,
+				Quantity:            item.Quantity,
+				UnitCost:            item.UnitCost,
+				PreviousCostPrice:   fromNullDecimal(item.PreviousCostPrice),
+				NewCostPrice:        fromNullDecimal(item.NewCostPrice),
+			})
+		}
+		object.Receipts = append(object.Receipts, receiptObject)
+	}
+	return object
+}
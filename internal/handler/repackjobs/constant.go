@@ -0,0 +1,15 @@
+package repackjobs
+
+const (
+	fieldValidationFieldJobID          = "job_id"
+	fieldValidationFieldChildVariantID = "child_variant_id"
+	fieldValidationFieldVariantID      = "variant_id"
+	fieldValidationFieldQuantity       = "quantity"
+	fieldValidationFieldStatus         = "status"
+	fieldValidationFieldNote           = "note"
+
+	maxLengthNote = 255
+
+	// stockReferenceType marks stock movements posted by a repack job
+	stockReferenceType = "REPACK_JOB"
+)
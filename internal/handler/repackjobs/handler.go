@@ -0,0 +1,87 @@
+package repackjobs
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service RepackJobService
+}
+
+func NewHandler(service RepackJobService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/repack-jobs")
+	endpoint.POST("/", h.CreateRepackJob)
+	endpoint.GET("/", h.GetRepackJobs)
+	endpoint.GET("/:job_id", h.GetRepackJob)
+	endpoint.PATCH("/:job_id/status", h.UpdateRepackJobStatus)
+}
+
+func (h *Handler) CreateRepackJob(c *gin.Context) {
+	request := &CreateRepackJobRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateRepackJob(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetRepackJobs(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetRepackJobsRequest{
+		PaginationData: *pagination,
+		Status:         c.Query("status"),
+		VariantID:      c.Query("variant_id"),
+	}
+	response, err := h.service.GetRepackJobs(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetRepackJob(c *gin.Context) {
+	request := &GetRepackJobRequest{
+		JobID: c.Param("job_id"),
+	}
+	response, err := h.service.GetRepackJob(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateRepackJobStatus(c *gin.Context) {
+	request := &UpdateRepackJobStatusRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.JobID = c.Param("job_id")
+	if err := h.service.UpdateRepackJobStatus(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,42 @@
+package repackjobs
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type CreateRepackJobRequest struct {
+	ChildVariantID string          `json:"child_variant_id"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	// Status is optional, an empty status executes the job right away
+	Status string  `json:"status"`
+	Note   *string `json:"note"`
+}
+
+type CreateRepackJobResponse struct {
+	JobID string `json:"job_id"`
+}
+
+type GetRepackJobRequest struct {
+	JobID string `json:"job_id"`
+}
+
+type GetRepackJobResponse struct {
+	Data RepackJobObject `json:"data"`
+}
+
+type GetRepackJobsRequest struct {
+	util.PaginationData `json:"pagination"`
+	Status              string `json:"status"`
+	VariantID           string `json:"variant_id"`
+}
+
+type GetRepackJobsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []RepackJobObject `json:"data"`
+}
+
+type UpdateRepackJobStatusRequest struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+}
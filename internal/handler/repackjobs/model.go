@@ -0,0 +1,27 @@
+package repackjobs
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type RepackJobObject struct {
+	JobID           string          `json:"job_id"`
+	RecipeID        string          `json:"recipe_id"`
+	ParentVariantID string          `json:"parent_variant_id"`
+	ParentFullName  string          `json:"parent_full_name"`
+	ChildVariantID  string          `json:"child_variant_id"`
+	ChildFullName   string          `json:"child_full_name"`
+	QuantityRatio   float32         `json:"quantity_ratio"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	ParentQuantity  decimal.Decimal `json:"parent_quantity"`
+	Status          string          `json:"status"`
+	Operator        string          `json:"operator"`
+	Note            *string         `json:"note"`
+	StartedAt       *time.Time      `json:"started_at"`
+	CompletedAt     *time.Time      `json:"completed_at"`
+	CancelledAt     *time.Time      `json:"cancelled_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
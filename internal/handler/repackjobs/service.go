@@ -0,0 +1,36 @@
+package repackjobs
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type RepackJobService interface {
+	CreateRepackJob(ctx context.Context, request *CreateRepackJobRequest) (*CreateRepackJobResponse, error)
+	GetRepackJob(ctx context.Context, request *GetRepackJobRequest) (*GetRepackJobResponse, error)
+	GetRepackJobs(ctx context.Context, request *GetRepackJobsRequest) (*GetRepackJobsResponse, error)
+	UpdateRepackJobStatus(ctx context.Context, request *UpdateRepackJobStatusRequest) error
+}
+
+type Service struct {
+	db                     *pgxpool.Pool
+	repackJobRepository    repository.RepackJob
+	repackRecipeRepository repository.RepackRecipe
+	stockLedgerRepository  repository.StockLedger
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	repackJobRepository repository.RepackJob,
+	repackRecipeRepository repository.RepackRecipe,
+	stockLedgerRepository repository.StockLedger,
+) RepackJobService {
+	return &Service{
+		db:                     db,
+		repackJobRepository:    repackJobRepository,
+		repackRecipeRepository: repackRecipeRepository,
+		stockLedgerRepository:  stockLedgerRepository,
+	}
+}
@@ -0,0 +1,169 @@
+package repackjobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestCreateRepackJob struct {
+	*CreateRepackJobRequest
+}
+
+func (req *requestCreateRepackJob) sanitize() {
+	req.ChildVariantID = strings.TrimSpace(req.ChildVariantID)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+	if req.Status == "" {
+		req.Status = string(repository.RepackJobStatusDone)
+	}
+	if req.Note != nil {
+		*req.Note = strings.TrimSpace(*req.Note)
+	}
+}
+
+func (req *requestCreateRepackJob) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.ChildVariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldChildVariantID,
+			Message: err.Error(),
+		})
+	}
+	if !req.Quantity.IsPositive() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldQuantity,
+			Message: "quantity must be greater than 0",
+		})
+	}
+	if err := common.ValidateOneOf(req.Status, []string{
+		string(repository.RepackJobStatusPlanned),
+		string(repository.RepackJobStatusInProgress),
+		string(repository.RepackJobStatusDone),
+	}); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldStatus,
+			Message: err.Error(),
+		})
+	}
+	if req.Note != nil {
+		if err := common.ValidateMaxLengthStr(*req.Note, maxLengthNote); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldNote,
+				Message: err.Error(),
+			})
+		}
+	}
+	return fieldValidation
+}
+
+func (s *Service) CreateRepackJob(
+	ctx context.Context,
+	request *CreateRepackJobRequest,
+) (*CreateRepackJobResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateRepackJob{
+		CreateRepackJobRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	recipe, err := s.repackRecipeRepository.FindActiveByChildVariantID(
+		ctx, tx, input.ChildVariantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"repack recipe not found for child variant",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	ratio := decimal.NewFromFloat32(recipe.QuantityRatio)
+	if !ratio.IsPositive() {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"repack recipe has an invalid quantity ratio",
+		))
+	}
+	job := &repository.RepackJobData{
+		ID:              uuid.NewString(),
+		RecipeID:        recipe.ID,
+		ParentVariantID: recipe.ParentVariantID,
+		ChildVariantID:  recipe.ChildVariantID,
+		QuantityRatio:   recipe.QuantityRatio,
+		Quantity:        input.Quantity,
+		// round up so a job never consumes less parent than it needs
+		ParentQuantity: input.Quantity.Div(ratio).RoundCeil(4),
+		Status:         repository.RepackJobStatus(input.Status),
+		Operator:       userID,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+	}
+	if input.Note != nil && *input.Note != "" {
+		job.Note.String = *input.Note
+		job.Note.Valid = true
+	}
+	parentStock, err := s.stockLedgerRepository.FindBalanceByVariantID(
+		ctx, job.ParentVariantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"parent variant not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if parentStock.Quantity.LessThan(job.ParentQuantity) {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"insufficient parent stock for repack job",
+		))
+	}
+	now := time.Now()
+	if job.Status != repository.RepackJobStatusPlanned {
+		job.StartedAt.Time = now
+		job.StartedAt.Valid = true
+	}
+	if job.Status == repository.RepackJobStatusDone {
+		job.CompletedAt.Time = now
+		job.CompletedAt.Valid = true
+	}
+	if err := s.repackJobRepository.InsertTransaction(ctx, tx, job); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if job.Status == repository.RepackJobStatusDone {
+		if err := s.executeRepackJob(ctx, tx, job, userID); err != nil {
+			// error is already handled by executeRepackJob
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateRepackJobResponse{
+		JobID: job.ID,
+	}, nil
+}
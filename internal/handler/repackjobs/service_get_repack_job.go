@@ -0,0 +1,37 @@
+package repackjobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+func (s *Service) GetRepackJob(
+	ctx context.Context,
+	request *GetRepackJobRequest,
+) (*GetRepackJobResponse, error) {
+	request.JobID = strings.TrimSpace(request.JobID)
+	if err := common.ValidateUUIDFormat(request.JobID); err != nil {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{
+			httperror.NewFieldValidation(fieldValidationFieldJobID, err.Error()),
+		})
+	}
+	job, err := s.repackJobRepository.FindByID(ctx, request.JobID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"repack job not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetRepackJobResponse{
+		Data: toRepackJobObject(job),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package repackjobs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetRepackJobs struct {
+	*GetRepackJobsRequest
+}
+
+func (req *requestGetRepackJobs) sanitize() {
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+	req.VariantID = strings.TrimSpace(req.VariantID)
+}
+
+func (req *requestGetRepackJobs) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, []string{
+			string(repository.RepackJobStatusPlanned),
+			string(repository.RepackJobStatusInProgress),
+			string(repository.RepackJobStatusDone),
+			string(repository.RepackJobStatusCancelled),
+		}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.VariantID != "" {
+		if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldVariantID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetRepackJobs(
+	ctx context.Context,
+	request *GetRepackJobsRequest,
+) (*GetRepackJobsResponse, error) {
+	input := &requestGetRepackJobs{
+		GetRepackJobsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	jobs, totalCount, err := s.repackJobRepository.FindPaginated(ctx,
+		&repository.RepackJobFilter{
+			Status:    input.Status,
+			VariantID: input.VariantID,
+			Limit:     input.PageSize,
+			Offset:    input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]RepackJobObject, 0, len(jobs))
+	for i := range jobs {
+		data = append(data, toRepackJobObject(&jobs[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetRepackJobsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package repackjobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateRepackJobStatus struct {
+	*UpdateRepackJobStatusRequest
+}
+
+func (req *requestUpdateRepackJobStatus) sanitize() {
+	req.JobID = strings.TrimSpace(req.JobID)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+}
+
+func (req *requestUpdateRepackJobStatus) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.JobID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldJobID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateOneOf(req.Status, []string{
+		string(repository.RepackJobStatusInProgress),
+		string(repository.RepackJobStatusDone),
+		string(repository.RepackJobStatusCancelled),
+	}); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldStatus,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) UpdateRepackJobStatus(
+	ctx context.Context,
+	request *UpdateRepackJobStatusRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateRepackJobStatus{
+		UpdateRepackJobStatusRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	job, err := s.repackJobRepository.FindByIDForUpdate(ctx, tx, input.JobID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"repack job not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	nextStatus := repository.RepackJobStatus(input.Status)
+	if !canTransition(job.Status, nextStatus) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"cannot change repack job status from "+
+				string(job.Status)+" to "+string(nextStatus),
+		))
+	}
+	now := time.Now()
+	switch nextStatus {
+	case repository.RepackJobStatusInProgress:
+		job.StartedAt.Time = now
+		job.StartedAt.Valid = true
+	case repository.RepackJobStatusDone:
+		if !job.StartedAt.Valid {
+			job.StartedAt.Time = now
+			job.StartedAt.Valid = true
+		}
+		job.CompletedAt.Time = now
+		job.CompletedAt.Valid = true
+	case repository.RepackJobStatusCancelled:
+		job.CancelledAt.Time = now
+		job.CancelledAt.Valid = true
+	}
+	job.Status = nextStatus
+	job.UpdatedBy = userID
+	if err := s.repackJobRepository.UpdateStatusTransaction(ctx, tx, job); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if nextStatus == repository.RepackJobStatusDone {
+		if err := s.executeRepackJob(ctx, tx, job, userID); err != nil {
+			// error is already handled by executeRepackJob
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package repackjobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+// allowedStatusTransitions lists the next statuses a job may move to. DONE and
+// CANCELLED are final, a finished job is corrected with a stock adjustment.
+var allowedStatusTransitions = map[repository.RepackJobStatus][]repository.RepackJobStatus{
+	repository.RepackJobStatusPlanned: {
+		repository.RepackJobStatusInProgress,
+		repository.RepackJobStatusDone,
+		repository.RepackJobStatusCancelled,
+	},
+	repository.RepackJobStatusInProgress: {
+		repository.RepackJobStatusDone,
+		repository.RepackJobStatusCancelled,
+	},
+}
+
+func canTransition(from, to repository.RepackJobStatus) bool {
+	for _, next := range allowedStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// executeRepackJob moves stock for a finished job: the parent is consumed and
+// the child is produced within the caller's transaction.
+func (s *Service) executeRepackJob(
+	ctx context.Context,
+	tx pgx.Tx,
+	job *repository.RepackJobData,
+	userID string,
+) error {
+	movements := []repository.StockMovementData{
+		{
+			ID:           uuid.NewString(),
+			VariantID:    job.ParentVariantID,
+			MovementType: repository.StockMovementTypeRepackConsume,
+			Quantity:     job.ParentQuantity.Neg(),
+		},
+		{
+			ID:           uuid.NewString(),
+			VariantID:    job.ChildVariantID,
+			MovementType: repository.StockMovementTypeRepackProduce,
+			Quantity:     job.Quantity,
+		},
+	}
+	for i := range movements {
+		movements[i].ReferenceType.String = stockReferenceType
+		movements[i].ReferenceType.Valid = true
+		movements[i].ReferenceID.String = job.ID
+		movements[i].ReferenceID.Valid = true
+		movements[i].CreatedBy = userID
+		if err := s.stockLedgerRepository.InsertMovementTransaction(
+			ctx, tx, &movements[i]); err != nil {
+			if errors.Is(err, pg.ErrInsufficientStock) {
+				return httperror.NewBadRequest(ctx, httperror.WithMessage(
+					"insufficient parent stock for repack job",
+				))
+			}
+			return httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+	}
+	return nil
+}
+
+func toRepackJobObject(job *repository.RepackJobData) RepackJobObject {
+	object := RepackJobObject{
+		JobID:           job.ID,
+		RecipeID:        job.RecipeID,
+		ParentVariantID: job.ParentVariantID,
+		ParentFullName:  job.ParentFullName,
+		ChildVariantID:  job.ChildVariantID,
+		ChildFullName:   job.ChildFullName,
+		QuantityRatio:   job.QuantityRatio,
+		Quantity:        job.Quantity,
+		ParentQuantity:  job.ParentQuantity,
+		Status:          string(job.Status),
+		Operator:        job.Operator,
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+	if job.Note.Valid {
+		object.Note = &job.Note.String
+	}
+	if job.StartedAt.Valid {
+		object.StartedAt = &job.StartedAt.Time
+	}
+	if job.CompletedAt.Valid {
+		object.CompletedAt = &job.CompletedAt.Time
+	}
+	if job.CancelledAt.Valid {
+		object.CancelledAt = &job.CancelledAt.Time
+	}
+	return object
+}
@@ -0,0 +1,33 @@
+package sales
+
+const (
+	fieldValidationFieldSaleID        = "sale_id"
+	fieldValidationFieldSaleNumber    = "sale_number"
+	fieldValidationFieldSaleDate      = "sale_date"
+	fieldValidationFieldCustomerID    = "customer_id"
+	fieldValidationFieldPaymentMethod = "payment_method"
+	fieldValidationFieldStatus        = "status"
+	fieldValidationFieldItems         = "items"
+	fieldValidationFieldDiscount      = "discount_amount"
+	fieldValidationFieldPaidAmount    = "paid_amount"
+	fieldValidationFieldNote          = "note"
+	fieldValidationFieldReason        = "reason"
+	fieldValidationFieldReturnDate    = "return_date"
+	fieldValidationFieldStartDate     = "start_date"
+	fieldValidationFieldEndDate       = "end_date"
+	fieldValidationFieldFormat        = "format"
+	fieldValidationFieldWidth         = "width"
+
+	maxLengthNote       = 255
+	maxLengthSaleNumber = 30
+	maxItems            = 100
+
+	dateLayout = "2006-01-02"
+)
+
+// Reference types of the stock movements posted by sales
+const (
+	stockReferenceTypeSale       = "SALE"
+	stockReferenceTypeSaleReturn = "SALE_RETURN"
+	stockReferenceTypeSaleVoid   = "SALE_VOID"
+)
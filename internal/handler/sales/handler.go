@@ -0,0 +1,126 @@
+package sales
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service SaleService
+}
+
+func NewHandler(service SaleService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/sales")
+	endpoint.POST("/", h.CreateSale)
+	endpoint.GET("/", h.GetSales)
+	endpoint.GET("/:sale_id", h.GetSale)
+	endpoint.GET("/:sale_id/receipt", h.GetSaleReceipt)
+	endpoint.POST("/:sale_id/void", h.VoidSale)
+	endpoint.POST("/:sale_id/returns", h.CreateSaleReturn)
+}
+
+func (h *Handler) CreateSale(c *gin.Context) {
+	request := &CreateSaleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateSale(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetSales(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetSalesRequest{
+		PaginationData: *pagination,
+		SaleNumber:     c.Query("sale_number"),
+		PaymentMethod:  c.Query("payment_method"),
+		CustomerID:     c.Query("customer_id"),
+		Status:         c.Query("status"),
+		StartDate:      c.Query("start_date"),
+		EndDate:        c.Query("end_date"),
+	}
+	response, err := h.service.GetSales(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetSale(c *gin.Context) {
+	request := &GetSaleRequest{
+		SaleID: c.Param("sale_id"),
+	}
+	response, err := h.service.GetSale(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) VoidSale(c *gin.Context) {
+	request := &VoidSaleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.SaleID = c.Param("sale_id")
+	if err := h.service.VoidSale(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) CreateSaleReturn(c *gin.Context) {
+	request := &CreateSaleReturnRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.SaleID = c.Param("sale_id")
+	response, err := h.service.CreateSaleReturn(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetSaleReceipt sends the receipt as the raw document instead of JSON
+func (h *Handler) GetSaleReceipt(c *gin.Context) {
+	request := &GetSaleReceiptRequest{
+		SaleID: c.Param("sale_id"),
+		Format: c.Query("format"),
+		Width:  c.Query("width"),
+	}
+	response, err := h.service.GetSaleReceipt(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.Header("Content-Disposition",
+		fmt.Sprintf(`inline; filename="%s"`, response.FileName))
+	c.Data(http.StatusOK, response.ContentType, response.Content)
+}
@@ -0,0 +1,104 @@
+package sales
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type CreateSaleRequest struct {
+	// SaleDate is in YYYY-MM-DD format, today when empty
+	SaleDate      string `json:"sale_date"`
+	PaymentMethod string `json:"payment_method"`
+	// CustomerID is empty for a walk-in customer, the price tier of the
+	// customer picks the default unit prices
+	CustomerID *string `json:"customer_id"`
+	// DiscountAmount is a discount on the whole sale
+	DiscountAmount decimal.Decimal `json:"discount_amount"`
+	// PaidAmount is the cash handed over, it defaults to the total and may
+	// only differ from it for CASH payments
+	PaidAmount *decimal.Decimal        `json:"paid_amount"`
+	Note       *string                 `json:"note"`
+	Items      []CreateSaleItemRequest `json:"items"`
+}
+
+type CreateSaleItemRequest struct {
+	VariantID string `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+	// UnitPrice defaults to the effective price of the variant for the
+	// customer and quantity
+	UnitPrice *decimal.Decimal `json:"unit_price"`
+	// DiscountAmount is a discount on the whole line
+	DiscountAmount decimal.Decimal `json:"discount_amount"`
+}
+
+type CreateSaleResponse struct {
+	SaleID         string          `json:"sale_id"`
+	SaleNumber     string          `json:"sale_number"`
+	TotalAmount    decimal.Decimal `json:"total_amount"`
+	PaidAmount     decimal.Decimal `json:"paid_amount"`
+	ChangeAmount   decimal.Decimal `json:"change_amount"`
+	TotalNetProfit decimal.Decimal `json:"total_net_profit"`
+}
+
+type GetSaleRequest struct {
+	SaleID string `json:"sale_id"`
+}
+
+type GetSaleResponse struct {
+	Data SaleObject `json:"data"`
+}
+
+type GetSalesRequest struct {
+	util.PaginationData `json:"pagination"`
+	SaleNumber          string `json:"sale_number"`
+	PaymentMethod       string `json:"payment_method"`
+	CustomerID          string `json:"customer_id"`
+	Status              string `json:"status"`
+	StartDate           string `json:"start_date"`
+	EndDate             string `json:"end_date"`
+}
+
+type GetSalesResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []SaleObject `json:"data"`
+}
+
+type VoidSaleRequest struct {
+	SaleID string `json:"-"`
+	Reason string `json:"reason"`
+}
+
+type CreateSaleReturnRequest struct {
+	SaleID string `json:"-"`
+	// ReturnDate is in YYYY-MM-DD format, today when empty
+	ReturnDate string  `json:"return_date"`
+	Reason     *string `json:"reason"`
+	// Restock puts the returned items back into stock, true when empty. The
+	// cost of items that are not restocked stays in the cost of goods sold.
+	Restock *bool                         `json:"restock"`
+	Items   []CreateSaleReturnItemRequest `json:"items"`
+}
+
+type CreateSaleReturnItemRequest struct {
+	SaleItemID string `json:"sale_item_id"`
+	Quantity   int    `json:"quantity"`
+}
+
+type CreateSaleReturnResponse struct {
+	ReturnID     string          `json:"return_id"`
+	RefundAmount decimal.Decimal `json:"refund_amount"`
+}
+
+type GetSaleReceiptRequest struct {
+	SaleID string `json:"sale_id"`
+	// Format is escpos, pdf or txt, txt when empty
+	Format string `json:"format"`
+	// Width is the thermal paper width, 58 or 80, 58 when empty
+	Width string `json:"width"`
+}
+
+type GetSaleReceiptResponse struct {
+	ContentType string
+	FileName    string
+	Content     []byte
+}
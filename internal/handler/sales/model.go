@@ -0,0 +1,65 @@
+package sales
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type SaleObject struct {
+	SaleID            string             `json:"sale_id"`
+	SaleNumber        string             `json:"sale_number"`
+	SaleDate          string             `json:"sale_date"`
+	PaymentMethod     string             `json:"payment_method"`
+	Status            string             `json:"status"`
+	SubtotalAmount    decimal.Decimal    `json:"subtotal_amount"`
+	DiscountAmount    decimal.Decimal    `json:"discount_amount"`
+	TotalAmount       decimal.Decimal    `json:"total_amount"`
+	TotalCostAmount   decimal.Decimal    `json:"total_cost_amount"`
+	TotalNetProfit    decimal.Decimal    `json:"total_net_profit"`
+	PaidAmount        decimal.Decimal    `json:"paid_amount"`
+	ChangeAmount      decimal.Decimal    `json:"change_amount"`
+	TotalRefundAmount decimal.Decimal    `json:"total_refund_amount"`
+	Note              *string            `json:"note"`
+	CustomerID        *string            `json:"customer_id"`
+	CustomerName      *string            `json:"customer_name"`
+	PriceTier         string             `json:"price_tier"`
+	VoidReason        *string            `json:"void_reason"`
+	VoidedBy          *string            `json:"voided_by"`
+	VoidedAt          *time.Time         `json:"voided_at"`
+	CreatedBy         string             `json:"created_by"`
+	CreatedAt         time.Time          `json:"created_at"`
+	Items             []SaleItemObject   `json:"items,omitempty"`
+	Returns           []SaleReturnObject `json:"returns,omitempty"`
+}
+
+type SaleItemObject struct {
+	SaleItemID       string          `json:"sale_item_id"`
+	VariantID        string          `json:"variant_id"`
+	ProductName      string          `json:"product_name"`
+	Quantity         int             `json:"quantity"`
+	UnitPrice        decimal.Decimal `json:"unit_price"`
+	DiscountAmount   decimal.Decimal `json:"discount_amount"`
+	NetAmount        decimal.Decimal `json:"net_amount"`
+	CostPrice        decimal.Decimal `json:"cost_price"`
+	NetProfit        decimal.Decimal `json:"net_profit"`
+	ReturnedQuantity int             `json:"returned_quantity"`
+}
+
+type SaleReturnObject struct {
+	ReturnID     string                 `json:"return_id"`
+	ReturnDate   string                 `json:"return_date"`
+	RefundAmount decimal.Decimal        `json:"refund_amount"`
+	CostAmount   decimal.Decimal        `json:"cost_amount"`
+	Reason       *string                `json:"reason"`
+	CreatedBy    string                 `json:"created_by"`
+	CreatedAt    time.Time              `json:"created_at"`
+	Items        []SaleReturnItemObject `json:"items"`
+}
+
+type SaleReturnItemObject struct {
+	SaleItemID   string          `json:"sale_item_id"`
+	Quantity     int             `json:"quantity"`
+	RefundAmount decimal.Decimal `json:"refund_amount"`
+	CostAmount   decimal.Decimal `json:"cost_amount"`
+}
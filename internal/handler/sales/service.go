@@ -0,0 +1,51 @@
+package sales
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type SaleService interface {
+	CreateSale(ctx context.Context, request *CreateSaleRequest) (*CreateSaleResponse, error)
+	GetSale(ctx context.Context, request *GetSaleRequest) (*GetSaleResponse, error)
+	GetSales(ctx context.Context, request *GetSalesRequest) (*GetSalesResponse, error)
+	VoidSale(ctx context.Context, request *VoidSaleRequest) error
+	CreateSaleReturn(
+		ctx context.Context,
+		request *CreateSaleReturnRequest,
+	) (*CreateSaleReturnResponse, error)
+	GetSaleReceipt(
+		ctx context.Context,
+		request *GetSaleReceiptRequest,
+	) (*GetSaleReceiptResponse, error)
+}
+
+type Service struct {
+	db                       *pgxpool.Pool
+	saleRepository           repository.Sale
+	productVariantRepository repository.ProductVariant
+	stockLedgerRepository    repository.StockLedger
+	shopSettingRepository    repository.ShopSetting
+	tierPriceResolver        *pricing.TierPriceResolver
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	saleRepository repository.Sale,
+	productVariantRepository repository.ProductVariant,
+	stockLedgerRepository repository.StockLedger,
+	shopSettingRepository repository.ShopSetting,
+	tierPriceResolver *pricing.TierPriceResolver,
+) SaleService {
+	return &Service{
+		db:                       db,
+		saleRepository:           saleRepository,
+		productVariantRepository: productVariantRepository,
+		stockLedgerRepository:    stockLedgerRepository,
+		shopSettingRepository:    shopSettingRepository,
+		tierPriceResolver:        tierPriceResolver,
+	}
+}
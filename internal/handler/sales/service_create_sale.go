@@ -0,0 +1,347 @@
+package sales
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestCreateSale struct {
+	*CreateSaleRequest
+	saleDate time.Time
+}
+
+func (req *requestCreateSale) sanitize() {
+	req.SaleDate = strings.TrimSpace(req.SaleDate)
+	req.PaymentMethod = strings.TrimSpace(strings.ToUpper(req.PaymentMethod))
+	if req.CustomerID != nil {
+		*req.CustomerID = strings.TrimSpace(*req.CustomerID)
+		if *req.CustomerID == "" {
+			req.CustomerID = nil
+		}
+	}
+	if req.Note != nil {
+		*req.Note = strings.TrimSpace(*req.Note)
+		if *req.Note == "" {
+			req.Note = nil
+		}
+	}
+	for i := range req.Items {
+		req.Items[i].VariantID = strings.TrimSpace(req.Items[i].VariantID)
+	}
+}
+
+func (req *requestCreateSale) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.SaleDate == "" {
+		req.saleDate = time.Now()
+	} else {
+		saleDate, err := time.Parse(dateLayout, req.SaleDate)
+		if err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldSaleDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+		req.saleDate = saleDate
+	}
+	if err := common.ValidateOneOf(req.PaymentMethod, allowedPaymentMethods); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPaymentMethod,
+			Message: err.Error(),
+		})
+	}
+	if req.CustomerID != nil {
+		if err := common.ValidateUUIDFormat(*req.CustomerID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldCustomerID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.DiscountAmount.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldDiscount,
+			Message: "discount_amount must not be negative",
+		})
+	}
+	if req.PaidAmount != nil && req.PaidAmount.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPaidAmount,
+			Message: "paid_amount must not be negative",
+		})
+	}
+	if req.Note != nil {
+		if err := common.ValidateMaxLengthStr(*req.Note, maxLengthNote); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldNote,
+				Message: err.Error(),
+			})
+		}
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxItems {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldItems,
+			Message: fmt.Sprintf("items must contain 1 to %d items", maxItems),
+		})
+	}
+	for i := range req.Items {
+		fieldValidation = append(fieldValidation, validateItem(i, &req.Items[i])...)
+	}
+	return fieldValidation
+}
+
+func validateItem(index int, item *CreateSaleItemRequest) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	field := func(name string) string {
+		return fmt.Sprintf("%s[%d].%s", fieldValidationFieldItems, index, name)
+	}
+	if err := common.ValidateUUIDFormat(item.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("variant_id"),
+			Message: err.Error(),
+		})
+	}
+	if item.Quantity <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("quantity"),
+			Message: "quantity must be greater than 0",
+		})
+	}
+	if item.UnitPrice != nil && item.UnitPrice.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("unit_price"),
+			Message: "unit_price must not be negative",
+		})
+	}
+	if item.DiscountAmount.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field("discount_amount"),
+			Message: "discount_amount must not be negative",
+		})
+	}
+	return fieldValidation
+}
+
+// CreateSale records an in-store sale, the stock of the sold variants is
+// taken out in the same transaction
+func (s *Service) CreateSale(
+	ctx context.Context,
+	request *CreateSaleRequest,
+) (*CreateSaleResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateSale{
+		CreateSaleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	customerID := ""
+	if input.CustomerID != nil {
+		customerID = *input.CustomerID
+	}
+	tier, err := s.tierPriceResolver.CustomerTier(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, pricing.ErrCustomerNotFound) ||
+			errors.Is(err, pricing.ErrCustomerInactive) {
+			return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+				Field:   fieldValidationFieldCustomerID,
+				Message: err.Error(),
+			}})
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	items, err := s.mapSaleItems(ctx, tx, tier, input.Items)
+	if err != nil {
+		// error is already handled by mapSaleItems
+		return nil, err
+	}
+	sale := &repository.SaleData{
+		ID:             uuid.NewString(),
+		SaleDate:       input.saleDate,
+		PaymentMethod:  repository.SalePaymentMethod(input.PaymentMethod),
+		Status:         repository.SaleStatusCompleted,
+		PriceTier:      tier,
+		DiscountAmount: input.DiscountAmount.Round(2),
+		CreatedBy:      userID,
+		Items:          items,
+	}
+	if input.CustomerID != nil {
+		sale.CustomerID = sql.NullString{String: *input.CustomerID, Valid: true}
+	}
+	if input.Note != nil {
+		sale.Note = sql.NullString{String: *input.Note, Valid: true}
+	}
+	subtotal := decimal.Zero
+	for i := range sale.Items {
+		subtotal = subtotal.Add(lineAmount(&sale.Items[i]))
+	}
+	if sale.DiscountAmount.GreaterThan(subtotal) {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+			Field:   fieldValidationFieldDiscount,
+			Message: "discount_amount must not exceed the sale subtotal",
+		}})
+	}
+	calculateTotals(sale)
+	if err := applyPayment(sale, input.PaidAmount); err != nil {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+			Field:   fieldValidationFieldPaidAmount,
+			Message: err.Error(),
+		}})
+	}
+	if err := s.saleRepository.InsertTransaction(ctx, tx, sale); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	movements := make([]stockMovement, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		movements = append(movements, stockMovement{
+			variantID:   item.VariantID,
+			productName: item.ProductName,
+			quantity:    -item.Quantity,
+		})
+	}
+	if err := s.postStockMovements(
+		ctx, tx, movements, stockReferenceTypeSale, sale.ID, userID); err != nil {
+		// error is already handled by postStockMovements
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateSaleResponse{
+		SaleID:         sale.ID,
+		SaleNumber:     sale.SaleNumber,
+		TotalAmount:    sale.TotalAmount,
+		PaidAmount:     sale.PaidAmount,
+		ChangeAmount:   sale.ChangeAmount,
+		TotalNetProfit: sale.TotalNetProfit,
+	}, nil
+}
+
+// applyPayment fills the paid and change amount. Only cash may be paid
+// above the total, the difference is given back as change.
+func applyPayment(sale *repository.SaleData, paidAmount *decimal.Decimal) error {
+	sale.PaidAmount = sale.TotalAmount
+	sale.ChangeAmount = decimal.Zero
+	if paidAmount == nil {
+		return nil
+	}
+	paid := paidAmount.Round(2)
+	if paid.LessThan(sale.TotalAmount) {
+		return fmt.Errorf("paid_amount must not be less than the total %s",
+			sale.TotalAmount.StringFixed(2))
+	}
+	if sale.PaymentMethod != repository.SalePaymentMethodCash &&
+		!paid.Equal(sale.TotalAmount) {
+		return fmt.Errorf("paid_amount must equal the total for %s payments",
+			sale.PaymentMethod)
+	}
+	sale.PaidAmount = paid
+	sale.ChangeAmount = paid.Sub(sale.TotalAmount)
+	return nil
+}
+
+// mapSaleItems resolves the variants of the items, the unit price defaults
+// to the effective price for the price tier and the item quantity, the cost
+// is taken from the variant at sale time
+func (s *Service) mapSaleItems(
+	ctx context.Context,
+	tx pgx.Tx,
+	tier repository.CustomerPriceTier,
+	requests []CreateSaleItemRequest,
+) ([]repository.SaleItemData, error) {
+	variantIDs := make([]string, 0, len(requests))
+	for _, request := range requests {
+		variantIDs = append(variantIDs, request.VariantID)
+	}
+	variants, err := s.productVariantRepository.FindManyByID(ctx, tx, variantIDs)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	pricesByVariantID, err := s.tierPriceResolver.TierPrices(ctx, variantIDs)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	variantByID := make(map[string]repository.ProductVariantData, len(variants))
+	for _, variant := range variants {
+		variantByID[variant.ID] = variant
+	}
+	fieldValidation := []httperror.FieldValidation{}
+	items := make([]repository.SaleItemData, 0, len(requests))
+	for i, request := range requests {
+		field := fmt.Sprintf("%s[%d].variant_id", fieldValidationFieldItems, i)
+		variant, ok := variantByID[request.VariantID]
+		if !ok {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field,
+				Message: "variant not found",
+			})
+			continue
+		}
+		if !variant.CostPrice.Valid {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field,
+				Message: "variant has no cost price, set it before selling",
+			})
+			continue
+		}
+		effectivePrice := pricing.ResolveUnitPrice(
+			variant.SellingPrice, tier, request.Quantity,
+			pricesByVariantID[variant.ID])
+		item := repository.SaleItemData{
+			ID:             uuid.NewString(),
+			VariantID:      variant.ID,
+			ProductName:    variant.FullName,
+			Quantity:       request.Quantity,
+			UnitPrice:      effectivePrice.UnitPrice,
+			DiscountAmount: request.DiscountAmount.Round(2),
+			CostPrice:      variant.CostPrice.Decimal,
+		}
+		if request.UnitPrice != nil {
+			item.UnitPrice = request.UnitPrice.Round(2)
+		}
+		if lineAmount(&item).IsNegative() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fmt.Sprintf("%s[%d].discount_amount", fieldValidationFieldItems, i),
+				Message: "discount_amount must not exceed the line amount",
+			})
+			continue
+		}
+		items = append(items, item)
+	}
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	return items, nil
+}
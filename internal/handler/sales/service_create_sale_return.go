@@ -0,0 +1,242 @@
+package sales
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestCreateSaleReturn struct {
+	*CreateSaleReturnRequest
+	returnDate time.Time
+}
+
+func (req *requestCreateSaleReturn) sanitize() {
+	req.SaleID = strings.TrimSpace(req.SaleID)
+	req.ReturnDate = strings.TrimSpace(req.ReturnDate)
+	if req.Reason != nil {
+		*req.Reason = strings.TrimSpace(*req.Reason)
+		if *req.Reason == "" {
+			req.Reason = nil
+		}
+	}
+	for i := range req.Items {
+		req.Items[i].SaleItemID = strings.TrimSpace(req.Items[i].SaleItemID)
+	}
+}
+
+func (req *requestCreateSaleReturn) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SaleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSaleID,
+			Message: err.Error(),
+		})
+	}
+	if req.ReturnDate == "" {
+		req.returnDate = time.Now()
+	} else {
+		returnDate, err := time.Parse(dateLayout, req.ReturnDate)
+		if err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldReturnDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+		req.returnDate = returnDate
+	}
+	if req.Reason != nil {
+		if err := common.ValidateMaxLengthStr(*req.Reason, maxLengthNote); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldReason,
+				Message: err.Error(),
+			})
+		}
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxItems {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldItems,
+			Message: fmt.Sprintf("items must contain 1 to %d items", maxItems),
+		})
+	}
+	seenItemIDs := map[string]bool{}
+	for i, item := range req.Items {
+		field := func(name string) string {
+			return fmt.Sprintf("%s[%d].%s", fieldValidationFieldItems, i, name)
+		}
+		if err := common.ValidateUUIDFormat(item.SaleItemID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("sale_item_id"),
+				Message: err.Error(),
+			})
+		} else if seenItemIDs[item.SaleItemID] {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("sale_item_id"),
+				Message: "sale_item_id must be unique",
+			})
+		}
+		seenItemIDs[item.SaleItemID] = true
+		if item.Quantity <= 0 {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("quantity"),
+				Message: "quantity must be greater than 0",
+			})
+		}
+	}
+	return fieldValidation
+}
+
+// CreateSaleReturn takes items of a sale back. The refund is the share of
+// the line net amount, the return lowers the summary on its return date.
+func (s *Service) CreateSaleReturn(
+	ctx context.Context,
+	request *CreateSaleReturnRequest,
+) (*CreateSaleReturnResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateSaleReturn{
+		CreateSaleReturnRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	sale, err := s.saleRepository.FindByIDForUpdate(ctx, tx, input.SaleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"sale not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if sale.Status != repository.SaleStatusCompleted {
+		return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"a voided sale can not be returned",
+		))
+	}
+	if input.returnDate.Format(dateLayout) < sale.SaleDate.Format(dateLayout) {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+			Field:   fieldValidationFieldReturnDate,
+			Message: "return_date must not be before the sale date",
+		}})
+	}
+	saleReturn := &repository.SaleReturnData{
+		ID:         uuid.NewString(),
+		SaleID:     sale.ID,
+		ReturnDate: input.returnDate,
+		CreatedBy:  userID,
+	}
+	if input.Reason != nil {
+		saleReturn.Reason = sql.NullString{String: *input.Reason, Valid: true}
+	}
+	restock := input.Restock == nil || *input.Restock
+	movements, err := mapReturnItems(ctx, sale, saleReturn, input.Items, restock)
+	if err != nil {
+		// error is already handled by mapReturnItems
+		return nil, err
+	}
+	if err := s.saleRepository.InsertReturnTransaction(ctx, tx, saleReturn); err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if restock {
+		if err := s.postStockMovements(ctx, tx, movements,
+			stockReferenceTypeSaleReturn, saleReturn.ID, userID); err != nil {
+			// error is already handled by postStockMovements
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &CreateSaleReturnResponse{
+		ReturnID:     saleReturn.ID,
+		RefundAmount: saleReturn.RefundAmount,
+	}, nil
+}
+
+// mapReturnItems fills the return items and totals from the sale items and
+// returns the stock movements that put the items back. Items that are not
+// restocked keep their cost as sold, so their return cost is zero.
+func mapReturnItems(
+	ctx context.Context,
+	sale *repository.SaleData,
+	saleReturn *repository.SaleReturnData,
+	requests []CreateSaleReturnItemRequest,
+	restock bool,
+) ([]stockMovement, error) {
+	itemByID := make(map[string]*repository.SaleItemData, len(sale.Items))
+	for i := range sale.Items {
+		itemByID[sale.Items[i].ID] = &sale.Items[i]
+	}
+	fieldValidation := []httperror.FieldValidation{}
+	movements := make([]stockMovement, 0, len(requests))
+	saleReturn.RefundAmount = decimal.Zero
+	saleReturn.CostAmount = decimal.Zero
+	for i, request := range requests {
+		item, ok := itemByID[request.SaleItemID]
+		if !ok {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fmt.Sprintf("%s[%d].sale_item_id", fieldValidationFieldItems, i),
+				Message: "sale item not found",
+			})
+			continue
+		}
+		remaining := item.Quantity - item.ReturnedQuantity
+		if request.Quantity > remaining {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fmt.Sprintf("%s[%d].quantity", fieldValidationFieldItems, i),
+				Message: fmt.Sprintf("only %d left to return", remaining),
+			})
+			continue
+		}
+		returnItem := repository.SaleReturnItemData{
+			ID:           uuid.NewString(),
+			ReturnID:     saleReturn.ID,
+			SaleItemID:   item.ID,
+			Quantity:     request.Quantity,
+			RefundAmount: returnRefundAmount(item, request.Quantity),
+			CostAmount:   decimal.Zero,
+		}
+		if restock {
+			returnItem.CostAmount = item.CostPrice.
+				Mul(decimal.NewFromInt(int64(request.Quantity))).Round(2)
+		}
+		saleReturn.Items = append(saleReturn.Items, returnItem)
+		saleReturn.RefundAmount = saleReturn.RefundAmount.Add(returnItem.RefundAmount)
+		saleReturn.CostAmount = saleReturn.CostAmount.Add(returnItem.CostAmount)
+		movements = append(movements, stockMovement{
+			variantID:   item.VariantID,
+			productName: item.ProductName,
+			quantity:    request.Quantity,
+		})
+	}
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	return movements, nil
+}
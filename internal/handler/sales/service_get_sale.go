@@ -0,0 +1,58 @@
+package sales
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetSale struct {
+	*GetSaleRequest
+}
+
+func (req *requestGetSale) sanitize() {
+	req.SaleID = strings.TrimSpace(req.SaleID)
+}
+
+func (req *requestGetSale) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SaleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSaleID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetSale(
+	ctx context.Context,
+	request *GetSaleRequest,
+) (*GetSaleResponse, error) {
+	input := &requestGetSale{
+		GetSaleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	sale, err := s.saleRepository.FindByID(ctx, input.SaleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"sale not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetSaleResponse{
+		Data: toSaleObject(sale),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package sales
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/receipt"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetSaleReceipt struct {
+	*GetSaleReceiptRequest
+	width receipt.PaperWidth
+}
+
+func (req *requestGetSaleReceipt) sanitize() {
+	req.SaleID = strings.TrimSpace(req.SaleID)
+	req.Format = strings.TrimSpace(strings.ToLower(req.Format))
+	req.Width = strings.TrimSpace(strings.ToLower(req.Width))
+	req.Width = strings.TrimSuffix(req.Width, "mm")
+	if req.Format == "" {
+		req.Format = string(receipt.FormatText)
+	}
+	if req.Width == "" {
+		req.Width = strconv.Itoa(int(receipt.PaperWidth58))
+	}
+}
+
+func (req *requestGetSaleReceipt) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SaleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSaleID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateOneOf(req.Format, []string{
+		string(receipt.FormatESCPOS),
+		string(receipt.FormatPDF),
+		string(receipt.FormatText),
+	}); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldFormat,
+			Message: err.Error(),
+		})
+	}
+	width, err := strconv.Atoi(req.Width)
+	req.width = receipt.PaperWidth(width)
+	if err != nil || (req.width != receipt.PaperWidth58 && req.width != receipt.PaperWidth80) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldWidth,
+			Message: receipt.ErrUnsupportedPaperWidth.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// GetSaleReceipt renders the thermal receipt or the A4 invoice of a sale
+// with the shop header and footer
+func (s *Service) GetSaleReceipt(
+	ctx context.Context,
+	request *GetSaleReceiptRequest,
+) (*GetSaleReceiptResponse, error) {
+	input := &requestGetSaleReceipt{
+		GetSaleReceiptRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	sale, err := s.saleRepository.FindByID(ctx, input.SaleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"sale not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	shop, err := s.shopSettingRepository.Find(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"shop settings are not configured",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	rendered, err := receipt.Render(
+		receipt.Format(input.Format),
+		input.width,
+		&receipt.Document{Shop: shop, Sale: sale},
+	)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetSaleReceiptResponse{
+		ContentType: rendered.ContentType,
+		FileName:    rendered.FileName,
+		Content:     rendered.Content,
+	}, nil
+}
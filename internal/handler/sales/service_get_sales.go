@@ -0,0 +1,128 @@
+package sales
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetSales struct {
+	*GetSalesRequest
+}
+
+func (req *requestGetSales) sanitize() {
+	req.SaleNumber = strings.TrimSpace(req.SaleNumber)
+	req.PaymentMethod = strings.TrimSpace(strings.ToUpper(req.PaymentMethod))
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+	req.StartDate = strings.TrimSpace(req.StartDate)
+	req.EndDate = strings.TrimSpace(req.EndDate)
+}
+
+func (req *requestGetSales) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateMaxLengthStr(req.SaleNumber, maxLengthSaleNumber); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSaleNumber,
+			Message: err.Error(),
+		})
+	}
+	if req.PaymentMethod != "" {
+		if err := common.ValidateOneOf(req.PaymentMethod, allowedPaymentMethods); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldPaymentMethod,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.CustomerID != "" {
+		if err := common.ValidateUUIDFormat(req.CustomerID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldCustomerID,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, allowedStatuses); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	var startDate, endDate time.Time
+	var err error
+	if req.StartDate != "" {
+		if startDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStartDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if req.EndDate != "" {
+		if endDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEndDate,
+				Message: "invalid date format, use YYYY-MM-DD",
+			})
+		}
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldEndDate,
+			Message: "end_date must not be before start_date",
+		})
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetSales(
+	ctx context.Context,
+	request *GetSalesRequest,
+) (*GetSalesResponse, error) {
+	input := &requestGetSales{
+		GetSalesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	sales, totalCount, err := s.saleRepository.FindPaginated(ctx,
+		&repository.SaleFilter{
+			SaleNumber:    input.SaleNumber,
+			PaymentMethod: input.PaymentMethod,
+			CustomerID:    input.CustomerID,
+			Status:        input.Status,
+			StartDate:     input.StartDate,
+			EndDate:       input.EndDate,
+			Limit:         input.PageSize,
+			Offset:        input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]SaleObject, 0, len(sales))
+	for i := range sales {
+		data = append(data, toSaleObject(&sales[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetSalesResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
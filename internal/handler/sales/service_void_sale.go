@@ -0,0 +1,109 @@
+package sales
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestVoidSale struct {
+	*VoidSaleRequest
+}
+
+func (req *requestVoidSale) sanitize() {
+	req.SaleID = strings.TrimSpace(req.SaleID)
+	req.Reason = strings.TrimSpace(req.Reason)
+}
+
+func (req *requestVoidSale) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SaleID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSaleID,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateStringRequired(req.Reason, fieldValidationFieldReason); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldReason,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateMaxLengthStr(req.Reason, maxLengthNote); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldReason,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// VoidSale cancels a sale entered by mistake. The quantities that were not
+// returned yet go back into stock and the sale leaves the summary.
+func (s *Service) VoidSale(ctx context.Context, request *VoidSaleRequest) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestVoidSale{
+		VoidSaleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	sale, err := s.saleRepository.FindByIDForUpdate(ctx, tx, input.SaleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"sale not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if sale.Status != repository.SaleStatusCompleted {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"sale is already voided",
+		))
+	}
+	sale.VoidReason = sql.NullString{String: input.Reason, Valid: true}
+	sale.VoidedBy = sql.NullString{String: userID, Valid: true}
+	if err := s.saleRepository.VoidTransaction(ctx, tx, sale); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	movements := make([]stockMovement, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		movements = append(movements, stockMovement{
+			variantID:   item.VariantID,
+			productName: item.ProductName,
+			quantity:    item.Quantity - item.ReturnedQuantity,
+		})
+	}
+	if err := s.postStockMovements(
+		ctx, tx, movements, stockReferenceTypeSaleVoid, sale.ID, userID); err != nil {
+		// error is already handled by postStockMovements
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package sales
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+var allowedPaymentMethods = []string{
+	string(repository.SalePaymentMethodCash),
+	string(repository.SalePaymentMethodQRIS),
+	string(repository.SalePaymentMethodTransfer),
+}
+
+var allowedStatuses = []string{
+	string(repository.SaleStatusCompleted),
+	string(repository.SaleStatusVoided),
+}
+
+// lineAmount is the line price after the line discount
+func lineAmount(item *repository.SaleItemData) decimal.Decimal {
+	return item.UnitPrice.
+		Mul(decimal.NewFromInt(int64(item.Quantity))).
+		Sub(item.DiscountAmount).
+		Round(2)
+}
+
+// calculateTotals fills the item net amounts and the sale totals. The sale
+// discount is spread over the lines by their amount, the last line takes
+// the rounding difference so the net amounts add up to the total.
+func calculateTotals(sale *repository.SaleData) {
+	subtotal := decimal.Zero
+	for i := range sale.Items {
+		subtotal = subtotal.Add(lineAmount(&sale.Items[i]))
+	}
+	totalCost := decimal.Zero
+	allocated := decimal.Zero
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		amount := lineAmount(item)
+		discount := decimal.Zero
+		if i == len(sale.Items)-1 {
+			discount = sale.DiscountAmount.Sub(allocated)
+		} else if subtotal.IsPositive() {
+			discount = sale.DiscountAmount.Mul(amount).Div(subtotal).Round(2)
+		}
+		allocated = allocated.Add(discount)
+		item.NetAmount = amount.Sub(discount)
+		totalCost = totalCost.Add(
+			item.CostPrice.Mul(decimal.NewFromInt(int64(item.Quantity))))
+	}
+	sale.SubtotalAmount = subtotal
+	sale.TotalAmount = subtotal.Sub(sale.DiscountAmount).Round(2)
+	sale.TotalCostAmount = totalCost.Round(2)
+	sale.TotalNetProfit = sale.TotalAmount.Sub(sale.TotalCostAmount)
+}
+
+// returnRefundAmount is the part of the line net amount refunded for
+// quantity more units. It is taken from the running total so the refunds of
+// a fully returned line add up to its net amount.
+func returnRefundAmount(item *repository.SaleItemData, quantity int) decimal.Decimal {
+	total := decimal.NewFromInt(int64(item.Quantity))
+	refunded := item.NetAmount.
+		Mul(decimal.NewFromInt(int64(item.ReturnedQuantity))).Div(total).Round(2)
+	refundedAfter := item.NetAmount.
+		Mul(decimal.NewFromInt(int64(item.ReturnedQuantity + quantity))).Div(total).Round(2)
+	return refundedAfter.Sub(refunded)
+}
+
+// stockMovement is the stock change of one variant posted by a sale
+type stockMovement struct {
+	variantID   string
+	productName string
+	quantity    int
+}
+
+// postStockMovements applies the movements within the caller's transaction.
+// Variants are locked in id order so concurrent sales can not deadlock.
+func (s *Service) postStockMovements(
+	ctx context.Context,
+	tx pgx.Tx,
+	movements []stockMovement,
+	referenceType, referenceID, userID string,
+) error {
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].variantID < movements[j].variantID
+	})
+	for _, movement := range movements {
+		if movement.quantity == 0 {
+			continue
+		}
+		data := &repository.StockMovementData{
+			ID:           uuid.NewString(),
+			VariantID:    movement.variantID,
+			MovementType: repository.StockMovementTypeSale,
+			Quantity:     decimal.NewFromInt(int64(movement.quantity)),
+			CreatedBy:    userID,
+		}
+		data.ReferenceType.String = referenceType
+		data.ReferenceType.Valid = true
+		data.ReferenceID.String = referenceID
+		data.ReferenceID.Valid = true
+		if err := s.stockLedgerRepository.InsertMovementTransaction(
+			ctx, tx, data); err != nil {
+			if errors.Is(err, pg.ErrInsufficientStock) {
+				return httperror.NewBadRequest(ctx, httperror.WithMessage(
+					"insufficient stock for "+movement.productName,
+				))
+			}
+			return httperror.NewInternalServer(ctx, httperror.WithMessage(
+				"internal_server_error: "+err.Error(),
+			))
+		}
+	}
+	return nil
+}
+
+func toSaleObject(sale *repository.SaleData) SaleObject {
+	object := SaleObject{
+		SaleID:            sale.ID,
+		SaleNumber:        sale.SaleNumber,
+		SaleDate:          sale.SaleDate.Format(dateLayout),
+		PaymentMethod:     string(sale.PaymentMethod),
+		Status:            string(sale.Status),
+		SubtotalAmount:    sale.SubtotalAmount,
+		DiscountAmount:    sale.DiscountAmount,
+		TotalAmount:       sale.TotalAmount,
+		TotalCostAmount:   sale.TotalCostAmount,
+		TotalNetProfit:    sale.TotalNetProfit,
+		PaidAmount:        sale.PaidAmount,
+		ChangeAmount:      sale.ChangeAmount,
+		TotalRefundAmount: sale.TotalRefundAmount,
+		CreatedBy:         sale.CreatedBy,
+		CreatedAt:         sale.CreatedAt,
+	}
+	if sale.Note.Valid {
+		object.Note = &sale.Note.String
+	}
+	if sale.CustomerID.Valid {
+		object.CustomerID = &sale.CustomerID.String
+	}
+	if sale.CustomerName.Valid {
+		object.CustomerName = &sale.CustomerName.String
+	}
+	if sale.VoidReason.Valid {
+		object.VoidReason = &sale.VoidReason.String
+	}
+	if sale.VoidedBy.Valid {
+		object.VoidedBy = &sale.VoidedBy.String
+	}
+	if sale.VoidedAt.Valid {
+		object.VoidedAt = &sale.VoidedAt.Time
+	}
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		quantity := decimal.NewFromInt(int64(item.Quantity))
+		object.Items = append(object.Items, SaleItemObject{
+			SaleItemID:       item.ID,
+			VariantID:        item.VariantID,
+			ProductName:      item.ProductName,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			DiscountAmount:   item.DiscountAmount,
+			NetAmount:        item.NetAmount,
+			CostPrice:        item.CostPrice,
+			NetProfit:        item.NetAmount.Sub(item.CostPrice.Mul(quantity)).Round(2),
+			ReturnedQuantity: item.ReturnedQuantity,
+		})
+	}
+	for i := range sale.Returns {
+		saleReturn := &sale.Returns[i]
+		returnObject := SaleReturnObject{
+			ReturnID:     saleReturn.ID,
+			ReturnDate:   saleReturn.ReturnDate.Format(dateLayout),
+			RefundAmount: saleReturn.RefundAmount,
+			CostAmount:   saleReturn.CostAmount,
+			CreatedBy:    saleReturn.CreatedBy,
+			CreatedAt:    saleReturn.CreatedAt,
+			Items:        make([]SaleReturnItemObject, 0, len(saleReturn.Items)),
+		}
+		if saleReturn.Reason.Valid {
+			returnObject.Reason = &saleReturn.Reason.String
+		}
+		for _, item := range saleReturn.Items {
+			returnObject.Items = append(returnObject.Items, SaleReturnItemObject{
+				SaleItemID:   item.SaleItemID,
+				Quantity:     item.Quantity,
+				RefundAmount: item.RefundAmount,
+				CostAmount:   item.CostAmount,
+			})
+		}
+		object.Returns = append(object.Returns, returnObject)
+	}
+	return object
+}
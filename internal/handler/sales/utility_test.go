@@ -0,0 +1,165 @@
+package sales
+
+import (
+	"testing"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateTotals(t *testing.T) {
+	type line struct {
+		unitPrice string
+		quantity  int
+		discount  string
+		costPrice string
+	}
+	tests := []struct {
+		name          string
+		lines         []line
+		discount      string
+		wantNet       []string
+		wantSubtotal  string
+		wantTotal     string
+		wantCost      string
+		wantNetProfit string
+	}{
+		{
+			name:          "no discount",
+			lines:         []line{{"5000", 2, "0", "3000"}},
+			discount:      "0",
+			wantNet:       []string{"10000"},
+			wantSubtotal:  "10000",
+			wantTotal:     "10000",
+			wantCost:      "6000",
+			wantNetProfit: "4000",
+		},
+		{
+			name:          "line discount lowers the line amount",
+			lines:         []line{{"5000", 2, "500", "3000"}},
+			discount:      "0",
+			wantNet:       []string{"9500"},
+			wantSubtotal:  "9500",
+			wantTotal:     "9500",
+			wantCost:      "6000",
+			wantNetProfit: "3500",
+		},
+		{
+			name: "sale discount is spread by line amount",
+			lines: []line{
+				{"3000", 1, "0", "2000"},
+				{"1000", 1, "0", "500"},
+			},
+			discount:      "400",
+			wantNet:       []string{"2700", "900"},
+			wantSubtotal:  "4000",
+			wantTotal:     "3600",
+			wantCost:      "2500",
+			wantNetProfit: "1100",
+		},
+		{
+			name: "last line takes the rounding difference",
+			lines: []line{
+				{"1000", 1, "0", "600"},
+				{"1000", 1, "0", "600"},
+				{"1000", 1, "0", "600"},
+			},
+			discount:      "100",
+			wantNet:       []string{"966.67", "966.67", "966.66"},
+			wantSubtotal:  "3000",
+			wantTotal:     "2900",
+			wantCost:      "1800",
+			wantNetProfit: "1100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sale := &repository.SaleData{
+				DiscountAmount: decimal.RequireFromString(tt.discount),
+			}
+			for _, l := range tt.lines {
+				sale.Items = append(sale.Items, repository.SaleItemData{
+					UnitPrice:      decimal.RequireFromString(l.unitPrice),
+					Quantity:       l.quantity,
+					DiscountAmount: decimal.RequireFromString(l.discount),
+					CostPrice:      decimal.RequireFromString(l.costPrice),
+				})
+			}
+			calculateTotals(sale)
+			netSum := decimal.Zero
+			for i, want := range tt.wantNet {
+				assertDecimal(t, "net amount", sale.Items[i].NetAmount, want)
+				netSum = netSum.Add(sale.Items[i].NetAmount)
+			}
+			assertDecimal(t, "sum of net amounts", netSum, tt.wantTotal)
+			assertDecimal(t, "subtotal", sale.SubtotalAmount, tt.wantSubtotal)
+			assertDecimal(t, "total", sale.TotalAmount, tt.wantTotal)
+			assertDecimal(t, "total cost", sale.TotalCostAmount, tt.wantCost)
+			assertDecimal(t, "net profit", sale.TotalNetProfit, tt.wantNetProfit)
+		})
+	}
+}
+
+func TestReturnRefundAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		netAmount string
+		quantity  int
+		returns   []int
+		want      []string
+	}{
+		{
+			name:      "full return refunds the net amount",
+			netAmount: "9000",
+			quantity:  3,
+			returns:   []int{3},
+			want:      []string{"9000"},
+		},
+		{
+			name:      "even partial returns",
+			netAmount: "9000",
+			quantity:  3,
+			returns:   []int{1, 2},
+			want:      []string{"3000", "6000"},
+		},
+		{
+			name:      "partial returns add up to the line net",
+			netAmount: "100",
+			quantity:  3,
+			returns:   []int{1, 1, 1},
+			want:      []string{"33.33", "33.34", "33.33"},
+		},
+		{
+			name:      "uneven split of a discounted line",
+			netAmount: "966.67",
+			quantity:  7,
+			returns:   []int{2, 4, 1},
+			want:      []string{"276.19", "552.38", "138.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &repository.SaleItemData{
+				Quantity:  tt.quantity,
+				NetAmount: decimal.RequireFromString(tt.netAmount),
+			}
+			refunded := decimal.Zero
+			for i, quantity := range tt.returns {
+				refund := returnRefundAmount(item, quantity)
+				assertDecimal(t, "refund", refund, tt.want[i])
+				refunded = refunded.Add(refund)
+				item.ReturnedQuantity += quantity
+			}
+			if item.ReturnedQuantity == item.Quantity {
+				assertDecimal(t, "total refund", refunded, tt.netAmount)
+			}
+		})
+	}
+}
+
+func assertDecimal(t *testing.T, field string, got decimal.Decimal, want string) {
+	t.Helper()
+	if !got.Equal(decimal.RequireFromString(want)) {
+		t.Errorf("%s = %s, want %s", field, got, want)
+	}
+}
@@ -1,28 +1,49 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkysr90/rizkiplastik-be/internal/config"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/authentication"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/authevents"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/category"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/channellistings"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/channelprices"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/customers"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/feeschedules"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/onlinetransactions"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/packagingtypes"
 	packagingtypesPg "github.com/rizkysr90/rizkiplastik-be/internal/handler/packagingtypes/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/pricechanges"
 	productcategoryrules "github.com/rizkysr90/rizkiplastik-be/internal/handler/product_category_rules"
 	productCategoryRulesPg "github.com/rizkysr90/rizkiplastik-be/internal/handler/product_category_rules/repository/pg"
 	productsizeunitrules "github.com/rizkysr90/rizkiplastik-be/internal/handler/product_sizeunit_rules"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/products"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/purchaseorders"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/repackjobs"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/sales"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/shopsettings"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/sizeunits"
 	sizeunitsPg "github.com/rizkysr90/rizkiplastik-be/internal/handler/sizeunits/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/stock"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/summary"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/suppliers"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/tierprices"
+	"github.com/rizkysr90/rizkiplastik-be/internal/handler/users"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/variantypes"
 	variantypesPg "github.com/rizkysr90/rizkiplastik-be/internal/handler/variantypes/repository/pg"
 
 	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/sku"
 )
 
 // Server wraps gin.Engine
@@ -41,6 +62,13 @@ func NewServer(db *pgxpool.Pool, cfg *config.Config) *Server {
 	// Create a new Gin router
 	router := gin.New()
 
+	// Only the configured proxies may set X-Forwarded-For, otherwise any
+	// client could pick the IP used for login lockouts and audit events
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Printf("server: invalid trusted proxies, trusting none: %v", err)
+		_ = router.SetTrustedProxies(nil)
+	}
+
 	// Use the recovery middleware to recover from panics
 	router.Use(gin.Recovery())
 
@@ -54,9 +82,6 @@ func NewServer(db *pgxpool.Pool, cfg *config.Config) *Server {
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 	router.Use(cors.New(config))
 
-	// Session middleware
-	router.Use(middleware.Session())
-
 	server := &Server{
 		router: router,
 		db:     db,
@@ -68,9 +93,34 @@ func NewServer(db *pgxpool.Pool, cfg *config.Config) *Server {
 	return server
 }
 
-// Run starts the HTTP server
-func (s *Server) Run(addr string) error {
-	return s.router.Run(addr)
+// shutdownTimeout bounds the wait for the requests in flight on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// Run starts the HTTP server and serves until ctx is done, then it stops
+// accepting connections and waits for the requests in flight
+func (s *Server) Run(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:    addr,
+		Handler: s.router,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
 // registerRoutes sets up all the routes for the server
@@ -81,13 +131,72 @@ func (s *Server) registerRoutes() {
 			"message": "Welcome to Gin API with CORS and logging",
 		})
 	})
-	// Authentication routes
-	authenticationHandler := authentication.NewAuthHandler(s.db, s.cfg)
-	authenticationHandler.RegisterRoutes(s.router)
-
 	// Initialize auth middleware
 	authMiddleware := middleware.NewAuthMiddleware(s.db, s.cfg)
 
+	// Authentication routes
+	userRepo := pg.NewUser(s.db)
+	sessionRepo := pg.NewSession(s.db)
+	loginAttemptRepo := pg.NewLoginAttempt(s.db)
+	authEventRepo := pg.NewAuthEvent(s.db)
+	authenticationHandler := authentication.NewAuthHandler(
+		s.db,
+		s.cfg,
+		userRepo,
+		sessionRepo,
+		loginAttemptRepo,
+		authEventRepo,
+	)
+	authenticationHandler.RegisterRoutes(s.router, authMiddleware)
+
+	// Catalog routes require a logged in user, every group below then checks
+	// the role permission matrix by request method
+	catalog := s.router.Group("", authMiddleware.RequireAuth())
+	masterDataGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		middleware.ReadWritePermissions(
+			middleware.PermissionCatalogRead,
+			middleware.PermissionMasterDataWrite,
+		)))
+	rulesGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		middleware.ReadWritePermissions(
+			middleware.PermissionCatalogRead,
+			middleware.PermissionRulesWrite,
+		)))
+	productPermissions := middleware.ReadWritePermissions(
+		middleware.PermissionCatalogRead,
+		middleware.PermissionProductWrite,
+	)
+	productPermissions[http.MethodDelete] = middleware.PermissionProductDelete
+	productGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		productPermissions))
+	recipeGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		middleware.ReadWritePermissions(
+			middleware.PermissionCatalogRead,
+			middleware.PermissionRecipeWrite,
+		)))
+	pricingGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		middleware.ReadWritePermissions(
+			middleware.PermissionCatalogRead,
+			middleware.PermissionPricingWrite,
+		)))
+	stockGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		middleware.ReadWritePermissions(
+			middleware.PermissionStockRead,
+			middleware.PermissionStockWrite,
+		)))
+	salesPermissions := middleware.ReadWritePermissions(
+		middleware.PermissionSalesRead,
+		middleware.PermissionSalesWrite,
+	)
+	salesPermissions[http.MethodDelete] = middleware.PermissionSalesDelete
+	salesGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		salesPermissions))
+	userGroup := catalog.Group("", authMiddleware.RequirePermissions(
+		middleware.ReadWritePermissions(
+			middleware.PermissionUserManage,
+			middleware.PermissionUserManage,
+		)))
+
 	// Summary routes
 	summaryHandler := summary.NewSummaryHandler(s.db)
 	summaryHandler.RegisterRoutes(s.router, authMiddleware)
@@ -96,36 +205,51 @@ func (s *Server) registerRoutes() {
 	categoryRepo := pg.NewCategory(s.db)
 	categoryService := category.NewService(categoryRepo)
 	categoryHandler := category.NewCategoryHandler(categoryService)
-	categoryHandler.RegisterRoutes(s.router, authMiddleware)
+	categoryHandler.RegisterRoutes(masterDataGroup)
 
 	// Packaging type routes
 	packagingTypeRepo := packagingtypesPg.NewPackagingType(s.db)
 	packagingTypeHandler := packagingtypes.NewHandler(packagingTypeRepo)
-	packagingTypeHandler.RegisterRoutes(s.router)
+	packagingTypeHandler.RegisterRoutes(masterDataGroup)
 
 	// Size unit routes
 	sizeUnitRepo := sizeunitsPg.NewSizeUnits(s.db)
 	sizeUnitHandler := sizeunits.NewHandler(sizeUnitRepo)
-	sizeUnitHandler.RegisterRoutes(s.router)
+	sizeUnitHandler.RegisterRoutes(masterDataGroup)
 
 	// Variant type routes
 	variantTypeRepo := variantypesPg.NewVarianTypes(s.db)
 	variantTypeHandler := variantypes.NewHandler(variantTypeRepo)
-	variantTypeHandler.RegisterRoutes(s.router)
+	variantTypeHandler.RegisterRoutes(masterDataGroup)
 
 	// Product category rules routes
 	productCategoryRulesRepo := productCategoryRulesPg.NewProductCategoryRules(s.db)
 	productCategoryRulesHandler := productcategoryrules.NewHandler(productCategoryRulesRepo)
-	productCategoryRulesHandler.RegisterRoutes(s.router)
+	productCategoryRulesHandler.RegisterRoutes(rulesGroup)
 
 	// Product size unit rules routes
 	productCategoryRepoV2 := pg.NewCategory(s.db)
 	sizeUnitRepoV2 := pg.NewSizeUnit(s.db)
 	productSizeUnitRulesRepo := pg.NewProductSizeUnitRules(s.db, productCategoryRepoV2, sizeUnitRepoV2)
 	productSizeUnitRulesHandler := productsizeunitrules.NewHandler(productSizeUnitRulesRepo)
-	productSizeUnitRulesHandler.RegisterRoutes(s.router)
+	productSizeUnitRulesHandler.RegisterRoutes(rulesGroup)
+
+	// Marketplace fee schedule routes
+	feeScheduleRepo := pg.NewChannelFeeSchedule(s.db)
+	pricingEngine := pricing.NewEngine(feeScheduleRepo)
+	feeScheduleService := feeschedules.NewService(s.db, feeScheduleRepo, pricingEngine)
+	feeScheduleHandler := feeschedules.NewHandler(feeScheduleService)
+	feeScheduleHandler.RegisterRoutes(rulesGroup)
+
+	// Customer routes
+	customerRepo := pg.NewCustomer(s.db)
+	customerService := customers.NewService(customerRepo)
+	customerHandler := customers.NewHandler(customerService)
+	customerHandler.RegisterRoutes(salesGroup)
 
 	// Product routes
+	variantTierPriceRepo := pg.NewVariantTierPrice(s.db)
+	tierPriceResolver := pricing.NewTierPriceResolver(customerRepo, variantTierPriceRepo)
 	productRepo := pg.NewProduct(s.db)
 	productVariantRepo := pg.NewProductVariant(s.db)
 	repackRecipeRepo := pg.NewRepackRecipe(s.db)
@@ -137,7 +261,134 @@ func (s *Server) registerRoutes() {
 		categoryPackagingRulesRepo,
 		productVariantRepo,
 		repackRecipeRepo,
+		tierPriceResolver,
+		sku.UpdatePolicy(s.cfg.SKUUpdatePolicy),
 	)
 	productHandler := products.NewHandler(productService)
-	productHandler.RegisterRoutes(s.router)
+	productHandler.RegisterRoutes(productGroup)
+	productHandler.RegisterRecipeRoutes(recipeGroup)
+
+	// Variant channel listing routes
+	variantChannelListingRepo := pg.NewVariantChannelListing(s.db)
+	channelListingService := channellistings.NewService(
+		s.db,
+		variantChannelListingRepo,
+		productVariantRepo,
+	)
+	channelListingHandler := channellistings.NewHandler(channelListingService)
+	channelListingHandler.RegisterRoutes(pricingGroup)
+
+	// Variant channel price routes
+	channelPriceService := channelprices.NewService(
+		s.db,
+		productVariantRepo,
+		variantChannelListingRepo,
+		pricingEngine,
+	)
+	channelPriceHandler := channelprices.NewHandler(channelPriceService)
+	channelPriceHandler.RegisterRoutes(productGroup)
+
+	// Variant tier price routes
+	tierPriceService := tierprices.NewService(
+		s.db,
+		productVariantRepo,
+		variantTierPriceRepo,
+		tierPriceResolver,
+	)
+	tierPriceHandler := tierprices.NewHandler(tierPriceService)
+	tierPriceHandler.RegisterRoutes(pricingGroup)
+
+	// Variant price history and price schedule routes
+	variantPriceHistoryRepo := pg.NewVariantPriceHistory(s.db)
+	variantPriceScheduleRepo := pg.NewVariantPriceSchedule(s.db)
+	priceChangeService := pricechanges.NewService(
+		s.db,
+		productVariantRepo,
+		repackRecipeRepo,
+		variantPriceHistoryRepo,
+		variantPriceScheduleRepo,
+	)
+	priceChangeHandler := pricechanges.NewHandler(priceChangeService)
+	priceChangeHandler.RegisterRoutes(pricingGroup)
+
+	// Stock ledger routes
+	stockLedgerRepo := pg.NewStockLedger(s.db)
+	stockService := stock.NewService(
+		s.db,
+		stockLedgerRepo,
+		productVariantRepo,
+	)
+	stockHandler := stock.NewHandler(stockService)
+	stockHandler.RegisterRoutes(stockGroup)
+
+	// Repack job routes
+	repackJobRepo := pg.NewRepackJob(s.db)
+	repackJobService := repackjobs.NewService(
+		s.db,
+		repackJobRepo,
+		repackRecipeRepo,
+		stockLedgerRepo,
+	)
+	repackJobHandler := repackjobs.NewHandler(repackJobService)
+	repackJobHandler.RegisterRoutes(stockGroup)
+
+	// Supplier routes
+	supplierRepo := pg.NewSupplier(s.db)
+	supplierService := suppliers.NewService(supplierRepo)
+	supplierHandler := suppliers.NewHandler(supplierService)
+	supplierHandler.RegisterRoutes(masterDataGroup)
+
+	// Purchase order routes
+	purchaseOrderService := purchaseorders.NewService(
+		s.db,
+		pg.NewPurchaseOrder(s.db),
+		supplierRepo,
+		productVariantRepo,
+		stockLedgerRepo,
+		repackRecipeRepo,
+	)
+	purchaseOrderHandler := purchaseorders.NewHandler(purchaseOrderService)
+	purchaseOrderHandler.RegisterRoutes(stockGroup)
+
+	// Online transaction routes
+	onlineTransactionRepo := pg.NewOnlineTransaction(s.db)
+	onlineTransactionService := onlinetransactions.NewService(
+		s.db,
+		onlineTransactionRepo,
+		productVariantRepo,
+		pg.NewMarketplaceProduct(s.db),
+		pg.NewProductErrorLog(s.db),
+	)
+	onlineTransactionHandler := onlinetransactions.NewHandler(onlineTransactionService)
+	onlineTransactionHandler.RegisterRoutes(salesGroup)
+
+	// In-store sale routes
+	saleRepo := pg.NewSale(s.db)
+	shopSettingRepo := pg.NewShopSetting(s.db)
+	saleService := sales.NewService(
+		s.db,
+		saleRepo,
+		productVariantRepo,
+		stockLedgerRepo,
+		shopSettingRepo,
+		tierPriceResolver,
+	)
+	saleHandler := sales.NewHandler(saleService)
+	saleHandler.RegisterRoutes(salesGroup)
+
+	// Shop setting routes
+	shopSettingService := shopsettings.NewService(shopSettingRepo)
+	shopSettingHandler := shopsettings.NewHandler(shopSettingService)
+	shopSettingHandler.RegisterRoutes(masterDataGroup)
+
+	// User management routes
+	userService := users.NewService(userRepo, authEventRepo)
+	userHandler := users.NewHandler(userService)
+	userHandler.RegisterRoutes(userGroup)
+	userHandler.RegisterAccountRoutes(catalog)
+
+	// Auth audit trail routes
+	authEventService := authevents.NewService(authEventRepo)
+	authEventHandler := authevents.NewHandler(authEventService)
+	authEventHandler.RegisterRoutes(userGroup)
 }
@@ -0,0 +1,15 @@
+package shopsettings
+
+const (
+	fieldValidationFieldShopName      = "shop_name"
+	fieldValidationFieldAddress       = "address"
+	fieldValidationFieldPhone         = "phone"
+	fieldValidationFieldReceiptHeader = "receipt_header"
+	fieldValidationFieldReceiptFooter = "receipt_footer"
+	fieldValidationFieldInvoiceFooter = "invoice_footer"
+
+	maxLengthShopName = 100
+	maxLengthAddress  = 255
+	maxLengthPhone    = 30
+	maxLengthFreeText = 500
+)
@@ -0,0 +1,46 @@
+package shopsettings
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service ShopSettingService
+}
+
+func NewHandler(service ShopSettingService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/shop-settings")
+	endpoint.GET("/", h.GetShopSetting)
+	endpoint.PUT("/", h.UpdateShopSetting)
+}
+
+func (h *Handler) GetShopSetting(c *gin.Context) {
+	response, err := h.service.GetShopSetting(c)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateShopSetting(c *gin.Context) {
+	request := &UpdateShopSettingRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.UpdateShopSetting(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
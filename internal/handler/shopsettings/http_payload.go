@@ -0,0 +1,15 @@
+package shopsettings
+
+type GetShopSettingResponse struct {
+	Data ShopSettingObject `json:"data"`
+}
+
+type UpdateShopSettingRequest struct {
+	ShopName string `json:"shop_name"`
+	Address  string `json:"address"`
+	Phone    string `json:"phone"`
+	// ReceiptHeader, ReceiptFooter and InvoiceFooter may span several lines
+	ReceiptHeader string `json:"receipt_header"`
+	ReceiptFooter string `json:"receipt_footer"`
+	InvoiceFooter string `json:"invoice_footer"`
+}
@@ -0,0 +1,14 @@
+package shopsettings
+
+import "time"
+
+type ShopSettingObject struct {
+	ShopName      string    `json:"shop_name"`
+	Address       string    `json:"address"`
+	Phone         string    `json:"phone"`
+	ReceiptHeader string    `json:"receipt_header"`
+	ReceiptFooter string    `json:"receipt_footer"`
+	InvoiceFooter string    `json:"invoice_footer"`
+	UpdatedBy     string    `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
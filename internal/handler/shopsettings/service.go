@@ -0,0 +1,22 @@
+package shopsettings
+
+import (
+	"context"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type ShopSettingService interface {
+	GetShopSetting(ctx context.Context) (*GetShopSettingResponse, error)
+	UpdateShopSetting(ctx context.Context, request *UpdateShopSettingRequest) error
+}
+
+type Service struct {
+	shopSettingRepository repository.ShopSetting
+}
+
+func NewService(shopSettingRepository repository.ShopSetting) ShopSettingService {
+	return &Service{
+		shopSettingRepository: shopSettingRepository,
+	}
+}
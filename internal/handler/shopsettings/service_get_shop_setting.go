@@ -0,0 +1,35 @@
+package shopsettings
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+func (s *Service) GetShopSetting(ctx context.Context) (*GetShopSettingResponse, error) {
+	setting, err := s.shopSettingRepository.Find(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"shop settings not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetShopSettingResponse{
+		Data: ShopSettingObject{
+			ShopName:      setting.ShopName,
+			Address:       setting.Address,
+			Phone:         setting.Phone,
+			ReceiptHeader: setting.ReceiptHeader,
+			ReceiptFooter: setting.ReceiptFooter,
+			InvoiceFooter: setting.InvoiceFooter,
+			UpdatedBy:     setting.UpdatedBy,
+			UpdatedAt:     setting.UpdatedAt,
+		},
+	}, nil
+}
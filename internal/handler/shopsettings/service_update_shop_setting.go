@@ -0,0 +1,98 @@
+package shopsettings
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateShopSetting struct {
+	*UpdateShopSettingRequest
+}
+
+func (req *requestUpdateShopSetting) sanitize() {
+	req.ShopName = strings.TrimSpace(req.ShopName)
+	req.Address = strings.TrimSpace(req.Address)
+	req.Phone = strings.TrimSpace(req.Phone)
+	req.ReceiptHeader = sanitizeFreeText(req.ReceiptHeader)
+	req.ReceiptFooter = sanitizeFreeText(req.ReceiptFooter)
+	req.InvoiceFooter = sanitizeFreeText(req.InvoiceFooter)
+}
+
+// sanitizeFreeText trims every line and drops the surrounding empty lines
+func sanitizeFreeText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func (req *requestUpdateShopSetting) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateStringRequired(
+		req.ShopName, fieldValidationFieldShopName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldShopName,
+			Message: err.Error(),
+		})
+	}
+	maxLengths := []struct {
+		field     string
+		value     string
+		maxLength int
+	}{
+		{fieldValidationFieldShopName, req.ShopName, maxLengthShopName},
+		{fieldValidationFieldAddress, req.Address, maxLengthAddress},
+		{fieldValidationFieldPhone, req.Phone, maxLengthPhone},
+		{fieldValidationFieldReceiptHeader, req.ReceiptHeader, maxLengthFreeText},
+		{fieldValidationFieldReceiptFooter, req.ReceiptFooter, maxLengthFreeText},
+		{fieldValidationFieldInvoiceFooter, req.InvoiceFooter, maxLengthFreeText},
+	}
+	for _, check := range maxLengths {
+		if err := common.ValidateMaxLengthStr(check.value, check.maxLength); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   check.field,
+				Message: err.Error(),
+			})
+		}
+	}
+	return fieldValidation
+}
+
+func (s *Service) UpdateShopSetting(
+	ctx context.Context,
+	request *UpdateShopSettingRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateShopSetting{
+		UpdateShopSettingRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if err := s.shopSettingRepository.Update(ctx, &repository.ShopSettingData{
+		ShopName:      input.ShopName,
+		Address:       input.Address,
+		Phone:         input.Phone,
+		ReceiptHeader: input.ReceiptHeader,
+		ReceiptFooter: input.ReceiptFooter,
+		InvoiceFooter: input.InvoiceFooter,
+		UpdatedBy:     userID,
+	}); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
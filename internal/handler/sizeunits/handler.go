@@ -20,7 +20,7 @@ func NewHandler(repository repository.SizeUnits) *Handler {
 		service: service,
 	}
 }
-func (h *Handler) RegisterRoutes(router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	endpoint := router.Group("/api/v1/size-units")
 
 	endpoint.POST("/", h.PostSizeUnit)
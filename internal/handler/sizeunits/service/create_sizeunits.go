@@ -10,6 +10,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/sizeunits/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/sizeunits/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -40,6 +41,11 @@ func (r *reqCreateSizeUnit) validate(ctx context.Context) error {
 }
 func (s *SizeUnits) CreateSizeUnit(ctx context.Context,
 	request model.RequestCreateSizeUnit) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := reqCreateSizeUnit{
 		RequestCreateSizeUnit: &request,
 	}
@@ -52,8 +58,8 @@ func (s *SizeUnits) CreateSizeUnit(ctx context.Context,
 		SizeUnitName: input.SizeUnitName,
 		SizeUnitCode: input.SizeUnitCode,
 		SizeUnitType: input.SizeUnitType,
-		CreatedBy:    "SYSTEM",
-		UpdatedBy:    "SYSTEM",
+		CreatedBy:    userID,
+		UpdatedBy:    userID,
 	}
 	if input.SizeUnitDescription != nil {
 		insertedData.SizeUnitDescription = sql.NullString{
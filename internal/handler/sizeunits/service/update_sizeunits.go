@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/sizeunits/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/sizeunits/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -39,6 +40,11 @@ func (r *reqUpdateSizeUnit) validateFields(ctx context.Context) error {
 }
 func (s *SizeUnits) UpdateSizeUnit(ctx context.Context,
 	request model.RequestUpdateSizeUnit) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := reqUpdateSizeUnit{
 		RequestUpdateSizeUnit: &request,
 	}
@@ -51,7 +57,7 @@ func (s *SizeUnits) UpdateSizeUnit(ctx context.Context,
 		SizeUnitName: input.SizeUnitName,
 		SizeUnitType: input.SizeUnitType,
 		IsActive:     input.IsActive,
-		UpdatedBy:    "SYSTEM",
+		UpdatedBy:    userID,
 	}
 	if input.SizeUnitDescription != nil {
 		updatedData.SizeUnitDescription = sql.NullString{
@@ -0,0 +1,10 @@
+package stock
+
+const (
+	fieldValidationFieldVariantID    = "variant_id"
+	fieldValidationFieldMovementType = "movement_type"
+	fieldValidationFieldQuantity     = "quantity"
+	fieldValidationFieldNote         = "note"
+
+	maxLengthNote = 255
+)
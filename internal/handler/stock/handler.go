@@ -0,0 +1,72 @@
+package stock
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service StockService
+}
+
+func NewHandler(service StockService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/variants")
+	endpoint.GET("/:variant_id/stock", h.GetStock)
+	endpoint.GET("/:variant_id/stock/movements", h.GetMovements)
+	endpoint.POST("/:variant_id/stock/movements", h.CreateMovement)
+}
+
+func (h *Handler) GetStock(c *gin.Context) {
+	request := &GetStockRequest{
+		VariantID: c.Param("variant_id"),
+	}
+	response, err := h.service.GetStock(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetMovements(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetMovementsRequest{
+		PaginationData: *pagination,
+		VariantID:      c.Param("variant_id"),
+		MovementType:   c.Query("movement_type"),
+	}
+	response, err := h.service.GetMovements(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) CreateMovement(c *gin.Context) {
+	request := &CreateMovementRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.VariantID = c.Param("variant_id")
+	if err := h.service.CreateMovement(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{})
+}
@@ -0,0 +1,32 @@
+package stock
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type GetStockRequest struct {
+	VariantID string `json:"variant_id"`
+}
+
+type GetStockResponse struct {
+	Data StockObject `json:"data"`
+}
+
+type GetMovementsRequest struct {
+	util.PaginationData `json:"pagination"`
+	VariantID           string `json:"variant_id"`
+	MovementType        string `json:"movement_type"`
+}
+
+type GetMovementsResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []MovementObject `json:"data"`
+}
+
+type CreateMovementRequest struct {
+	VariantID    string          `json:"variant_id"`
+	MovementType string          `json:"movement_type"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	Note         *string         `json:"note"`
+}
@@ -0,0 +1,29 @@
+package stock
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type StockObject struct {
+	VariantID string          `json:"variant_id"`
+	SKU       string          `json:"sku"`
+	FullName  string          `json:"full_name"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	UpdatedBy *string         `json:"updated_by"`
+	UpdatedAt *time.Time      `json:"updated_at"`
+}
+
+type MovementObject struct {
+	MovementID    string          `json:"movement_id"`
+	VariantID     string          `json:"variant_id"`
+	MovementType  string          `json:"movement_type"`
+	Quantity      decimal.Decimal `json:"quantity"`
+	BalanceAfter  decimal.Decimal `json:"balance_after"`
+	ReferenceType *string         `json:"reference_type"`
+	ReferenceID   *string         `json:"reference_id"`
+	Note          *string         `json:"note"`
+	CreatedBy     string          `json:"created_by"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package stock
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type StockService interface {
+	GetStock(ctx context.Context, request *GetStockRequest) (*GetStockResponse, error)
+	GetMovements(ctx context.Context, request *GetMovementsRequest) (*GetMovementsResponse, error)
+	CreateMovement(ctx context.Context, request *CreateMovementRequest) error
+}
+
+type Service struct {
+	db                       *pgxpool.Pool
+	stockLedgerRepository    repository.StockLedger
+	productVariantRepository repository.ProductVariant
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	stockLedgerRepository repository.StockLedger,
+	productVariantRepository repository.ProductVariant,
+) StockService {
+	return &Service{
+		db:                       db,
+		stockLedgerRepository:    stockLedgerRepository,
+		productVariantRepository: productVariantRepository,
+	}
+}
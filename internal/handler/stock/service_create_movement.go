@@ -0,0 +1,133 @@
+package stock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateMovement struct {
+	*CreateMovementRequest
+}
+
+func (req *requestCreateMovement) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.MovementType = strings.TrimSpace(strings.ToUpper(req.MovementType))
+	if req.Note != nil {
+		*req.Note = strings.TrimSpace(*req.Note)
+	}
+}
+
+// validateField only accepts movements that are entered by hand. Sales and
+// repack movements are posted by their own workflows.
+func (req *requestCreateMovement) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if req.MovementType != string(repository.StockMovementTypePurchaseReceipt) &&
+		req.MovementType != string(repository.StockMovementTypeAdjustment) {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldMovementType,
+			Message: "movement_type must be PURCHASE_RECEIPT or ADJUSTMENT",
+		})
+	}
+	if err := common.ValidateDecimalRequired(
+		req.Quantity, fieldValidationFieldQuantity); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldQuantity,
+			Message: err.Error(),
+		})
+	}
+	if req.MovementType == string(repository.StockMovementTypePurchaseReceipt) &&
+		req.Quantity.IsNegative() {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldQuantity,
+			Message: "quantity must be greater than 0 for purchase receipt",
+		})
+	}
+	if req.Note != nil {
+		if err := common.ValidateMaxLengthStr(*req.Note, maxLengthNote); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldNote,
+				Message: err.Error(),
+			})
+		}
+	}
+	return fieldValidation
+}
+
+func (s *Service) CreateMovement(
+	ctx context.Context,
+	request *CreateMovementRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestCreateMovement{
+		CreateMovementRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	variants, err := s.productVariantRepository.FindManyByID(
+		ctx, tx, []string{input.VariantID})
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(variants) != 1 {
+		return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"variant not found",
+		))
+	}
+	movement := &repository.StockMovementData{
+		ID:           uuid.NewString(),
+		VariantID:    input.VariantID,
+		MovementType: repository.StockMovementType(input.MovementType),
+		Quantity:     input.Quantity,
+		CreatedBy:    userID,
+	}
+	if input.Note != nil && *input.Note != "" {
+		movement.Note = sql.NullString{String: *input.Note, Valid: true}
+	}
+	if err := s.stockLedgerRepository.InsertMovementTransaction(
+		ctx, tx, movement); err != nil {
+		return handleStockLedgerError(ctx, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func handleStockLedgerError(ctx context.Context, err error) error {
+	if errors.Is(err, pg.ErrInsufficientStock) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
@@ -0,0 +1,103 @@
+package stock
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetMovements struct {
+	*GetMovementsRequest
+}
+
+func (req *requestGetMovements) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.MovementType = strings.TrimSpace(strings.ToUpper(req.MovementType))
+}
+
+func (req *requestGetMovements) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if req.MovementType != "" {
+		if err := common.ValidateOneOf(req.MovementType, []string{
+			string(repository.StockMovementTypePurchaseReceipt),
+			string(repository.StockMovementTypeSale),
+			string(repository.StockMovementTypeAdjustment),
+			string(repository.StockMovementTypeRepackConsume),
+			string(repository.StockMovementTypeRepackProduce),
+		}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldMovementType,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetMovements(
+	ctx context.Context,
+	request *GetMovementsRequest,
+) (*GetMovementsResponse, error) {
+	input := &requestGetMovements{
+		GetMovementsRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	movements, totalCount, err := s.stockLedgerRepository.FindPaginatedMovements(ctx,
+		&repository.StockMovementFilter{
+			VariantID:    input.VariantID,
+			MovementType: input.MovementType,
+			Limit:        input.PageSize,
+			Offset:       input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]MovementObject, 0, len(movements))
+	for _, movement := range movements {
+		object := MovementObject{
+			MovementID:   movement.ID,
+			VariantID:    movement.VariantID,
+			MovementType: string(movement.MovementType),
+			Quantity:     movement.Quantity,
+			BalanceAfter: movement.BalanceAfter,
+			CreatedBy:    movement.CreatedBy,
+			CreatedAt:    movement.CreatedAt,
+		}
+		if movement.ReferenceType.Valid {
+			object.ReferenceType = &movement.ReferenceType.String
+		}
+		if movement.ReferenceID.Valid {
+			object.ReferenceID = &movement.ReferenceID.String
+		}
+		if movement.Note.Valid {
+			object.Note = &movement.Note.String
+		}
+		data = append(data, object)
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetMovementsResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+func (s *Service) GetStock(
+	ctx context.Context,
+	request *GetStockRequest,
+) (*GetStockResponse, error) {
+	request.VariantID = strings.TrimSpace(request.VariantID)
+	if err := common.ValidateUUIDFormat(request.VariantID); err != nil {
+		return nil, httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{
+			httperror.NewFieldValidation(fieldValidationFieldVariantID, err.Error()),
+		})
+	}
+	balance, err := s.stockLedgerRepository.FindBalanceByVariantID(ctx, request.VariantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"variant not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	response := &GetStockResponse{
+		Data: StockObject{
+			VariantID: balance.VariantID,
+			SKU:       balance.SKU,
+			FullName:  balance.FullName,
+			Quantity:  balance.Quantity,
+		},
+	}
+	if balance.UpdatedBy.Valid {
+		response.Data.UpdatedBy = &balance.UpdatedBy.String
+	}
+	if balance.UpdatedAt.Valid {
+		response.Data.UpdatedAt = &balance.UpdatedAt.Time
+	}
+	return response, nil
+}
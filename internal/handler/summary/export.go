@@ -0,0 +1,86 @@
+package summary
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/export"
+)
+
+var summaryMetricsHeader = []string{
+	"revenue",
+	"fees",
+	"cost",
+	"net_profit",
+	"margin_percentage",
+	"order_count",
+	"average_order_value",
+}
+
+func (metrics *SummaryMetrics) values() []any {
+	return []any{
+		metrics.Revenue,
+		metrics.Fees,
+		metrics.Cost,
+		metrics.NetProfit,
+		metrics.MarginPercentage,
+		metrics.OrderCount,
+		metrics.AverageOrderValue,
+	}
+}
+
+// exportSummary renders one section of the summary as a table, only the
+// query of that section is run
+func (h *SummaryHandler) exportSummary(
+	c *gin.Context,
+	format export.Format,
+	section string,
+	request *RequestSummary,
+) {
+	switch section {
+	case sectionChannels:
+		header := append([]string{"channel"}, summaryMetricsHeader...)
+		export.Respond(c, format, "summary-channels", header,
+			func(write export.RowFunc) error {
+				channels, err := findChannels(c, h.db, request.StartDate, request.EndDate)
+				if err != nil {
+					return err
+				}
+				for _, channel := range channels {
+					row := append([]any{channel.Channel}, channel.values()...)
+					if err := write(row); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	case sectionProducts:
+		header := append(
+			[]string{"variant_id", "product_name", "quantity"},
+			summaryMetricsHeader...)
+		export.Respond(c, format, "summary-products", header,
+			func(write export.RowFunc) error {
+				return eachProduct(c, h.db, request.StartDate, request.EndDate,
+					func(product *ProductSummary) error {
+						return write(append(
+							[]any{product.VariantID, product.ProductName, product.Quantity},
+							product.values()...))
+					})
+			})
+	default:
+		header := append([]string{request.Granularity}, summaryMetricsHeader...)
+		export.Respond(c, format, "summary-series", header,
+			func(write export.RowFunc) error {
+				series, err := findPeriods(c, h.db,
+					request.StartDate, request.EndDate, request.Granularity)
+				if err != nil {
+					return err
+				}
+				for _, period := range series {
+					row := append([]any{period.Period}, period.values()...)
+					if err := write(row); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	}
+}
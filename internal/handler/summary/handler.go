@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/export"
 	"github.com/rizkysr90/rizkiplastik-be/internal/middleware"
 )
 
@@ -32,12 +33,38 @@ func (h *SummaryHandler) RegisterRoutes(
 	}
 }
 
+const (
+	granularityDay   = "day"
+	granularityWeek  = "week"
+	granularityMonth = "month"
+)
+
+// Sections of the summary that can be exported as a table
+const (
+	sectionSeries   = "series"
+	sectionChannels = "channels"
+	sectionProducts = "products"
+)
+
 // Using the standard library time package
 func isValidISO8601Date(dateStr string) bool {
 	_, err := time.Parse("2006-01-02", dateStr)
 	return err == nil
 }
 func (h *SummaryHandler) GetSummary(c *gin.Context) {
+	format, err := export.FormatFromRequest(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	section := strings.ToLower(strings.TrimSpace(c.Query("section")))
+	if section == "" {
+		section = sectionSeries
+	}
+	if section != sectionSeries && section != sectionChannels && section != sectionProducts {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "section must be one of series, channels, products"})
+		return
+	}
 	start_date := c.Query("start_date")
 	end_date := c.Query("end_date")
 	if start_date == "" || end_date == "" {
@@ -45,18 +72,43 @@ func (h *SummaryHandler) GetSummary(c *gin.Context) {
 		return
 	}
 	request := RequestSummary{
-		StartDate: start_date,
-		EndDate:   end_date,
+		StartDate:   start_date,
+		EndDate:     end_date,
+		Granularity: c.Query("granularity"),
 	}
 	// Sanitize ISO 8601 FORMAT
 	request.EndDate = strings.TrimSpace(request.EndDate)
 	request.StartDate = strings.TrimSpace(request.StartDate)
+	request.Granularity = strings.ToLower(strings.TrimSpace(request.Granularity))
+	if request.Granularity == "" {
+		request.Granularity = granularityDay
+	}
 
 	// Validate ISO 8601 FORMAT
 	if !isValidISO8601Date(request.EndDate) || !isValidISO8601Date(request.StartDate) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ISO 8601 format"})
 		return
 	}
+	startDate, _ := time.Parse("2006-01-02", request.StartDate)
+	endDate, _ := time.Parse("2006-01-02", request.EndDate)
+	if endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be before start_date"})
+		return
+	}
+	if request.Granularity != granularityDay &&
+		request.Granularity != granularityWeek &&
+		request.Granularity != granularityMonth {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "granularity must be one of day, week, month"})
+		return
+	}
+	if format != export.FormatJSON {
+		h.exportSummary(c, format, section, &request)
+		return
+	}
+	// The previous period has the same length and ends the day before start_date
+	periodDays := int(endDate.Sub(startDate).Hours()/24) + 1
+	previousEndDate := startDate.AddDate(0, 0, -1)
+	previousStartDate := previousEndDate.AddDate(0, 0, -(periodDays - 1))
 
 	// Query for total net profit
 	var totalNetProfit float64
@@ -64,12 +116,11 @@ func (h *SummaryHandler) GetSummary(c *gin.Context) {
 		SELECT  
 			COALESCE(SUM(total_net_profit), 0) as total_net_profit
 		FROM 
-			online_transactions
+			summary_transactions
 		WHERE 
-			created_date BETWEEN $1::DATE AND $2::DATE 
-			AND deleted_at IS NULL
+			created_date BETWEEN $1::DATE AND $2::DATE
 	`
-	err := h.db.QueryRow(c, totalQuery, request.StartDate, request.EndDate).Scan(&totalNetProfit)
+	err = h.db.QueryRow(c, totalQuery, request.StartDate, request.EndDate).Scan(&totalNetProfit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -81,10 +132,9 @@ func (h *SummaryHandler) GetSummary(c *gin.Context) {
 			TO_CHAR(created_date, 'YYYY-MM-DD') as date,
 			COALESCE(SUM(total_net_profit), 0) as net_profit
 		FROM 
-			online_transactions
+			summary_transactions
 		WHERE 
-			created_date BETWEEN $1::DATE AND $2::DATE 
-			AND deleted_at IS NULL
+			created_date BETWEEN $1::DATE AND $2::DATE
 		GROUP BY DATE(created_date)
 		ORDER BY DATE(created_date)
 	`
@@ -105,9 +155,58 @@ func (h *SummaryHandler) GetSummary(c *gin.Context) {
 		dailyProfits = append(dailyProfits, daily)
 	}
 
+	if err := rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	totals, err := findTotals(c, h.db, request.StartDate, request.EndDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	series, err := findPeriods(c, h.db, request.StartDate, request.EndDate, request.Granularity)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	channels, err := findChannels(c, h.db, request.StartDate, request.EndDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	products, err := findProducts(c, h.db, request.StartDate, request.EndDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	previous := PreviousPeriodSummary{
+		StartDate: previousStartDate.Format("2006-01-02"),
+		EndDate:   previousEndDate.Format("2006-01-02"),
+	}
+	previous.Totals, err = findTotals(c, h.db, previous.StartDate, previous.EndDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	previous.Change = SummaryChange{
+		Revenue:   percentageChange(totals.Revenue, previous.Totals.Revenue),
+		NetProfit: percentageChange(totals.NetProfit, previous.Totals.NetProfit),
+		OrderCount: percentageChange(
+			float64(totals.OrderCount),
+			float64(previous.Totals.OrderCount),
+		),
+	}
+
 	response := ResponseSummary{
 		TotalNetProfit: totalNetProfit,
 		DailyProfits:   dailyProfits,
+		Granularity:    request.Granularity,
+		Totals:         totals,
+		Series:         series,
+		Channels:       channels,
+		Products:       products,
+		Previous:       previous,
 	}
 	c.JSON(http.StatusOK, response)
 }
@@ -3,6 +3,8 @@ package summary
 type RequestSummary struct {
 	StartDate string `json:"start_date" binding:"required"` // ISO 8601 format
 	EndDate   string `json:"end_date" binding:"required"`   // ISO 8601 format
+	// Granularity of the series, day, week or month. day when empty
+	Granularity string `json:"granularity"`
 }
 
 type DailyProfit struct {
@@ -10,7 +12,61 @@ type DailyProfit struct {
 	NetProfit float64 `json:"net_profit"`
 }
 
+// SummaryMetrics are the figures reported for every breakdown
+type SummaryMetrics struct {
+	Revenue   float64 `json:"revenue"`
+	Fees      float64 `json:"fees"`
+	Cost      float64 `json:"cost"`
+	NetProfit float64 `json:"net_profit"`
+	// MarginPercentage is the net profit in percent of the revenue
+	MarginPercentage  float64 `json:"margin_percentage"`
+	OrderCount        int     `json:"order_count"`
+	AverageOrderValue float64 `json:"average_order_value"`
+}
+
+type PeriodSummary struct {
+	// Period is the first day of the day, week or month
+	Period string `json:"period"`
+	SummaryMetrics
+}
+
+type ChannelSummary struct {
+	// Channel is the online channel, or OFFLINE for in-store sales
+	Channel string `json:"channel"`
+	SummaryMetrics
+}
+
+type ProductSummary struct {
+	VariantID   *string `json:"variant_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	SummaryMetrics
+}
+
+// PreviousPeriodSummary covers the period of the same length right before
+// the requested one
+type PreviousPeriodSummary struct {
+	StartDate string         `json:"start_date"`
+	EndDate   string         `json:"end_date"`
+	Totals    SummaryMetrics `json:"totals"`
+	Change    SummaryChange  `json:"change"`
+}
+
+// SummaryChange is the change against the previous period in percent, a
+// field is null when the previous period has nothing to compare with
+type SummaryChange struct {
+	Revenue    *float64 `json:"revenue"`
+	NetProfit  *float64 `json:"net_profit"`
+	OrderCount *float64 `json:"order_count"`
+}
+
 type ResponseSummary struct {
-	TotalNetProfit float64       `json:"total_net_profit"`
-	DailyProfits   []DailyProfit `json:"daily_profits"`
+	TotalNetProfit float64               `json:"total_net_profit"`
+	DailyProfits   []DailyProfit         `json:"daily_profits"`
+	Granularity    string                `json:"granularity"`
+	Totals         SummaryMetrics        `json:"totals"`
+	Series         []PeriodSummary       `json:"series"`
+	Channels       []ChannelSummary      `json:"channels"`
+	Products       []ProductSummary      `json:"products"`
+	Previous       PreviousPeriodSummary `json:"previous"`
 }
@@ -0,0 +1,223 @@
+package summary
+
+import (
+	"context"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxProducts limits the product breakdown of the JSON summary to the best
+// selling products, exports are not limited
+const maxProducts = 50
+
+const (
+	// summaryMetricsColumns must be scanned by scanMetrics
+	summaryMetricsColumns = `
+			COALESCE(SUM(total_sale_amount), 0),
+			COALESCE(SUM(total_fee_amount), 0),
+			COALESCE(SUM(total_base_amount), 0),
+			COALESCE(SUM(total_net_profit), 0),
+			COALESCE(SUM(order_count), 0)
+	`
+	totalSummaryQuery = `
+		SELECT ` + summaryMetricsColumns + `
+		FROM summary_transactions
+		WHERE
+			created_date BETWEEN $1::DATE AND $2::DATE
+	`
+	periodSummaryQuery = `
+		SELECT
+			TO_CHAR(DATE_TRUNC($3::text, created_date::timestamp), 'YYYY-MM-DD'),
+			` + summaryMetricsColumns + `
+		FROM summary_transactions
+		WHERE
+			created_date BETWEEN $1::DATE AND $2::DATE
+		GROUP BY DATE_TRUNC($3::text, created_date::timestamp)
+		ORDER BY DATE_TRUNC($3::text, created_date::timestamp)
+	`
+	channelSummaryQuery = `
+		SELECT
+			channel,
+			` + summaryMetricsColumns + `
+		FROM summary_transactions
+		WHERE
+			created_date BETWEEN $1::DATE AND $2::DATE
+		GROUP BY channel
+		ORDER BY channel
+	`
+	// productSummaryQuery groups lines by variant, lines without a variant
+	// are grouped by product name. A null limit returns every product.
+	productSummaryQuery = `
+		SELECT
+			variant_id::text,
+			MIN(product_name),
+			COALESCE(SUM(quantity), 0),
+			COALESCE(SUM(revenue), 0),
+			COALESCE(SUM(fees), 0),
+			COALESCE(SUM(cost), 0),
+			COALESCE(SUM(revenue - cost - fees), 0),
+			COUNT(DISTINCT transaction_id) FILTER (WHERE quantity > 0)
+		FROM summary_transaction_lines
+		WHERE created_date BETWEEN $1::DATE AND $2::DATE
+		GROUP BY variant_id, CASE
+			WHEN variant_id IS NULL THEN UPPER(product_name)
+		END
+		ORDER BY 4 DESC, 2
+		LIMIT $3
+	`
+)
+
+func metricsDest(metrics *SummaryMetrics) []any {
+	return []any{
+		&metrics.Revenue,
+		&metrics.Fees,
+		&metrics.Cost,
+		&metrics.NetProfit,
+		&metrics.OrderCount,
+	}
+}
+
+// completeMetrics derives the ratios from the summed figures
+func completeMetrics(metrics *SummaryMetrics) {
+	if metrics.Revenue != 0 {
+		metrics.MarginPercentage = roundTwo(metrics.NetProfit / metrics.Revenue * 100)
+	}
+	if metrics.OrderCount > 0 {
+		metrics.AverageOrderValue = roundTwo(metrics.Revenue / float64(metrics.OrderCount))
+	}
+}
+
+func findTotals(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	startDate, endDate string,
+) (SummaryMetrics, error) {
+	var metrics SummaryMetrics
+	if err := db.QueryRow(ctx, totalSummaryQuery, startDate, endDate).
+		Scan(metricsDest(&metrics)...); err != nil {
+		return metrics, err
+	}
+	completeMetrics(&metrics)
+	return metrics, nil
+}
+
+func findPeriods(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	startDate, endDate, granularity string,
+) ([]PeriodSummary, error) {
+	rows, err := db.Query(ctx, periodSummaryQuery, startDate, endDate, granularity)
+	if err != nil {
+		return nil, err
+	}
+	return collectSummaries(rows, func(row pgx.Rows) (PeriodSummary, error) {
+		var period PeriodSummary
+		err := row.Scan(append([]any{&period.Period}, metricsDest(&period.SummaryMetrics)...)...)
+		completeMetrics(&period.SummaryMetrics)
+		return period, err
+	})
+}
+
+func findChannels(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	startDate, endDate string,
+) ([]ChannelSummary, error) {
+	rows, err := db.Query(ctx, channelSummaryQuery, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return collectSummaries(rows, func(row pgx.Rows) (ChannelSummary, error) {
+		var channel ChannelSummary
+		err := row.Scan(append([]any{&channel.Channel}, metricsDest(&channel.SummaryMetrics)...)...)
+		completeMetrics(&channel.SummaryMetrics)
+		return channel, err
+	})
+}
+
+func findProducts(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	startDate, endDate string,
+) ([]ProductSummary, error) {
+	rows, err := db.Query(ctx, productSummaryQuery, startDate, endDate, maxProducts)
+	if err != nil {
+		return nil, err
+	}
+	return collectSummaries(rows, scanProduct)
+}
+
+// eachProduct calls fn with every product of the breakdown as it is read,
+// so an export does not hold the whole breakdown in memory
+func eachProduct(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	startDate, endDate string,
+	fn func(product *ProductSummary) error,
+) error {
+	rows, err := db.Query(ctx, productSummaryQuery, startDate, endDate, nil)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(&product); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func scanProduct(row pgx.Rows) (ProductSummary, error) {
+	var product ProductSummary
+	err := row.Scan(
+		&product.VariantID,
+		&product.ProductName,
+		&product.Quantity,
+		&product.Revenue,
+		&product.Fees,
+		&product.Cost,
+		&product.NetProfit,
+		&product.OrderCount,
+	)
+	completeMetrics(&product.SummaryMetrics)
+	return product, err
+}
+
+func collectSummaries[T any](
+	rows pgx.Rows,
+	scan func(row pgx.Rows) (T, error),
+) ([]T, error) {
+	defer rows.Close()
+	summaries := []T{}
+	for rows.Next() {
+		summary, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// percentageChange is nil when there is no previous value to compare with
+func percentageChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := roundTwo((current - previous) / math.Abs(previous) * 100)
+	return &change
+}
+
+func roundTwo(value float64) float64 {
+	return math.Round(value*100) / 100
+}
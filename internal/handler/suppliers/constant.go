@@ -0,0 +1,22 @@
+package suppliers
+
+const (
+	fieldValidationFieldSupplierID      = "supplier_id"
+	fieldValidationFieldName            = "name"
+	fieldValidationFieldContactName     = "contact_name"
+	fieldValidationFieldPhone           = "phone"
+	fieldValidationFieldEmail           = "email"
+	fieldValidationFieldAddress         = "address"
+	fieldValidationFieldPaymentTermDays = "payment_term_days"
+	fieldValidationFieldNote            = "note"
+	fieldValidationFieldIsActive        = "is_active"
+	fieldValidationFieldStatus          = "status"
+
+	maxLengthName        = 100
+	maxLengthContactName = 100
+	maxLengthPhone       = 30
+	maxLengthEmail       = 100
+	maxLengthAddress     = 255
+	maxLengthNote        = 255
+	maxPaymentTermDays   = 365
+)
@@ -0,0 +1,99 @@
+package suppliers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service SupplierService
+}
+
+func NewHandler(service SupplierService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/suppliers")
+	endpoint.POST("/", h.CreateSupplier)
+	endpoint.GET("/", h.GetSuppliers)
+	endpoint.GET("/:supplier_id", h.GetSupplier)
+	endpoint.PUT("/:supplier_id", h.UpdateSupplier)
+	endpoint.DELETE("/:supplier_id", h.DeleteSupplier)
+}
+
+func (h *Handler) CreateSupplier(c *gin.Context) {
+	request := &CreateSupplierRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateSupplier(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetSuppliers(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetSuppliersRequest{
+		PaginationData: *pagination,
+		Search:         c.Query("search"),
+		Status:         c.Query("status"),
+	}
+	response, err := h.service.GetSuppliers(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetSupplier(c *gin.Context) {
+	request := &GetSupplierRequest{
+		SupplierID: c.Param("supplier_id"),
+	}
+	response, err := h.service.GetSupplier(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateSupplier(c *gin.Context) {
+	request := &UpdateSupplierRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.SupplierID = c.Param("supplier_id")
+	if err := h.service.UpdateSupplier(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) DeleteSupplier(c *gin.Context) {
+	request := &DeleteSupplierRequest{
+		SupplierID: c.Param("supplier_id"),
+	}
+	if err := h.service.DeleteSupplier(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
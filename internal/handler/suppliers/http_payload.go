@@ -0,0 +1,54 @@
+package suppliers
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type SupplierPayload struct {
+	Name        string  `json:"name"`
+	ContactName *string `json:"contact_name"`
+	Phone       *string `json:"phone"`
+	Email       *string `json:"email"`
+	Address     *string `json:"address"`
+	// PaymentTermDays is the number of days until an order is due, 0 is cash
+	// on delivery
+	PaymentTermDays int     `json:"payment_term_days"`
+	Note            *string `json:"note"`
+}
+
+type CreateSupplierRequest struct {
+	SupplierPayload
+}
+
+type CreateSupplierResponse struct {
+	SupplierID string `json:"supplier_id"`
+}
+
+type UpdateSupplierRequest struct {
+	SupplierID string `json:"supplier_id"`
+	SupplierPayload
+	IsActive *bool `json:"is_active"`
+}
+
+type GetSupplierRequest struct {
+	SupplierID string `json:"supplier_id"`
+}
+
+type GetSupplierResponse struct {
+	Data SupplierObject `json:"data"`
+}
+
+type GetSuppliersRequest struct {
+	util.PaginationData `json:"pagination"`
+	Search              string `json:"search"`
+	Status              string `json:"status"`
+}
+
+type GetSuppliersResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []SupplierObject `json:"data"`
+}
+
+type DeleteSupplierRequest struct {
+	SupplierID string `json:"supplier_id"`
+}
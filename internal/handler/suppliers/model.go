@@ -0,0 +1,19 @@
+package suppliers
+
+import "time"
+
+type SupplierObject struct {
+	SupplierID      string    `json:"supplier_id"`
+	Name            string    `json:"name"`
+	ContactName     *string   `json:"contact_name"`
+	Phone           *string   `json:"phone"`
+	Email           *string   `json:"email"`
+	Address         *string   `json:"address"`
+	PaymentTermDays int       `json:"payment_term_days"`
+	Note            *string   `json:"note"`
+	IsActive        bool      `json:"is_active"`
+	CreatedBy       string    `json:"created_by"`
+	UpdatedBy       string    `json:"updated_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package suppliers
+
+import (
+	"context"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type SupplierService interface {
+	CreateSupplier(ctx context.Context, request *CreateSupplierRequest) (*CreateSupplierResponse, error)
+	GetSupplier(ctx context.Context, request *GetSupplierRequest) (*GetSupplierResponse, error)
+	GetSuppliers(ctx context.Context, request *GetSuppliersRequest) (*GetSuppliersResponse, error)
+	UpdateSupplier(ctx context.Context, request *UpdateSupplierRequest) error
+	DeleteSupplier(ctx context.Context, request *DeleteSupplierRequest) error
+}
+
+type Service struct {
+	supplierRepository repository.Supplier
+}
+
+func NewService(supplierRepository repository.Supplier) SupplierService {
+	return &Service{
+		supplierRepository: supplierRepository,
+	}
+}
@@ -0,0 +1,58 @@
+package suppliers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateSupplier struct {
+	*CreateSupplierRequest
+}
+
+func (req *requestCreateSupplier) sanitize() {
+	req.SupplierPayload.sanitize()
+}
+
+func (req *requestCreateSupplier) validateField() []httperror.FieldValidation {
+	return req.SupplierPayload.validateField()
+}
+
+func (s *Service) CreateSupplier(
+	ctx context.Context,
+	request *CreateSupplierRequest,
+) (*CreateSupplierResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateSupplier{
+		CreateSupplierRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	supplier := &repository.SupplierData{
+		ID:              uuid.NewString(),
+		Name:            input.Name,
+		ContactName:     toNullString(input.ContactName),
+		Phone:           toNullString(input.Phone),
+		Email:           toNullString(input.Email),
+		Address:         toNullString(input.Address),
+		PaymentTermDays: input.PaymentTermDays,
+		Note:            toNullString(input.Note),
+		IsActive:        true,
+		CreatedBy:       userID,
+		UpdatedBy:       userID,
+	}
+	if err := s.supplierRepository.Insert(ctx, supplier); err != nil {
+		return nil, handleSaveError(ctx, err)
+	}
+	return &CreateSupplierResponse{SupplierID: supplier.ID}, nil
+}
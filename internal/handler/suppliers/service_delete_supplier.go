@@ -0,0 +1,63 @@
+package suppliers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestDeleteSupplier struct {
+	*DeleteSupplierRequest
+}
+
+func (req *requestDeleteSupplier) sanitize() {
+	req.SupplierID = strings.TrimSpace(req.SupplierID)
+}
+
+func (req *requestDeleteSupplier) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SupplierID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSupplierID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// DeleteSupplier soft deletes the supplier, its purchase orders keep
+// pointing to it and its name becomes free to be used again
+func (s *Service) DeleteSupplier(
+	ctx context.Context,
+	request *DeleteSupplierRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestDeleteSupplier{
+		DeleteSupplierRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if err := s.supplierRepository.SoftDelete(ctx, input.SupplierID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"supplier not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return nil
+}
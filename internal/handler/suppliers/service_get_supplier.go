@@ -0,0 +1,58 @@
+package suppliers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetSupplier struct {
+	*GetSupplierRequest
+}
+
+func (req *requestGetSupplier) sanitize() {
+	req.SupplierID = strings.TrimSpace(req.SupplierID)
+}
+
+func (req *requestGetSupplier) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SupplierID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSupplierID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetSupplier(
+	ctx context.Context,
+	request *GetSupplierRequest,
+) (*GetSupplierResponse, error) {
+	input := &requestGetSupplier{
+		GetSupplierRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	supplier, err := s.supplierRepository.FindByID(ctx, input.SupplierID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"supplier not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetSupplierResponse{
+		Data: toSupplierObject(supplier),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package suppliers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetSuppliers struct {
+	*GetSuppliersRequest
+}
+
+func (req *requestGetSuppliers) sanitize() {
+	req.Search = strings.TrimSpace(req.Search)
+	req.Status = strings.TrimSpace(strings.ToUpper(req.Status))
+}
+
+func (req *requestGetSuppliers) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.Status != "" {
+		if err := common.ValidateOneOf(req.Status, []string{"TRUE", "FALSE"}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldStatus,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetSuppliers(
+	ctx context.Context,
+	request *GetSuppliersRequest,
+) (*GetSuppliersResponse, error) {
+	input := &requestGetSuppliers{
+		GetSuppliersRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	suppliers, totalCount, err := s.supplierRepository.FindPaginated(ctx,
+		&repository.SupplierFilter{
+			Search:   input.Search,
+			IsActive: input.Status,
+			Limit:    input.PageSize,
+			Offset:   input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]SupplierObject, 0, len(suppliers))
+	for i := range suppliers {
+		data = append(data, toSupplierObject(&suppliers[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetSuppliersResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package suppliers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateSupplier struct {
+	*UpdateSupplierRequest
+}
+
+func (req *requestUpdateSupplier) sanitize() {
+	req.SupplierID = strings.TrimSpace(req.SupplierID)
+	req.SupplierPayload.sanitize()
+}
+
+func (req *requestUpdateSupplier) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.SupplierID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldSupplierID,
+			Message: err.Error(),
+		})
+	}
+	if req.IsActive == nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldIsActive,
+			Message: "is_active is required",
+		})
+	}
+	return append(fieldValidation, req.SupplierPayload.validateField()...)
+}
+
+// UpdateSupplier replaces every field of the supplier. Payment terms of
+// existing purchase orders are kept.
+func (s *Service) UpdateSupplier(
+	ctx context.Context,
+	request *UpdateSupplierRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateSupplier{
+		UpdateSupplierRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if err := s.supplierRepository.Update(ctx, &repository.SupplierData{
+		ID:              input.SupplierID,
+		Name:            input.Name,
+		ContactName:     toNullString(input.ContactName),
+		Phone:           toNullString(input.Phone),
+		Email:           toNullString(input.Email),
+		Address:         toNullString(input.Address),
+		PaymentTermDays: input.PaymentTermDays,
+		Note:            toNullString(input.Note),
+		IsActive:        *input.IsActive,
+		UpdatedBy:       userID,
+	}); err != nil {
+		return handleSaveError(ctx, err)
+	}
+	return nil
+}
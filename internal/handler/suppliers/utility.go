@@ -0,0 +1,138 @@
+package suppliers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+// sanitize trims the payload, optional fields that are empty after trimming
+// are treated as not set
+func (payload *SupplierPayload) sanitize() {
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.ContactName = trimOptional(payload.ContactName)
+	payload.Phone = trimOptional(payload.Phone)
+	payload.Email = trimOptional(payload.Email)
+	if payload.Email != nil {
+		*payload.Email = strings.ToLower(*payload.Email)
+	}
+	payload.Address = trimOptional(payload.Address)
+	payload.Note = trimOptional(payload.Note)
+}
+
+func (payload *SupplierPayload) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateStringRequired(
+		payload.Name, fieldValidationFieldName); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldName,
+			Message: err.Error(),
+		})
+	}
+	lengthFields := []struct {
+		field     string
+		value     *string
+		maxLength int
+	}{
+		{fieldValidationFieldName, &payload.Name, maxLengthName},
+		{fieldValidationFieldContactName, payload.ContactName, maxLengthContactName},
+		{fieldValidationFieldPhone, payload.Phone, maxLengthPhone},
+		{fieldValidationFieldEmail, payload.Email, maxLengthEmail},
+		{fieldValidationFieldAddress, payload.Address, maxLengthAddress},
+		{fieldValidationFieldNote, payload.Note, maxLengthNote},
+	}
+	for _, limit := range lengthFields {
+		if limit.value == nil {
+			continue
+		}
+		if err := common.ValidateMaxLengthStr(*limit.value, limit.maxLength); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   limit.field,
+				Message: err.Error(),
+			})
+		}
+	}
+	if payload.Email != nil {
+		if _, err := mail.ParseAddress(*payload.Email); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldEmail,
+				Message: "invalid email address",
+			})
+		}
+	}
+	if payload.PaymentTermDays < 0 || payload.PaymentTermDays > maxPaymentTermDays {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field: fieldValidationFieldPaymentTermDays,
+			Message: fmt.Sprintf("payment_term_days must be between 0 and %d",
+				maxPaymentTermDays),
+		})
+	}
+	return fieldValidation
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+// handleSaveError maps repository errors of insert and update
+func handleSaveError(ctx context.Context, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"supplier not found",
+		))
+	}
+	if errors.Is(err, pg.ErrSupplierAlreadyExists) {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(err.Error()))
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
+
+func toSupplierObject(supplier *repository.SupplierData) SupplierObject {
+	return SupplierObject{
+		SupplierID:      supplier.ID,
+		Name:            supplier.Name,
+		ContactName:     fromNullString(supplier.ContactName),
+		Phone:           fromNullString(supplier.Phone),
+		Email:           fromNullString(supplier.Email),
+		Address:         fromNullString(supplier.Address),
+		PaymentTermDays: supplier.PaymentTermDays,
+		Note:            fromNullString(supplier.Note),
+		IsActive:        supplier.IsActive,
+		CreatedBy:       supplier.CreatedBy,
+		UpdatedBy:       supplier.UpdatedBy,
+		CreatedAt:       supplier.CreatedAt,
+		UpdatedAt:       supplier.UpdatedAt,
+	}
+}
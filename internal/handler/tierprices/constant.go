@@ -0,0 +1,10 @@
+package tierprices
+
+const (
+	fieldValidationFieldVariantID  = "variant_id"
+	fieldValidationFieldCustomerID = "customer_id"
+	fieldValidationFieldQuantity   = "quantity"
+	fieldValidationFieldPrices     = "prices"
+
+	maxTierPrices = 50
+)
@@ -0,0 +1,67 @@
+package tierprices
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service TierPriceService
+}
+
+func NewHandler(service TierPriceService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/variants")
+	endpoint.GET("/:variant_id/tier-prices", h.GetTierPrices)
+	endpoint.PUT("/:variant_id/tier-prices", h.UpdateTierPrices)
+	endpoint.GET("/:variant_id/effective-price", h.GetEffectivePrice)
+}
+
+func (h *Handler) GetTierPrices(c *gin.Context) {
+	request := &GetTierPricesRequest{
+		VariantID: c.Param("variant_id"),
+	}
+	response, err := h.service.GetTierPrices(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateTierPrices(c *gin.Context) {
+	request := &UpdateTierPricesRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.VariantID = c.Param("variant_id")
+	if err := h.service.UpdateTierPrices(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetEffectivePrice takes the optional customer_id and quantity as query
+// parameters
+func (h *Handler) GetEffectivePrice(c *gin.Context) {
+	request := &GetEffectivePriceRequest{
+		VariantID:  c.Param("variant_id"),
+		CustomerID: c.Query("customer_id"),
+		Quantity:   c.Query("quantity"),
+	}
+	response, err := h.service.GetEffectivePrice(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
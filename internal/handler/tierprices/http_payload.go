@@ -0,0 +1,38 @@
+package tierprices
+
+import "github.com/shopspring/decimal"
+
+type GetTierPricesRequest struct {
+	VariantID string `json:"variant_id"`
+}
+
+type GetTierPricesResponse struct {
+	Data VariantTierPricesObject `json:"data"`
+}
+
+type UpdateTierPricesRequest struct {
+	VariantID string `json:"-"`
+	// Prices replace every tier price of the variant, an empty list removes
+	// them
+	Prices []TierPricePayload `json:"prices"`
+}
+
+type TierPricePayload struct {
+	PriceTier string `json:"price_tier"`
+	// MinQuantity is 1 for the price of the tier, higher values are quantity
+	// breaks
+	MinQuantity int             `json:"min_quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+}
+
+type GetEffectivePriceRequest struct {
+	VariantID string `json:"variant_id"`
+	// CustomerID is empty for a walk-in customer
+	CustomerID string `json:"customer_id"`
+	// Quantity is 1 when empty
+	Quantity string `json:"quantity"`
+}
+
+type GetEffectivePriceResponse struct {
+	Data EffectivePriceObject `json:"data"`
+}
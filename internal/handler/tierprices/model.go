@@ -0,0 +1,28 @@
+package tierprices
+
+import "github.com/shopspring/decimal"
+
+type TierPriceObject struct {
+	PriceTier   string          `json:"price_tier"`
+	MinQuantity int             `json:"min_quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+}
+
+type VariantTierPricesObject struct {
+	VariantID    string            `json:"variant_id"`
+	FullName     string            `json:"full_name"`
+	SellingPrice decimal.Decimal   `json:"selling_price"`
+	TierPrices   []TierPriceObject `json:"tier_prices"`
+}
+
+type EffectivePriceObject struct {
+	VariantID         string          `json:"variant_id"`
+	CustomerID        *string         `json:"customer_id"`
+	CustomerPriceTier string          `json:"customer_price_tier"`
+	Quantity          int             `json:"quantity"`
+	SellingPrice      decimal.Decimal `json:"selling_price"`
+	UnitPrice         decimal.Decimal `json:"unit_price"`
+	LineAmount        decimal.Decimal `json:"line_amount"`
+	// AppliedTierPrice is nil when the selling price applies
+	AppliedTierPrice *TierPriceObject `json:"applied_tier_price"`
+}
@@ -0,0 +1,39 @@
+package tierprices
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type TierPriceService interface {
+	GetTierPrices(ctx context.Context, request *GetTierPricesRequest) (*GetTierPricesResponse, error)
+	UpdateTierPrices(ctx context.Context, request *UpdateTierPricesRequest) error
+	GetEffectivePrice(
+		ctx context.Context,
+		request *GetEffectivePriceRequest,
+	) (*GetEffectivePriceResponse, error)
+}
+
+type Service struct {
+	db                         *pgxpool.Pool
+	productVariantRepository   repository.ProductVariant
+	variantTierPriceRepository repository.VariantTierPrice
+	tierPriceResolver          *pricing.TierPriceResolver
+}
+
+func NewService(
+	db *pgxpool.Pool,
+	productVariantRepository repository.ProductVariant,
+	variantTierPriceRepository repository.VariantTierPrice,
+	tierPriceResolver *pricing.TierPriceResolver,
+) TierPriceService {
+	return &Service{
+		db:                         db,
+		productVariantRepository:   productVariantRepository,
+		variantTierPriceRepository: variantTierPriceRepository,
+		tierPriceResolver:          tierPriceResolver,
+	}
+}
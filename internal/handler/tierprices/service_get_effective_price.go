@@ -0,0 +1,111 @@
+package tierprices
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"github.com/shopspring/decimal"
+)
+
+type requestGetEffectivePrice struct {
+	*GetEffectivePriceRequest
+	quantity int
+}
+
+func (req *requestGetEffectivePrice) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	req.CustomerID = strings.TrimSpace(req.CustomerID)
+	req.Quantity = strings.TrimSpace(req.Quantity)
+}
+
+func (req *requestGetEffectivePrice) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if req.CustomerID != "" {
+		if err := common.ValidateUUIDFormat(req.CustomerID); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldCustomerID,
+				Message: err.Error(),
+			})
+		}
+	}
+	req.quantity = 1
+	if req.Quantity != "" {
+		quantity, err := strconv.Atoi(req.Quantity)
+		if err != nil || quantity < 1 {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldQuantity,
+				Message: "quantity must be a whole number greater than 0",
+			})
+		}
+		req.quantity = quantity
+	}
+	return fieldValidation
+}
+
+// GetEffectivePrice returns the unit price the customer pays for the
+// quantity of the variant
+func (s *Service) GetEffectivePrice(
+	ctx context.Context,
+	request *GetEffectivePriceRequest,
+) (*GetEffectivePriceResponse, error) {
+	input := &requestGetEffectivePrice{
+		GetEffectivePriceRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tier, err := s.tierPriceResolver.CustomerTier(ctx, input.CustomerID)
+	if err != nil {
+		return nil, handleCustomerTierError(ctx, err)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	variant, err := s.findActiveVariant(ctx, tx, input.VariantID)
+	if err != nil {
+		// error is already handled by findActiveVariant
+		return nil, err
+	}
+	prices, err := s.tierPriceResolver.TierPrices(ctx, []string{variant.ID})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	price := pricing.ResolveUnitPrice(
+		variant.SellingPrice, tier, input.quantity, prices[variant.ID])
+	data := EffectivePriceObject{
+		VariantID:         variant.ID,
+		CustomerPriceTier: string(tier),
+		Quantity:          input.quantity,
+		SellingPrice:      variant.SellingPrice,
+		UnitPrice:         price.UnitPrice,
+		LineAmount:        price.UnitPrice.Mul(decimal.NewFromInt(int64(input.quantity))),
+	}
+	if input.CustomerID != "" {
+		data.CustomerID = &input.CustomerID
+	}
+	if price.PriceTier != "" {
+		data.AppliedTierPrice = &TierPriceObject{
+			PriceTier:   string(price.PriceTier),
+			MinQuantity: price.MinQuantity,
+			UnitPrice:   price.UnitPrice,
+		}
+	}
+	return &GetEffectivePriceResponse{Data: data}, nil
+}
@@ -0,0 +1,68 @@
+package tierprices
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetTierPrices struct {
+	*GetTierPricesRequest
+}
+
+func (req *requestGetTierPrices) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+}
+
+func (req *requestGetTierPrices) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetTierPrices(
+	ctx context.Context,
+	request *GetTierPricesRequest,
+) (*GetTierPricesResponse, error) {
+	input := &requestGetTierPrices{
+		GetTierPricesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	variant, err := s.findActiveVariant(ctx, tx, input.VariantID)
+	if err != nil {
+		// error is already handled by findActiveVariant
+		return nil, err
+	}
+	prices, err := s.variantTierPriceRepository.FindByVariantIDs(
+		ctx, []string{variant.ID})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetTierPricesResponse{
+		Data: VariantTierPricesObject{
+			VariantID:    variant.ID,
+			FullName:     variant.FullName,
+			SellingPrice: variant.SellingPrice,
+			TierPrices:   toTierPriceObjects(prices),
+		},
+	}, nil
+}
@@ -0,0 +1,124 @@
+package tierprices
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateTierPrices struct {
+	*UpdateTierPricesRequest
+}
+
+func (req *requestUpdateTierPrices) sanitize() {
+	req.VariantID = strings.TrimSpace(req.VariantID)
+	for i := range req.Prices {
+		req.Prices[i].PriceTier = strings.TrimSpace(
+			strings.ToUpper(req.Prices[i].PriceTier))
+	}
+}
+
+func (req *requestUpdateTierPrices) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateUUIDFormat(req.VariantID); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldVariantID,
+			Message: err.Error(),
+		})
+	}
+	if len(req.Prices) > maxTierPrices {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldPrices,
+			Message: fmt.Sprintf("prices must not contain more than %d items", maxTierPrices),
+		})
+	}
+	seen := make(map[string]bool, len(req.Prices))
+	for i, price := range req.Prices {
+		field := func(name string) string {
+			return fmt.Sprintf("%s[%d].%s", fieldValidationFieldPrices, i, name)
+		}
+		if err := common.ValidateOneOf(price.PriceTier, allowedPriceTiers); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("price_tier"),
+				Message: err.Error(),
+			})
+		}
+		if price.MinQuantity < 1 {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("min_quantity"),
+				Message: "min_quantity must be at least 1",
+			})
+		}
+		key := fmt.Sprintf("%s:%d", price.PriceTier, price.MinQuantity)
+		if seen[key] {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("min_quantity"),
+				Message: "min_quantity is already priced for the tier",
+			})
+		}
+		seen[key] = true
+		if price.UnitPrice.IsNegative() {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   field("unit_price"),
+				Message: "unit_price must not be negative",
+			})
+		}
+	}
+	return fieldValidation
+}
+
+// UpdateTierPrices replaces the tier and quantity break prices of the
+// variant, the selling price stays the price for walk-in customers
+func (s *Service) UpdateTierPrices(
+	ctx context.Context,
+	request *UpdateTierPricesRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateTierPrices{
+		UpdateTierPricesRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	variant, err := s.findActiveVariant(ctx, tx, input.VariantID)
+	if err != nil {
+		// error is already handled by findActiveVariant
+		return err
+	}
+	prices := make([]repository.VariantTierPriceData, 0, len(input.Prices))
+	for _, price := range input.Prices {
+		prices = append(prices, repository.VariantTierPriceData{
+			ID:          uuid.NewString(),
+			VariantID:   variant.ID,
+			PriceTier:   repository.CustomerPriceTier(price.PriceTier),
+			MinQuantity: price.MinQuantity,
+			UnitPrice:   price.UnitPrice.Round(2),
+			CreatedBy:   userID,
+		})
+	}
+	if err := s.variantTierPriceRepository.ReplaceByVariantIDTransaction(
+		ctx, tx, variant.ID, prices); err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,64 @@
+package tierprices
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/pricing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+var allowedPriceTiers = []string{
+	string(repository.CustomerPriceTierRetail),
+	string(repository.CustomerPriceTierReseller),
+	string(repository.CustomerPriceTierWholesale),
+}
+
+// findActiveVariant maps a missing or inactive variant to not found
+func (s *Service) findActiveVariant(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+) (*repository.ProductVariantData, error) {
+	variants, err := s.productVariantRepository.FindManyByID(
+		ctx, tx, []string{variantID})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	if len(variants) == 0 {
+		return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"variant not found",
+		))
+	}
+	return &variants[0], nil
+}
+
+// handleCustomerTierError maps the errors of TierPriceResolver.CustomerTier
+func handleCustomerTierError(ctx context.Context, err error) error {
+	if errors.Is(err, pricing.ErrCustomerNotFound) ||
+		errors.Is(err, pricing.ErrCustomerInactive) {
+		return httperror.NewMultiFieldValidation(ctx, []httperror.FieldValidation{{
+			Field:   fieldValidationFieldCustomerID,
+			Message: err.Error(),
+		}})
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
+
+func toTierPriceObjects(prices []repository.VariantTierPriceData) []TierPriceObject {
+	result := make([]TierPriceObject, 0, len(prices))
+	for _, price := range prices {
+		result = append(result, TierPriceObject{
+			PriceTier:   string(price.PriceTier),
+			MinQuantity: price.MinQuantity,
+			UnitPrice:   price.UnitPrice,
+		})
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+package users
+
+const (
+	fieldValidationFieldUsername        = "username"
+	fieldValidationFieldPassword        = "password"
+	fieldValidationFieldNewPassword     = "new_password"
+	fieldValidationFieldCurrentPassword = "current_password"
+	fieldValidationFieldRole            = "role"
+	fieldValidationFieldIsActive        = "is_active"
+
+	minLengthUsername = 3
+	maxLengthUsername = 30
+	minLengthPassword = 8
+	// bcrypt ignores every byte after the 72nd
+	maxLengthPassword = 72
+	// maxLengthUserAgent is the size of auth_events.user_agent
+	maxLengthUserAgent = 255
+)
@@ -0,0 +1,138 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+)
+
+type Handler struct {
+	service UserService
+}
+
+func NewHandler(service UserService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers the admin user management routes
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/users")
+	endpoint.POST("/", h.CreateUser)
+	endpoint.GET("/", h.GetUsers)
+	endpoint.GET("/:username", h.GetUser)
+	endpoint.PATCH("/:username/role", h.UpdateUserRole)
+	endpoint.PUT("/:username/password", h.ResetUserPassword)
+	endpoint.PATCH("/:username/status", h.UpdateUserStatus)
+}
+
+// RegisterAccountRoutes registers the self-service routes of the caller
+func (h *Handler) RegisterAccountRoutes(router gin.IRouter) {
+	endpoint := router.Group("/api/v1/account")
+	endpoint.PUT("/password", h.ChangePassword)
+}
+
+func (h *Handler) CreateUser(c *gin.Context) {
+	request := &CreateUserRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	response, err := h.service.CreateUser(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetUsers(c *gin.Context) {
+	pagination, err := util.NewPaginationData(
+		c.Query("page_number"), c.Query("page_size"))
+	if err != nil {
+		errMsg := "invalid pagination data : " + err.Error()
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+	request := &GetUsersRequest{
+		PaginationData: *pagination,
+		Username:       c.Query("username"),
+		Role:           c.Query("role"),
+		IsActive:       c.Query("is_active"),
+	}
+	response, err := h.service.GetUsers(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetUser(c *gin.Context) {
+	request := &GetUserRequest{
+		Username: c.Param("username"),
+	}
+	response, err := h.service.GetUser(c, request)
+	if err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	request := &UpdateUserRoleRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.Username = c.Param("username")
+	if err := h.service.UpdateUserRole(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) ResetUserPassword(c *gin.Context) {
+	request := &ResetUserPasswordRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.Username = c.Param("username")
+	if err := h.service.ResetUserPassword(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) UpdateUserStatus(c *gin.Context) {
+	request := &UpdateUserStatusRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.Username = c.Param("username")
+	if err := h.service.UpdateUserStatus(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (h *Handler) ChangePassword(c *gin.Context) {
+	request := &ChangePasswordRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.ChangePassword(c, request); err != nil {
+		util.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
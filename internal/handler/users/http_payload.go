@@ -0,0 +1,53 @@
+package users
+
+import "github.com/rizkysr90/rizkiplastik-be/internal/util"
+
+type CreateUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+type CreateUserResponse struct {
+	Username string `json:"username"`
+}
+
+type GetUserRequest struct {
+	Username string `json:"username"`
+}
+
+type GetUserResponse struct {
+	Data UserObject `json:"data"`
+}
+
+type GetUsersRequest struct {
+	util.PaginationData `json:"pagination"`
+	Username            string `json:"username"`
+	Role                string `json:"role"`
+	IsActive            string `json:"is_active"`
+}
+
+type GetUsersResponse struct {
+	util.PaginationData `json:"pagination"`
+	Data                []UserObject `json:"data"`
+}
+
+type UpdateUserRoleRequest struct {
+	Username string `json:"-"`
+	Role     string `json:"role"`
+}
+
+type ResetUserPasswordRequest struct {
+	Username string `json:"-"`
+	Password string `json:"password"`
+}
+
+type UpdateUserStatusRequest struct {
+	Username string `json:"-"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
@@ -0,0 +1,14 @@
+package users
+
+import "time"
+
+type UserObject struct {
+	Username  string     `json:"username"`
+	Role      string     `json:"role"`
+	IsActive  bool       `json:"is_active"`
+	LastLogin *time.Time `json:"last_login"`
+	CreatedBy *string    `json:"created_by"`
+	UpdatedBy *string    `json:"updated_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
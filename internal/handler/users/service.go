@@ -0,0 +1,32 @@
+package users
+
+import (
+	"context"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type UserService interface {
+	CreateUser(ctx context.Context, request *CreateUserRequest) (*CreateUserResponse, error)
+	GetUser(ctx context.Context, request *GetUserRequest) (*GetUserResponse, error)
+	GetUsers(ctx context.Context, request *GetUsersRequest) (*GetUsersResponse, error)
+	UpdateUserRole(ctx context.Context, request *UpdateUserRoleRequest) error
+	ResetUserPassword(ctx context.Context, request *ResetUserPasswordRequest) error
+	UpdateUserStatus(ctx context.Context, request *UpdateUserStatusRequest) error
+	ChangePassword(ctx context.Context, request *ChangePasswordRequest) error
+}
+
+type Service struct {
+	userRepository      repository.User
+	authEventRepository repository.AuthEvent
+}
+
+func NewService(
+	userRepository repository.User,
+	authEventRepository repository.AuthEvent,
+) UserService {
+	return &Service{
+		userRepository:      userRepository,
+		authEventRepository: authEventRepository,
+	}
+}
@@ -0,0 +1,96 @@
+package users
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type requestChangePassword struct {
+	*ChangePasswordRequest
+}
+
+func (req *requestChangePassword) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.CurrentPassword == "" {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldCurrentPassword,
+			Message: "current_password is required",
+		})
+	}
+	fieldValidation = append(fieldValidation,
+		validatePassword(fieldValidationFieldNewPassword, req.NewPassword)...)
+	if req.NewPassword != "" && req.NewPassword == req.CurrentPassword {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldNewPassword,
+			Message: "new_password must be different from current_password",
+		})
+	}
+	return fieldValidation
+}
+
+// ChangePassword lets the caller replace their own password. Every other
+// session of the caller is revoked, the session of this request stays valid
+// so the caller is not logged out.
+func (s *Service) ChangePassword(
+	ctx context.Context,
+	request *ChangePasswordRequest,
+) error {
+	identity, err := util.GetIdentity(ctx)
+	if err != nil {
+		// error is already handled by GetIdentity
+		return err
+	}
+	userID := identity.Username
+	input := &requestChangePassword{
+		ChangePasswordRequest: request,
+	}
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	user, err := s.userRepository.FindByUsername(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"user not found",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	err = bcrypt.CompareHashAndPassword(
+		[]byte(user.HashPassword), []byte(input.CurrentPassword))
+	if err != nil {
+		if isPasswordMismatch(err) {
+			return httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"current password is incorrect",
+			))
+		}
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	hash, err := hashPassword(input.NewPassword)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	err = s.userRepository.UpdatePassword(
+		ctx, userID, hash, userID, identity.SessionID)
+	if err != nil {
+		return handleUpdateUserError(ctx, err)
+	}
+	s.recordAuthEvent(ctx, repository.AuthEventTypePasswordChanged,
+		userID, repository.AuthEventReasonPasswordChanged)
+	s.recordAuthEvent(ctx, repository.AuthEventTypeTokenRevoked,
+		userID, repository.AuthEventReasonPasswordChanged)
+	return nil
+}
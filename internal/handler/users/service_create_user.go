@@ -0,0 +1,77 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository/pg"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestCreateUser struct {
+	*CreateUserRequest
+}
+
+func (req *requestCreateUser) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+	req.Role = strings.TrimSpace(strings.ToUpper(req.Role))
+}
+
+func (req *requestCreateUser) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	fieldValidation = append(fieldValidation, validateUsername(req.Username)...)
+	fieldValidation = append(fieldValidation,
+		validatePassword(fieldValidationFieldPassword, req.Password)...)
+	fieldValidation = append(fieldValidation, validateRole(req.Role)...)
+	return fieldValidation
+}
+
+func (s *Service) CreateUser(
+	ctx context.Context,
+	request *CreateUserRequest,
+) (*CreateUserResponse, error) {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return nil, err
+	}
+	input := &requestCreateUser{
+		CreateUserRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	hash, err := hashPassword(input.Password)
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	err = s.userRepository.Insert(ctx, &repository.UserData{
+		Username:     input.Username,
+		HashPassword: hash,
+		Role:         input.Role,
+		IsActive:     true,
+		CreatedBy:    sql.NullString{String: userID, Valid: true},
+		UpdatedBy:    sql.NullString{String: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pg.ErrUserAlreadyExists) {
+			return nil, httperror.NewBadRequest(ctx, httperror.WithMessage(
+				"username already exists",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &CreateUserResponse{
+		Username: input.Username,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetUser struct {
+	*GetUserRequest
+}
+
+func (req *requestGetUser) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+}
+
+func (req *requestGetUser) validateField() []httperror.FieldValidation {
+	return validateUsername(req.Username)
+}
+
+func (s *Service) GetUser(
+	ctx context.Context,
+	request *GetUserRequest,
+) (*GetUserResponse, error) {
+	input := &requestGetUser{
+		GetUserRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	user, err := s.userRepository.FindByUsername(ctx, input.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, httperror.NewDataNotFound(ctx, httperror.WithMessage(
+				"user not found",
+			))
+		}
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	return &GetUserResponse{
+		Data: toUserObject(user),
+	}, nil
+}
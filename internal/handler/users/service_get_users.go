@@ -0,0 +1,78 @@
+package users
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestGetUsers struct {
+	*GetUsersRequest
+}
+
+func (req *requestGetUsers) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+	req.Role = strings.TrimSpace(strings.ToUpper(req.Role))
+	req.IsActive = strings.TrimSpace(strings.ToUpper(req.IsActive))
+}
+
+func (req *requestGetUsers) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if req.Role != "" {
+		fieldValidation = append(fieldValidation, validateRole(req.Role)...)
+	}
+	if req.IsActive != "" {
+		if err := common.ValidateOneOf(req.IsActive, []string{"TRUE", "FALSE"}); err != nil {
+			fieldValidation = append(fieldValidation, httperror.FieldValidation{
+				Field:   fieldValidationFieldIsActive,
+				Message: err.Error(),
+			})
+		}
+	}
+	if req.PageNumber <= 0 || req.PageSize <= 0 {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   "pagination",
+			Message: "page_number and page_size must be greater than 0",
+		})
+	}
+	return fieldValidation
+}
+
+func (s *Service) GetUsers(
+	ctx context.Context,
+	request *GetUsersRequest,
+) (*GetUsersResponse, error) {
+	input := &requestGetUsers{
+		GetUsersRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return nil, httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	users, totalCount, err := s.userRepository.FindPaginated(ctx,
+		&repository.UserFilter{
+			Username: input.Username,
+			Role:     input.Role,
+			IsActive: input.IsActive,
+			Limit:    input.PageSize,
+			Offset:   input.GetOffset(),
+		})
+	if err != nil {
+		return nil, httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	data := make([]UserObject, 0, len(users))
+	for i := range users {
+		data = append(data, toUserObject(&users[i]))
+	}
+	input.SetTotalPagesAndTotalElement(totalCount)
+	return &GetUsersResponse{
+		PaginationData: input.PaginationData,
+		Data:           data,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+type requestUpdateUserRole struct {
+	*UpdateUserRoleRequest
+}
+
+func (req *requestUpdateUserRole) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+	req.Role = strings.TrimSpace(strings.ToUpper(req.Role))
+}
+
+func (req *requestUpdateUserRole) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	fieldValidation = append(fieldValidation, validateUsername(req.Username)...)
+	fieldValidation = append(fieldValidation, validateRole(req.Role)...)
+	return fieldValidation
+}
+
+// UpdateUserRole changes the role of a user and revokes the user's sessions,
+// the role is part of the JWT claims so the user must log in again
+func (s *Service) UpdateUserRole(
+	ctx context.Context,
+	request *UpdateUserRoleRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateUserRole{
+		UpdateUserRoleRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if input.Username == userID {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"cannot change your own role",
+		))
+	}
+	err = s.userRepository.UpdateRole(ctx, input.Username, input.Role, userID)
+	if err != nil {
+		return handleUpdateUserError(ctx, err)
+	}
+	s.recordAuthEvent(ctx, repository.AuthEventTypeTokenRevoked,
+		input.Username, repository.AuthEventReasonRoleChanged)
+	return nil
+}
+
+type requestResetUserPassword struct {
+	*ResetUserPasswordRequest
+}
+
+func (req *requestResetUserPassword) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+}
+
+func (req *requestResetUserPassword) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	fieldValidation = append(fieldValidation, validateUsername(req.Username)...)
+	fieldValidation = append(fieldValidation,
+		validatePassword(fieldValidationFieldPassword, req.Password)...)
+	return fieldValidation
+}
+
+// ResetUserPassword sets a new password chosen by an admin and revokes the
+// user's sessions
+func (s *Service) ResetUserPassword(
+	ctx context.Context,
+	request *ResetUserPasswordRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestResetUserPassword{
+		ResetUserPasswordRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	hash, err := hashPassword(input.Password)
+	if err != nil {
+		return httperror.NewInternalServer(ctx, httperror.WithMessage(
+			"internal_server_error: "+err.Error(),
+		))
+	}
+	err = s.userRepository.UpdatePassword(ctx, input.Username, hash, userID, "")
+	if err != nil {
+		return handleUpdateUserError(ctx, err)
+	}
+	s.recordAuthEvent(ctx, repository.AuthEventTypePasswordChanged,
+		input.Username, repository.AuthEventReasonPasswordReset)
+	s.recordAuthEvent(ctx, repository.AuthEventTypeTokenRevoked,
+		input.Username, repository.AuthEventReasonPasswordReset)
+	return nil
+}
+
+type requestUpdateUserStatus struct {
+	*UpdateUserStatusRequest
+}
+
+func (req *requestUpdateUserStatus) sanitize() {
+	req.Username = strings.TrimSpace(req.Username)
+}
+
+func (req *requestUpdateUserStatus) validateField() []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	fieldValidation = append(fieldValidation, validateUsername(req.Username)...)
+	if req.IsActive == nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldIsActive,
+			Message: "is_active is required",
+		})
+	}
+	return fieldValidation
+}
+
+// UpdateUserStatus enables or disables a user, disabling also revokes the
+// user's sessions
+func (s *Service) UpdateUserStatus(
+	ctx context.Context,
+	request *UpdateUserStatusRequest,
+) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
+	input := &requestUpdateUserStatus{
+		UpdateUserStatusRequest: request,
+	}
+	input.sanitize()
+	fieldValidation := input.validateField()
+	if len(fieldValidation) > 0 {
+		return httperror.NewMultiFieldValidation(ctx, fieldValidation)
+	}
+	if input.Username == userID && !*input.IsActive {
+		return httperror.NewBadRequest(ctx, httperror.WithMessage(
+			"cannot disable your own account",
+		))
+	}
+	err = s.userRepository.UpdateStatus(ctx, input.Username, *input.IsActive, userID)
+	if err != nil {
+		return handleUpdateUserError(ctx, err)
+	}
+	if !*input.IsActive {
+		s.recordAuthEvent(ctx, repository.AuthEventTypeTokenRevoked,
+			input.Username, repository.AuthEventReasonUserDisabled)
+	}
+	return nil
+}
+
+func handleUpdateUserError(ctx context.Context, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return httperror.NewDataNotFound(ctx, httperror.WithMessage(
+			"user not found",
+		))
+	}
+	return httperror.NewInternalServer(ctx, httperror.WithMessage(
+		"internal_server_error: "+err.Error(),
+	))
+}
@@ -0,0 +1,139 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rizkysr90/rizkiplastik-be/internal/common"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var allowedRoles = []string{constants.RoleAdmin, constants.RoleStaff}
+
+func validateUsername(username string) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateMinLengthStr(username, minLengthUsername); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldUsername,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateMaxLengthStr(username, maxLengthUsername); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldUsername,
+			Message: err.Error(),
+		})
+	}
+	if strings.ContainsAny(username, " \t\r\n") {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldUsername,
+			Message: "must not contain whitespace",
+		})
+	}
+	return fieldValidation
+}
+
+func validatePassword(field, password string) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateMinLengthStr(password, minLengthPassword); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field,
+			Message: err.Error(),
+		})
+	}
+	if err := common.ValidateMaxLengthStr(password, maxLengthPassword); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   field,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+func validateRole(role string) []httperror.FieldValidation {
+	fieldValidation := []httperror.FieldValidation{}
+	if err := common.ValidateOneOf(role, allowedRoles); err != nil {
+		fieldValidation = append(fieldValidation, httperror.FieldValidation{
+			Field:   fieldValidationFieldRole,
+			Message: err.Error(),
+		})
+	}
+	return fieldValidation
+}
+
+// hashPassword hashes the password the same way the login handler compares it
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func isPasswordMismatch(err error) bool {
+	return errors.Is(err, bcrypt.ErrMismatchedHashAndPassword)
+}
+
+func toUserObject(user *repository.UserData) UserObject {
+	object := UserObject{
+		Username:  user.Username,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+	if user.LastLogin.Valid {
+		object.LastLogin = &user.LastLogin.Time
+	}
+	if user.CreatedBy.Valid {
+		object.CreatedBy = &user.CreatedBy.String
+	}
+	if user.UpdatedBy.Valid {
+		object.UpdatedBy = &user.UpdatedBy.String
+	}
+	return object
+}
+
+// recordAuthEvent writes to the auth audit trail with the caller as actor,
+// errors are logged only because the change itself has been saved already
+func (s *Service) recordAuthEvent(
+	ctx context.Context,
+	eventType repository.AuthEventType,
+	username, reason string,
+) {
+	identity, err := util.GetIdentity(ctx)
+	if err != nil {
+		return
+	}
+	err = s.authEventRepository.Insert(ctx, &repository.AuthEventData{
+		ID:        uuid.NewString(),
+		EventType: eventType,
+		Username:  sql.NullString{String: username, Valid: true},
+		Actor:     sql.NullString{String: identity.Username, Valid: true},
+		Reason:    sql.NullString{String: reason, Valid: reason != ""},
+		IPAddress: sql.NullString{String: identity.IPAddress, Valid: identity.IPAddress != ""},
+		UserAgent: sql.NullString{
+			String: truncateUserAgent(identity.UserAgent),
+			Valid:  identity.UserAgent != "",
+		},
+	})
+	if err != nil {
+		log.Printf("users: failed to record auth event: %v", err)
+	}
+}
+
+func truncateUserAgent(userAgent string) string {
+	runes := []rune(userAgent)
+	if len(runes) > maxLengthUserAgent {
+		return string(runes[:maxLengthUserAgent])
+	}
+	return userAgent
+}
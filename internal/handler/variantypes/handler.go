@@ -22,7 +22,7 @@ func NewHandler(
 		service: service,
 	}
 }
-func (h *Handler) RegisterRoutes(router *gin.Engine) {
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
 	endpoint := router.Group("/api/v1/variant-types")
 	endpoint.POST("/", h.PostVariantType)
 	endpoint.PUT("/:variant_type_id", h.PutVariantType)
@@ -10,6 +10,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/variantypes/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/variantypes/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -36,6 +37,11 @@ func (req *reqCreateVarianType) validate(ctx context.Context) error {
 }
 func (s *VarianTypes) CreateVarianType(
 	ctx context.Context, data *model.RequestCreateVarianType) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqCreateVarianType{
 		RequestCreateVarianType: data,
 	}
@@ -46,8 +52,8 @@ func (s *VarianTypes) CreateVarianType(
 	insertedData := &repository.VarianTypeData{
 		ID:        uuid.NewString(),
 		Name:      input.VarianTypeName,
-		CreatedBy: "SYSTEM",
-		UpdatedBy: "SYSTEM",
+		CreatedBy: userID,
+		UpdatedBy: userID,
 	}
 	if input.VarianTypeDescription != nil {
 		insertedData.Description = sql.NullString{
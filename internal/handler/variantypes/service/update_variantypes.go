@@ -11,6 +11,7 @@ import (
 	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/variantypes/model"
 	"github.com/rizkysr90/rizkiplastik-be/internal/handler/variantypes/repository"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
 )
 
@@ -42,6 +43,11 @@ func (req *reqUpdateVarianType) validate(ctx context.Context) error {
 }
 func (s *VarianTypes) UpdateVarianType(
 	ctx context.Context, data *model.RequestUpdateVarianType) error {
+	userID, err := util.GetUserID(ctx)
+	if err != nil {
+		// error is already handled by GetUserID
+		return err
+	}
 	input := &reqUpdateVarianType{
 		RequestUpdateVarianType: data,
 	}
@@ -53,7 +59,7 @@ func (s *VarianTypes) UpdateVarianType(
 		ID:        input.VarianTypeID,
 		Name:      input.VarianTypeName,
 		IsActive:  input.IsActive,
-		UpdatedBy: "SYSTEM",
+		UpdatedBy: userID,
 	}
 	if input.VarianTypeDescription != nil {
 		updatedData.Description = sql.NullString{
@@ -10,6 +10,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rizkysr90/rizkiplastik-be/internal/config"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
 )
 
 // AuthMiddleware provides JWT authentication middleware
@@ -65,23 +66,28 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		// Get token and role from database by username
-		var storedToken string
+		sessionID, ok := claims["sid"].(string)
+		if !ok || sessionID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims: missing session"})
+			c.Abort()
+			return
+		}
+		// Get role from database by the session the token was issued for
 		var dbRole string
 		query := `
-			SELECT token, role
-			FROM users
-			WHERE username = $1
+			SELECT u.role
+			FROM user_sessions s
+			JOIN users u
+				ON u.username = s.username
+			WHERE s.id = $1
+			AND s.username = $2
+			AND s.revoked_at IS NULL
+			AND s.expires_at > NOW()
+			AND u.is_active = true
 		`
-		err = m.db.QueryRow(c, query, username).Scan(&storedToken, &dbRole)
+		err = m.db.QueryRow(c, query, sessionID, username).Scan(&dbRole)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found or token not set"})
-			c.Abort()
-			return
-		}
-		// Step 2: Compare stored token with provided token
-		if storedToken != tokenString {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked or expired"})
 			c.Abort()
 			return
 		}
@@ -94,6 +100,13 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		// Set claims in context for use in handlers
 		c.Set("username", username)
 		c.Set("role", tokenRole)
+		c.Set(util.ContextKeyIdentity, &util.Identity{
+			Username:  username,
+			Role:      tokenRole,
+			SessionID: sessionID,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
 		c.Next()
 	}
 }
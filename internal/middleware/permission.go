@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util"
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+// Permission is a single capability granted to a role
+type Permission string
+
+const (
+	PermissionCatalogRead     Permission = "catalog:read"
+	PermissionMasterDataWrite Permission = "master_data:write"
+	PermissionRulesWrite      Permission = "rules:write"
+	PermissionProductWrite    Permission = "product:write"
+	PermissionProductDelete   Permission = "product:delete"
+	PermissionRecipeWrite     Permission = "recipe:write"
+	PermissionStockRead       Permission = "stock:read"
+	PermissionStockWrite      Permission = "stock:write"
+	PermissionUserManage      Permission = "user:manage"
+	PermissionSalesRead       Permission = "sales:read"
+	PermissionSalesWrite      Permission = "sales:write"
+	PermissionSalesDelete     Permission = "sales:delete"
+	// PermissionPricingWrite guards every write of a cost or selling price,
+	// tier price, price schedule, channel listing and customer price tier
+	PermissionPricingWrite Permission = "pricing:write"
+)
+
+// rolePermissions is the permission matrix. ADMIN manages master data, rules,
+// prices and users, STAFF reads the catalog, creates products, works the
+// stock and records sales.
+var rolePermissions = map[string][]Permission{
+	constants.RoleAdmin: {
+		PermissionCatalogRead,
+		PermissionMasterDataWrite,
+		PermissionRulesWrite,
+		PermissionProductWrite,
+		PermissionProductDelete,
+		PermissionRecipeWrite,
+		PermissionStockRead,
+		PermissionStockWrite,
+		PermissionUserManage,
+		PermissionSalesRead,
+		PermissionSalesWrite,
+		PermissionSalesDelete,
+		PermissionPricingWrite,
+	},
+	constants.RoleStaff: {
+		PermissionCatalogRead,
+		PermissionProductWrite,
+		PermissionStockRead,
+		PermissionStockWrite,
+		PermissionSalesRead,
+		PermissionSalesWrite,
+	},
+}
+
+// MethodPermissions maps an HTTP method to the permission it requires,
+// methods that are not listed are denied
+type MethodPermissions map[string]Permission
+
+// ReadWritePermissions requires read for GET and write for every other method
+func ReadWritePermissions(read, write Permission) MethodPermissions {
+	return MethodPermissions{
+		http.MethodGet:    read,
+		http.MethodPost:   write,
+		http.MethodPut:    write,
+		http.MethodPatch:  write,
+		http.MethodDelete: write,
+	}
+}
+
+// PermissionsForRole returns the permissions granted to role
+func PermissionsForRole(role string) []Permission {
+	permissions := rolePermissions[role]
+	result := make([]Permission, len(permissions))
+	copy(result, permissions)
+	return result
+}
+
+// HasPermission checks whether role is granted permission
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPermission returns a forbidden error when the caller role is not
+// granted permission. Services use it when the permission depends on the
+// request body rather than the route.
+func CheckPermission(ctx context.Context, permission Permission) error {
+	identity, err := util.GetIdentity(ctx)
+	if err != nil {
+		// error is already handled by GetIdentity
+		return err
+	}
+	if !HasPermission(identity.Role, permission) {
+		return httperror.NewForbidden(ctx, httperror.WithMessage(
+			"insufficient permissions, "+string(permission)+" is required",
+		))
+	}
+	return nil
+}
+
+// RequirePermissions checks the caller role against the permission needed by
+// the request method. It must run after RequireAuth.
+func (m *AuthMiddleware) RequirePermissions(permissions MethodPermissions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		if role == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Role not found in context"})
+			c.Abort()
+			return
+		}
+		permission, ok := permissions[c.Request.Method]
+		if !ok || !HasPermission(role, permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,180 @@
+// Package pricescheduler applies scheduled variant price changes in the
+// background once they are effective
+package pricescheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/costing"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+// batchSize is the number of schedules applied in one transaction
+const batchSize = 50
+
+// errCostAboveSellingPrice cancels a schedule that would leave the variant
+// with a cost price above its selling price, the other price may have
+// changed since the schedule was created
+var errCostAboveSellingPrice = errors.New(
+	"scheduled prices put the cost price above the selling price")
+
+type Scheduler struct {
+	db                             *pgxpool.Pool
+	productVariantRepository       repository.ProductVariant
+	variantPriceScheduleRepository repository.VariantPriceSchedule
+	repackCost                     *costing.RepackCost
+	interval                       time.Duration
+}
+
+func NewScheduler(
+	db *pgxpool.Pool,
+	productVariantRepository repository.ProductVariant,
+	variantPriceScheduleRepository repository.VariantPriceSchedule,
+	repackCost *costing.RepackCost,
+	interval time.Duration,
+) *Scheduler {
+	return &Scheduler{
+		db:                             db,
+		productVariantRepository:       productVariantRepository,
+		variantPriceScheduleRepository: variantPriceScheduleRepository,
+		repackCost:                     repackCost,
+		interval:                       interval,
+	}
+}
+
+// Run applies the due schedules right away and then every interval until ctx
+// is done, errors are logged and retried on the next tick
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.ApplyDue(ctx, time.Now()); err != nil {
+			log.Printf("pricescheduler: failed to apply price schedules: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ApplyDue applies every pending schedule effective at or before now. Locked
+// schedules are skipped, so several instances can run the scheduler at once.
+func (s *Scheduler) ApplyDue(ctx context.Context, now time.Time) error {
+	for {
+		count, err := s.applyBatch(ctx, now)
+		if err != nil {
+			return err
+		}
+		if count < batchSize {
+			return nil
+		}
+	}
+}
+
+func (s *Scheduler) applyBatch(ctx context.Context, now time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	schedules, err := s.variantPriceScheduleRepository.FindDueForUpdate(
+		ctx, tx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i := range schedules {
+		if err := s.applySchedule(ctx, tx, &schedules[i]); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(schedules), nil
+}
+
+// applySchedule runs in a savepoint, a schedule that can never be applied is
+// cancelled instead of blocking the schedules after it
+func (s *Scheduler) applySchedule(
+	ctx context.Context,
+	tx pgx.Tx,
+	schedule *repository.VariantPriceScheduleData,
+) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer savepoint.Rollback(ctx)
+	err = s.updatePrices(ctx, savepoint, schedule)
+	if errors.Is(err, pgx.ErrNoRows) ||
+		errors.Is(err, costing.ErrRepackChainTooDeep) ||
+		errors.Is(err, costing.ErrVariantNotFound) ||
+		errors.Is(err, errCostAboveSellingPrice) {
+		log.Printf("pricescheduler: cancelled price schedule %s: %v",
+			schedule.ID, err)
+		if err := savepoint.Rollback(ctx); err != nil {
+			return err
+		}
+		return s.variantPriceScheduleRepository.CancelTransaction(
+			ctx, tx, schedule.VariantID, schedule.ID, sql.NullString{})
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.variantPriceScheduleRepository.MarkAppliedTransaction(
+		ctx, savepoint, schedule.ID); err != nil {
+		return err
+	}
+	return savepoint.Commit(ctx)
+}
+
+// updatePrices writes the scheduled prices as the user who scheduled them and
+// pushes a new cost price down the repack chain. The prices are checked
+// against each other under the row lock the update takes anyway.
+func (s *Scheduler) updatePrices(
+	ctx context.Context,
+	tx pgx.Tx,
+	schedule *repository.VariantPriceScheduleData,
+) error {
+	costPrice, sellingPrice, err := s.productVariantRepository.FindPricesForUpdate(
+		ctx, tx, schedule.VariantID)
+	if err != nil {
+		return err
+	}
+	if schedule.CostPrice.Valid {
+		costPrice = schedule.CostPrice
+	}
+	if schedule.SellingPrice.Valid {
+		sellingPrice = schedule.SellingPrice.Decimal
+	}
+	if costPrice.Valid && costPrice.Decimal.GreaterThan(sellingPrice) {
+		return errCostAboveSellingPrice
+	}
+	if err := s.productVariantRepository.UpdatePricesTransaction(
+		ctx, tx,
+		schedule.VariantID,
+		schedule.CostPrice,
+		schedule.SellingPrice,
+		schedule.CreatedBy,
+		&repository.PriceChange{
+			Source:     repository.VariantPriceChangeSourceScheduled,
+			Reason:     schedule.Reason,
+			ScheduleID: sql.NullString{String: schedule.ID, Valid: true},
+		},
+	); err != nil {
+		return err
+	}
+	if !schedule.CostPrice.Valid {
+		return nil
+	}
+	return s.repackCost.RecomputeDescendants(
+		ctx, tx, []string{schedule.VariantID}, schedule.CreatedBy)
+}
@@ -0,0 +1,120 @@
+package pricing
+
+import (
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// Quote is the fee and what the shop receives for one listing price
+type Quote struct {
+	Price decimal.Decimal
+	Fee   Fee
+	// Net is the price minus the fee
+	Net decimal.Decimal
+}
+
+// feeComponent is one percentage fee with its optional cap
+type feeComponent struct {
+	rate  decimal.Decimal
+	limit decimal.NullDecimal
+}
+
+// QuotePrice returns the fee and net amount of a listing price
+func QuotePrice(
+	schedule *repository.ChannelFeeScheduleData,
+	category string,
+	price decimal.Decimal,
+) (*Quote, error) {
+	fee, err := CalculateFee(schedule, category, price)
+	if err != nil {
+		return nil, err
+	}
+	return &Quote{Price: price, Fee: *fee, Net: price.Sub(fee.Total)}, nil
+}
+
+// RecommendListingPrice returns the lowest whole listing price whose net,
+// after the fee charged on that price, is at least net
+func RecommendListingPrice(
+	schedule *repository.ChannelFeeScheduleData,
+	category string,
+	net decimal.Decimal,
+) (*Quote, error) {
+	rate, err := FindRate(schedule, category)
+	if err != nil {
+		return nil, err
+	}
+	components := []feeComponent{
+		{rate: rate.AdminFeePercentage.Div(hundred), limit: rate.AdminFeeCap},
+		{
+			rate:  schedule.FreeDeliveryFeePercentage.Div(hundred),
+			limit: schedule.FreeDeliveryFeeCap,
+		},
+	}
+	price := solveListingPrice(net, components).Ceil()
+	quote, err := QuotePrice(schedule, category, price)
+	if err != nil {
+		return nil, err
+	}
+	// Fee rounding can leave the net a little short of the target
+	for quote.Net.LessThan(net) {
+		price = price.Add(decimal.NewFromInt(1))
+		if quote, err = QuotePrice(schedule, category, price); err != nil {
+			return nil, err
+		}
+	}
+	return quote, nil
+}
+
+// solveListingPrice solves price - fee(price) = net. Every fee component is
+// either below its cap, growing with the price, or fixed at its cap. The net
+// grows with the price, so exactly one combination of capped components is
+// consistent with its own solution.
+func solveListingPrice(net decimal.Decimal, components []feeComponent) decimal.Decimal {
+	combinations := 1 << len(components)
+	for mask := 0; mask < combinations; mask++ {
+		fixed := decimal.Zero
+		remaining := decimal.NewFromInt(1)
+		possible := true
+		for i, component := range components {
+			if mask&(1<<i) == 0 {
+				remaining = remaining.Sub(component.rate)
+				continue
+			}
+			if !component.limit.Valid {
+				possible = false
+				break
+			}
+			fixed = fixed.Add(component.limit.Decimal)
+		}
+		if !possible || !remaining.IsPositive() {
+			continue
+		}
+		price := net.Add(fixed).Div(remaining)
+		if isConsistent(price, mask, components) {
+			return price
+		}
+	}
+	// Unreachable while the percentages are below 100, fall back to the
+	// uncapped solution
+	remaining := decimal.NewFromInt(1)
+	for _, component := range components {
+		remaining = remaining.Sub(component.rate)
+	}
+	return net.Div(remaining)
+}
+
+func isConsistent(price decimal.Decimal, mask int, components []feeComponent) bool {
+	for i, component := range components {
+		fee := price.Mul(component.rate)
+		if mask&(1<<i) != 0 {
+			if fee.LessThan(component.limit.Decimal) {
+				return false
+			}
+			continue
+		}
+		if component.limit.Valid && fee.GreaterThan(component.limit.Decimal) {
+			return false
+		}
+	}
+	return true
+}
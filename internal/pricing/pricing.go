@@ -0,0 +1,115 @@
+// Package pricing calculates marketplace fees from the channel fee schedule
+// active on a given date and resolves the unit price a customer pays
+package pricing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoActiveSchedule = errors.New("no fee schedule is active for the channel on the date")
+	ErrCategoryNotFound = errors.New("fee schedule has no rate for the category")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Fee is the marketplace fee charged on one amount
+type Fee struct {
+	AdminFee        decimal.Decimal
+	FreeDeliveryFee decimal.Decimal
+	Total           decimal.Decimal
+}
+
+type Engine struct {
+	feeScheduleRepository repository.ChannelFeeSchedule
+}
+
+func NewEngine(feeScheduleRepository repository.ChannelFeeSchedule) *Engine {
+	return &Engine{feeScheduleRepository: feeScheduleRepository}
+}
+
+// ActiveSchedule returns the schedule of the channel active on date
+func (e *Engine) ActiveSchedule(
+	ctx context.Context,
+	channel repository.OnlineChannel,
+	date time.Time,
+) (*repository.ChannelFeeScheduleData, error) {
+	schedule, err := e.feeScheduleRepository.FindActive(ctx, channel, date)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoActiveSchedule
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// CalculateFee returns the fee of amount for a seller category using the
+// schedule of the channel active on date
+func (e *Engine) CalculateFee(
+	ctx context.Context,
+	channel repository.OnlineChannel,
+	category string,
+	date time.Time,
+	amount decimal.Decimal,
+) (*Fee, error) {
+	schedule, err := e.ActiveSchedule(ctx, channel, date)
+	if err != nil {
+		return nil, err
+	}
+	return CalculateFee(schedule, category, amount)
+}
+
+// FindRate returns the rate of a seller category, categories are matched
+// case insensitively
+func FindRate(
+	schedule *repository.ChannelFeeScheduleData,
+	category string,
+) (*repository.ChannelFeeRateData, error) {
+	category = strings.TrimSpace(category)
+	for i := range schedule.Rates {
+		if strings.EqualFold(schedule.Rates[i].Category, category) {
+			return &schedule.Rates[i], nil
+		}
+	}
+	return nil, ErrCategoryNotFound
+}
+
+// CalculateFee returns the admin fee of the category plus the free delivery
+// program fee charged on amount, each capped when the schedule has a cap
+func CalculateFee(
+	schedule *repository.ChannelFeeScheduleData,
+	category string,
+	amount decimal.Decimal,
+) (*Fee, error) {
+	rate, err := FindRate(schedule, category)
+	if err != nil {
+		return nil, err
+	}
+	adminFee := applyPercentage(amount, rate.AdminFeePercentage, rate.AdminFeeCap)
+	freeDeliveryFee := applyPercentage(
+		amount, schedule.FreeDeliveryFeePercentage, schedule.FreeDeliveryFeeCap)
+	return &Fee{
+		AdminFee:        adminFee,
+		FreeDeliveryFee: freeDeliveryFee,
+		Total:           adminFee.Add(freeDeliveryFee),
+	}, nil
+}
+
+func applyPercentage(
+	amount, percentage decimal.Decimal,
+	limit decimal.NullDecimal,
+) decimal.Decimal {
+	fee := amount.Mul(percentage).Div(hundred)
+	if limit.Valid && fee.GreaterThan(limit.Decimal) {
+		fee = limit.Decimal
+	}
+	return fee.Round(2)
+}
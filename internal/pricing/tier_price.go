@@ -0,0 +1,106 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrCustomerInactive = errors.New("customer is not active")
+)
+
+// EffectivePrice is the unit price a customer pays for a quantity
+type EffectivePrice struct {
+	UnitPrice decimal.Decimal
+	// PriceTier and MinQuantity identify the tier price applied, both are
+	// empty when the variant selling price applies
+	PriceTier   repository.CustomerPriceTier
+	MinQuantity int
+}
+
+type TierPriceResolver struct {
+	customerRepository  repository.Customer
+	tierPriceRepository repository.VariantTierPrice
+}
+
+func NewTierPriceResolver(
+	customerRepository repository.Customer,
+	tierPriceRepository repository.VariantTierPrice,
+) *TierPriceResolver {
+	return &TierPriceResolver{
+		customerRepository:  customerRepository,
+		tierPriceRepository: tierPriceRepository,
+	}
+}
+
+// CustomerTier returns the price tier of an active customer, an empty
+// customer id is a walk-in customer paying retail prices
+func (r *TierPriceResolver) CustomerTier(
+	ctx context.Context,
+	customerID string,
+) (repository.CustomerPriceTier, error) {
+	if customerID == "" {
+		return repository.CustomerPriceTierRetail, nil
+	}
+	customer, err := r.customerRepository.FindByID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrCustomerNotFound
+		}
+		return "", err
+	}
+	if !customer.IsActive {
+		return "", ErrCustomerInactive
+	}
+	return customer.PriceTier, nil
+}
+
+// TierPrices maps variant id to its tier prices
+func (r *TierPriceResolver) TierPrices(
+	ctx context.Context,
+	variantIDs []string,
+) (map[string][]repository.VariantTierPriceData, error) {
+	prices, err := r.tierPriceRepository.FindByVariantIDs(ctx, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	pricesByVariantID := make(map[string][]repository.VariantTierPriceData)
+	for _, price := range prices {
+		pricesByVariantID[price.VariantID] = append(
+			pricesByVariantID[price.VariantID], price)
+	}
+	return pricesByVariantID, nil
+}
+
+// ResolveUnitPrice returns the lowest price among the selling price and the
+// tier prices reached by quantity. Retail prices apply to every tier, so a
+// customer never pays more than a walk-in customer buying the same quantity.
+func ResolveUnitPrice(
+	sellingPrice decimal.Decimal,
+	tier repository.CustomerPriceTier,
+	quantity int,
+	prices []repository.VariantTierPriceData,
+) EffectivePrice {
+	result := EffectivePrice{UnitPrice: sellingPrice}
+	for _, price := range prices {
+		if price.PriceTier != tier &&
+			price.PriceTier != repository.CustomerPriceTierRetail {
+			continue
+		}
+		if price.MinQuantity > quantity ||
+			!price.UnitPrice.LessThan(result.UnitPrice) {
+			continue
+		}
+		result = EffectivePrice{
+			UnitPrice:   price.UnitPrice,
+			PriceTier:   price.PriceTier,
+			MinQuantity: price.MinQuantity,
+		}
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+func TestResolveUnitPrice(t *testing.T) {
+	tierPrice := func(
+		tier repository.CustomerPriceTier,
+		minQuantity int,
+		unitPrice string,
+	) repository.VariantTierPriceData {
+		return repository.VariantTierPriceData{
+			PriceTier:   tier,
+			MinQuantity: minQuantity,
+			UnitPrice:   decimal.RequireFromString(unitPrice),
+		}
+	}
+	prices := []repository.VariantTierPriceData{
+		tierPrice(repository.CustomerPriceTierRetail, 12, "9000"),
+		tierPrice(repository.CustomerPriceTierReseller, 1, "9500"),
+		tierPrice(repository.CustomerPriceTierReseller, 24, "8000"),
+		tierPrice(repository.CustomerPriceTierWholesale, 1, "8500"),
+		tierPrice(repository.CustomerPriceTierWholesale, 100, "7000"),
+	}
+	tests := []struct {
+		name            string
+		tier            repository.CustomerPriceTier
+		quantity        int
+		prices          []repository.VariantTierPriceData
+		wantUnitPrice   string
+		wantPriceTier   repository.CustomerPriceTier
+		wantMinQuantity int
+	}{
+		{
+			name:          "no tier prices",
+			tier:          repository.CustomerPriceTierReseller,
+			quantity:      50,
+			wantUnitPrice: "10000",
+		},
+		{
+			name:          "retail below the quantity break",
+			tier:          repository.CustomerPriceTierRetail,
+			quantity:      11,
+			prices:        prices,
+			wantUnitPrice: "10000",
+		},
+		{
+			name:            "retail quantity break reached",
+			tier:            repository.CustomerPriceTierRetail,
+			quantity:        12,
+			prices:          prices,
+			wantUnitPrice:   "9000",
+			wantPriceTier:   repository.CustomerPriceTierRetail,
+			wantMinQuantity: 12,
+		},
+		{
+			name:            "tier price of the customer",
+			tier:            repository.CustomerPriceTierReseller,
+			quantity:        1,
+			prices:          prices,
+			wantUnitPrice:   "9500",
+			wantPriceTier:   repository.CustomerPriceTierReseller,
+			wantMinQuantity: 1,
+		},
+		{
+			name:            "retail break beats a dearer tier price",
+			tier:            repository.CustomerPriceTierReseller,
+			quantity:        12,
+			prices:          prices,
+			wantUnitPrice:   "9000",
+			wantPriceTier:   repository.CustomerPriceTierRetail,
+			wantMinQuantity: 12,
+		},
+		{
+			name:            "tier quantity break reached",
+			tier:            repository.CustomerPriceTierReseller,
+			quantity:        24,
+			prices:          prices,
+			wantUnitPrice:   "8000",
+			wantPriceTier:   repository.CustomerPriceTierReseller,
+			wantMinQuantity: 24,
+		},
+		{
+			name:            "prices of another tier are ignored",
+			tier:            repository.CustomerPriceTierWholesale,
+			quantity:        24,
+			prices:          prices,
+			wantUnitPrice:   "8500",
+			wantPriceTier:   repository.CustomerPriceTierWholesale,
+			wantMinQuantity: 1,
+		},
+		{
+			name:            "highest break reached",
+			tier:            repository.CustomerPriceTierWholesale,
+			quantity:        150,
+			prices:          prices,
+			wantUnitPrice:   "7000",
+			wantPriceTier:   repository.CustomerPriceTierWholesale,
+			wantMinQuantity: 100,
+		},
+		{
+			name:     "tier price above the selling price is not applied",
+			tier:     repository.CustomerPriceTierReseller,
+			quantity: 1,
+			prices: []repository.VariantTierPriceData{
+				tierPrice(repository.CustomerPriceTierReseller, 1, "10500"),
+			},
+			wantUnitPrice: "10000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveUnitPrice(
+				decimal.NewFromInt(10000), tt.tier, tt.quantity, tt.prices)
+			if want := decimal.RequireFromString(tt.wantUnitPrice); !got.UnitPrice.Equal(want) {
+				t.Errorf("unit price = %s, want %s", got.UnitPrice, want)
+			}
+			if got.PriceTier != tt.wantPriceTier || got.MinQuantity != tt.wantMinQuantity {
+				t.Errorf("applied tier = %q min %d, want %q min %d",
+					got.PriceTier, got.MinQuantity, tt.wantPriceTier, tt.wantMinQuantity)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package receipt
+
+import (
+	"bytes"
+	"unicode"
+)
+
+// ESC/POS commands understood by common 58mm and 80mm printers
+var (
+	escposInitialize  = []byte{0x1b, 0x40}
+	escposAlignLeft   = []byte{0x1b, 0x61, 0x00}
+	escposAlignCenter = []byte{0x1b, 0x61, 0x01}
+	escposBoldOn      = []byte{0x1b, 0x45, 0x01}
+	escposBoldOff     = []byte{0x1b, 0x45, 0x00}
+	escposSizeNormal  = []byte{0x1d, 0x21, 0x00}
+	escposSizeLarge   = []byte{0x1d, 0x21, 0x11}
+	escposFeedLines   = []byte{0x1b, 0x64, 0x04}
+	escposPartialCut  = []byte{0x1d, 0x56, 0x42, 0x00}
+)
+
+const (
+	escposLineFeed     = '\n'
+	escposReplacement  = '?'
+	escposMaxPrintable = 0x7e
+)
+
+// renderESCPOS writes the receipt as printer commands, the alignment is left
+// to the printer and the paper is cut at the end
+func renderESCPOS(lines []line) []byte {
+	var buffer bytes.Buffer
+	buffer.Write(escposInitialize)
+	for _, l := range lines {
+		if l.align == alignCenter {
+			buffer.Write(escposAlignCenter)
+		} else {
+			buffer.Write(escposAlignLeft)
+		}
+		if l.bold {
+			buffer.Write(escposBoldOn)
+		}
+		if l.large {
+			buffer.Write(escposSizeLarge)
+		}
+		writeESCPOSText(&buffer, l.text)
+		buffer.WriteByte(escposLineFeed)
+		if l.large {
+			buffer.Write(escposSizeNormal)
+		}
+		if l.bold {
+			buffer.Write(escposBoldOff)
+		}
+	}
+	buffer.Write(escposAlignLeft)
+	buffer.Write(escposFeedLines)
+	buffer.Write(escposPartialCut)
+	return buffer.Bytes()
+}
+
+// writeESCPOSText keeps printable ASCII only, the printer code page can not
+// be relied on for anything else
+func writeESCPOSText(buffer *bytes.Buffer, text string) {
+	for _, r := range text {
+		if r > escposMaxPrintable || !unicode.IsPrint(r) {
+			buffer.WriteByte(escposReplacement)
+			continue
+		}
+		buffer.WriteByte(byte(r))
+	}
+}
@@ -0,0 +1,153 @@
+package receipt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+)
+
+// line is one printed line of a receipt, text already fits the paper
+type line struct {
+	text  string
+	align align
+	bold  bool
+	// large prints double width and height, the text fits half the columns
+	large bool
+}
+
+type receiptBuilder struct {
+	columns int
+	lines   []line
+}
+
+func (b *receiptBuilder) add(l line) {
+	b.lines = append(b.lines, l)
+}
+
+// text adds the text wrapped to the paper
+func (b *receiptBuilder) text(text string, textAlign align, bold, large bool) {
+	columns := b.columns
+	if large {
+		columns /= 2
+	}
+	for _, wrapped := range wrap(text, columns) {
+		b.add(line{text: wrapped, align: textAlign, bold: bold, large: large})
+	}
+}
+
+func (b *receiptBuilder) separator() {
+	b.add(line{text: strings.Repeat("-", b.columns)})
+}
+
+// pair adds the label on the left and the value on the right
+func (b *receiptBuilder) pair(label, value string, bold bool) {
+	space := b.columns - utf8.RuneCountInString(value) - 1
+	labels := []string{label}
+	if utf8.RuneCountInString(label) > space {
+		labels = wrap(label, space)
+	}
+	for _, wrapped := range labels[:len(labels)-1] {
+		b.add(line{text: wrapped, bold: bold})
+	}
+	last := labels[len(labels)-1]
+	padding := b.columns - utf8.RuneCountInString(last) - utf8.RuneCountInString(value)
+	b.add(line{text: last + strings.Repeat(" ", padding) + value, bold: bold})
+}
+
+// buildReceiptLines lays the receipt out for the number of columns
+func buildReceiptLines(document *Document, columns int) []line {
+	shop := document.Shop
+	sale := document.Sale
+	b := &receiptBuilder{columns: columns}
+	b.text(shop.ShopName, alignCenter, true, true)
+	for _, text := range append(
+		[]string{shop.Address, shop.Phone}, splitLines(shop.ReceiptHeader)...) {
+		if text != "" {
+			b.text(text, alignCenter, false, false)
+		}
+	}
+	if sale.Status == repository.SaleStatusVoided {
+		b.text("*** VOID ***", alignCenter, true, true)
+	}
+	b.separator()
+	b.pair("No", sale.SaleNumber, false)
+	b.pair("Date", sale.SaleDate.Format("02-01-2006")+" "+
+		sale.CreatedAt.Format("15:04"), false)
+	b.pair("Cashier", sale.CreatedBy, false)
+	b.separator()
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		b.text(item.ProductName, alignLeft, false, false)
+		quantity := fmt.Sprintf("  %s  %d x %s",
+			formatSize(item), item.Quantity, formatMoney(item.UnitPrice))
+		amount := item.UnitPrice.Mul(decimal.NewFromInt(int64(item.Quantity)))
+		b.pair(quantity, formatMoney(amount), false)
+		if item.DiscountAmount.IsPositive() {
+			b.pair("  Discount", formatMoney(item.DiscountAmount.Neg()), false)
+		}
+	}
+	b.separator()
+	b.pair("Subtotal", formatMoney(sale.SubtotalAmount), false)
+	if sale.DiscountAmount.IsPositive() {
+		b.pair("Discount", formatMoney(sale.DiscountAmount.Neg()), false)
+	}
+	b.pair("TOTAL", formatMoney(sale.TotalAmount), true)
+	b.pair("Paid ("+string(sale.PaymentMethod)+")", formatMoney(sale.PaidAmount), false)
+	if sale.ChangeAmount.IsPositive() {
+		b.pair("Change", formatMoney(sale.ChangeAmount), false)
+	}
+	if sale.TotalRefundAmount.IsPositive() {
+		b.pair("Refunded", formatMoney(sale.TotalRefundAmount.Neg()), false)
+	}
+	if footer := splitLines(shop.ReceiptFooter); len(footer) > 0 {
+		b.separator()
+		for _, text := range footer {
+			b.text(text, alignCenter, false, false)
+		}
+	}
+	return b.lines
+}
+
+// wrap breaks text into lines of at most width characters, words longer
+// than the width are cut
+func wrap(text string, width int) []string {
+	if width <= 0 {
+		return []string{text}
+	}
+	lines := []string{}
+	current := ""
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+	return lines
+}
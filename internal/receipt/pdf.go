@@ -0,0 +1,143 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+// invoiceColumn is a column of the invoice item table, widths are in
+// millimeters and add up to the A4 width inside the margins
+type invoiceColumn struct {
+	title string
+	width float64
+	align string
+}
+
+var invoiceColumns = []invoiceColumn{
+	{"No", 10, "C"},
+	{"Item", 70, "L"},
+	{"Size", 22, "L"},
+	{"Qty", 14, "R"},
+	{"Unit Price", 26, "R"},
+	{"Discount", 22, "R"},
+	{"Amount", 26, "R"},
+}
+
+// renderInvoicePDF writes an A4 invoice of the sale
+func renderInvoicePDF(document *Document) ([]byte, error) {
+	shop := document.Shop
+	sale := document.Sale
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	// core fonts are cp1252, anything else has to be translated
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(110, 8, tr(shop.ShopName), "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 8, "INVOICE", "", 1, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	for _, text := range append(
+		[]string{shop.Address, shop.Phone}, splitLines(shop.ReceiptHeader)...) {
+		if text != "" {
+			pdf.MultiCell(110, 4.5, tr(text), "", "L", false)
+		}
+	}
+	pdf.Ln(4)
+
+	details := [][2]string{
+		{"Invoice No", sale.SaleNumber},
+		{"Date", sale.SaleDate.Format("02-01-2006")},
+		{"Payment", string(sale.PaymentMethod)},
+		{"Status", string(sale.Status)},
+	}
+	for _, detail := range details {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(28, 5, detail[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(0, 5, tr(detail[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for _, column := range invoiceColumns {
+		pdf.CellFormat(column.width, 7, column.title, "1", 0, column.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Helvetica", "", 9)
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		values := []string{
+			strconv.Itoa(i + 1),
+			item.ProductName,
+			formatSize(item),
+			strconv.Itoa(item.Quantity),
+			formatMoney(item.UnitPrice),
+			formatMoney(item.DiscountAmount),
+			formatMoney(lineAmount(item)),
+		}
+		for j, column := range invoiceColumns {
+			text := tr(values[j])
+			// long item names are shortened to keep one row per item
+			for pdf.GetStringWidth(text) > column.width-2 && len(text) > 0 {
+				text = text[:len(text)-1]
+			}
+			pdf.CellFormat(column.width, 6, text, "1", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.Ln(2)
+
+	totals := [][2]string{
+		{"Subtotal", formatMoney(sale.SubtotalAmount)},
+	}
+	if sale.DiscountAmount.IsPositive() {
+		totals = append(totals, [2]string{"Discount", formatMoney(sale.DiscountAmount.Neg())})
+	}
+	totals = append(totals,
+		[2]string{"Total", formatMoney(sale.TotalAmount)},
+		[2]string{"Paid", formatMoney(sale.PaidAmount)},
+	)
+	if sale.ChangeAmount.IsPositive() {
+		totals = append(totals, [2]string{"Change", formatMoney(sale.ChangeAmount)})
+	}
+	if sale.TotalRefundAmount.IsPositive() {
+		totals = append(totals, [2]string{"Refunded", formatMoney(sale.TotalRefundAmount.Neg())})
+	}
+	for _, total := range totals {
+		style := ""
+		if total[0] == "Total" {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 9)
+		pdf.CellFormat(140, 6, total[0], "", 0, "R", false, 0, "")
+		pdf.CellFormat(0, 6, total[1], "", 1, "R", false, 0, "")
+	}
+
+	if sale.Status == repository.SaleStatusVoided {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.SetTextColor(200, 0, 0)
+		pdf.CellFormat(0, 8, "VOID", "", 1, "C", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	if footer := splitLines(shop.InvoiceFooter); len(footer) > 0 {
+		pdf.Ln(6)
+		pdf.SetFont("Helvetica", "", 9)
+		for _, text := range footer {
+			pdf.MultiCell(0, 4.5, tr(text), "", "L", false)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, fmt.Errorf("render invoice: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,135 @@
+// Package receipt renders sales as thermal printer receipts and A4
+// invoices. The receipt layout is built once as lines and then written as
+// plain text or as an ESC/POS byte stream.
+package receipt
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+type Format string
+
+const (
+	FormatESCPOS Format = "escpos"
+	FormatPDF    Format = "pdf"
+	FormatText   Format = "txt"
+)
+
+// PaperWidth is the thermal paper width in millimeters
+type PaperWidth int
+
+const (
+	PaperWidth58 PaperWidth = 58
+	PaperWidth80 PaperWidth = 80
+)
+
+var (
+	ErrUnsupportedFormat     = errors.New("format must be one of escpos, pdf, txt")
+	ErrUnsupportedPaperWidth = errors.New("width must be one of 58, 80")
+)
+
+// Columns is the number of characters of font A that fit the paper
+func (w PaperWidth) Columns() int {
+	if w == PaperWidth80 {
+		return 48
+	}
+	return 32
+}
+
+// Document is everything printed for one sale
+type Document struct {
+	Shop *repository.ShopSettingData
+	Sale *repository.SaleData
+}
+
+// Rendered is a rendered document ready to be sent
+type Rendered struct {
+	ContentType string
+	FileName    string
+	Content     []byte
+}
+
+// Render renders the document, width is only used by the thermal formats
+func Render(format Format, width PaperWidth, document *Document) (*Rendered, error) {
+	if width != PaperWidth58 && width != PaperWidth80 {
+		return nil, ErrUnsupportedPaperWidth
+	}
+	name := strings.ToLower(document.Sale.SaleNumber)
+	switch format {
+	case FormatText:
+		return &Rendered{
+			ContentType: "text/plain; charset=utf-8",
+			FileName:    name + ".txt",
+			Content: renderText(
+				buildReceiptLines(document, width.Columns()), width.Columns()),
+		}, nil
+	case FormatESCPOS:
+		return &Rendered{
+			ContentType: "application/octet-stream",
+			FileName:    name + ".bin",
+			Content:     renderESCPOS(buildReceiptLines(document, width.Columns())),
+		}, nil
+	case FormatPDF:
+		content, err := renderInvoicePDF(document)
+		if err != nil {
+			return nil, err
+		}
+		return &Rendered{
+			ContentType: "application/pdf",
+			FileName:    name + ".pdf",
+			Content:     content,
+		}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// formatMoney formats an amount the Indonesian way, 12.500 or 12.500,50
+func formatMoney(amount decimal.Decimal) string {
+	sign := ""
+	if amount.IsNegative() {
+		sign = "-"
+		amount = amount.Neg()
+	}
+	fixed := amount.StringFixed(2)
+	integer, fraction, _ := strings.Cut(fixed, ".")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction != "00" {
+		return sign + grouped.String() + "," + fraction
+	}
+	return sign + grouped.String()
+}
+
+// formatSize formats the variant size, 250 ML or 0.5 KG
+func formatSize(item *repository.SaleItemData) string {
+	return strings.TrimSpace(
+		strconv.FormatFloat(float64(item.SizeValue), 'f', -1, 32) + " " + item.SizeUnitCode)
+}
+
+// splitLines splits free text into its non empty lines
+func splitLines(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// lineAmount is the item price after the item discount
+func lineAmount(item *repository.SaleItemData) decimal.Decimal {
+	return item.UnitPrice.
+		Mul(decimal.NewFromInt(int64(item.Quantity))).
+		Sub(item.DiscountAmount)
+}
@@ -0,0 +1,24 @@
+package receipt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// renderText writes the receipt as monospaced text. Text has no double
+// width, large lines are centered over the full width instead.
+func renderText(lines []line, columns int) []byte {
+	var builder strings.Builder
+	for _, l := range lines {
+		text := l.text
+		if l.align == alignCenter {
+			padding := (columns - utf8.RuneCountInString(text)) / 2
+			if padding > 0 {
+				text = strings.Repeat(" ", padding) + text
+			}
+		}
+		builder.WriteString(strings.TrimRight(text, " "))
+		builder.WriteString("\n")
+	}
+	return []byte(builder.String())
+}
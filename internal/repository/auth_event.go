@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type AuthEventType string
+
+const (
+	AuthEventTypeLoginSuccess    AuthEventType = "LOGIN_SUCCESS"
+	AuthEventTypeLoginFailure    AuthEventType = "LOGIN_FAILURE"
+	AuthEventTypeLoginLocked     AuthEventType = "LOGIN_LOCKED"
+	AuthEventTypeTokenRevoked    AuthEventType = "TOKEN_REVOKED"
+	AuthEventTypePasswordChanged AuthEventType = "PASSWORD_CHANGED"
+)
+
+// Reasons stored with an auth event
+const (
+	AuthEventReasonUserNotFound       = "USER_NOT_FOUND"
+	AuthEventReasonInvalidCredentials = "INVALID_CREDENTIALS"
+	AuthEventReasonLogout             = "LOGOUT"
+	AuthEventReasonLogoutAll          = "LOGOUT_ALL"
+	AuthEventReasonRefreshTokenReuse  = "REFRESH_TOKEN_REUSE"
+	AuthEventReasonRoleChanged        = "ROLE_CHANGED"
+	AuthEventReasonUserDisabled       = "USER_DISABLED"
+	AuthEventReasonPasswordReset      = "PASSWORD_RESET"
+	AuthEventReasonPasswordChanged    = "PASSWORD_CHANGED"
+)
+
+type AuthEventData struct {
+	ID        string
+	EventType AuthEventType
+	Username  sql.NullString
+	Actor     sql.NullString
+	SessionID sql.NullString
+	Reason    sql.NullString
+	IPAddress sql.NullString
+	UserAgent sql.NullString
+	CreatedAt time.Time
+}
+
+type AuthEventFilter struct {
+	Username  string
+	EventType string
+	IPAddress string
+	// StartDate and EndDate are inclusive dates in YYYY-MM-DD format
+	StartDate string
+	EndDate   string
+	Limit     int
+	Offset    int
+}
+
+type AuthEvent interface {
+	Insert(ctx context.Context, data *AuthEventData) error
+	FindPaginated(ctx context.Context, filter *AuthEventFilter) ([]AuthEventData, int, error)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type ChannelFeeScheduleData struct {
+	ID            string
+	Channel       OnlineChannel
+	Name          string
+	EffectiveFrom time.Time
+	// FreeDeliveryFeePercentage and FreeDeliveryFeeCap are the free delivery
+	// program fee, the cap is per order line when set
+	FreeDeliveryFeePercentage decimal.Decimal
+	FreeDeliveryFeeCap        decimal.NullDecimal
+	CreatedBy                 string
+	UpdatedBy                 string
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
+	Rates                     []ChannelFeeRateData
+}
+
+// ChannelFeeRateData is the admin fee of one seller category
+type ChannelFeeRateData struct {
+	ID                 string
+	Category           string
+	AdminFeePercentage decimal.Decimal
+	AdminFeeCap        decimal.NullDecimal
+}
+
+type ChannelFeeScheduleFilter struct {
+	Channel string
+	Limit   int
+	Offset  int
+}
+
+type ChannelFeeSchedule interface {
+	InsertTransaction(ctx context.Context, tx pgx.Tx, data *ChannelFeeScheduleData) error
+	// UpdateTransaction updates the schedule and replaces all of its rates
+	UpdateTransaction(ctx context.Context, tx pgx.Tx, data *ChannelFeeScheduleData) error
+	FindByID(ctx context.Context, scheduleID string) (*ChannelFeeScheduleData, error)
+	// FindActive returns the schedule of the channel with the latest
+	// effective date on or before date
+	FindActive(
+		ctx context.Context,
+		channel OnlineChannel,
+		date time.Time,
+	) (*ChannelFeeScheduleData, error)
+	// FindPaginated returns schedules with their rates, latest first
+	FindPaginated(
+		ctx context.Context,
+		filter *ChannelFeeScheduleFilter,
+	) ([]ChannelFeeScheduleData, int, error)
+	// FindByChannelForUpdate locks the non deleted schedules of the channel
+	// until the transaction ends, latest effective date first, without rates
+	FindByChannelForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		channel OnlineChannel,
+	) ([]ChannelFeeScheduleData, error)
+	SoftDeleteTransaction(ctx context.Context, tx pgx.Tx, scheduleID, deletedBy string) error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// CustomerPriceTier mirrors the customer_price_tier enum
+type CustomerPriceTier string
+
+const (
+	// CustomerPriceTierRetail applies to every customer and to walk-in sales
+	CustomerPriceTierRetail    CustomerPriceTier = "RETAIL"
+	CustomerPriceTierReseller  CustomerPriceTier = "RESELLER"
+	CustomerPriceTierWholesale CustomerPriceTier = "WHOLESALE"
+)
+
+type CustomerData struct {
+	ID        string
+	Name      string
+	Phone     sql.NullString
+	Address   sql.NullString
+	PriceTier CustomerPriceTier
+	Note      sql.NullString
+	IsActive  bool
+	CreatedBy string
+	UpdatedBy string
+	DeletedBy sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt sql.NullTime
+}
+
+type CustomerFilter struct {
+	// Search matches the customer name or phone
+	Search    string
+	PriceTier string
+	IsActive  string
+	Limit     int
+	Offset    int
+}
+
+type Customer interface {
+	Insert(ctx context.Context, data *CustomerData) error
+	Update(ctx context.Context, data *CustomerData) error
+	// FindByID returns a non deleted customer
+	FindByID(ctx context.Context, customerID string) (*CustomerData, error)
+	FindPaginated(ctx context.Context, filter *CustomerFilter) ([]CustomerData, int, error)
+	SoftDelete(ctx context.Context, customerID, deletedBy string) error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// LegacyProductData is a row of products_old
+type LegacyProductData struct {
+	ID                          string
+	Name                        string
+	CostPrice                   decimal.Decimal
+	GrossProfitPercentage       decimal.NullDecimal
+	VarianGrossProfitPercentage decimal.NullDecimal
+	ShopeeCategory              sql.NullString
+	ShopeeName                  sql.NullString
+	ShopeeVarianName            sql.NullString
+}
+
+// LegacyProductMappingData holds the ids behind the codes of a mapping
+type LegacyProductMappingData struct {
+	CategoryID        string
+	CategoryCode      string
+	PackagingTypeID   string
+	PackagingTypeCode string
+	SizeUnitID        string
+	SizeUnitCode      string
+}
+
+type LegacyProductMigrationData struct {
+	LegacyProductID string
+	ProductID       string
+	VariantID       string
+	ListingID       sql.NullString
+	MigratedBy      string
+}
+
+type LegacyProduct interface {
+	// FindAll returns the non deleted products_old rows ordered by name
+	FindAll(ctx context.Context) ([]LegacyProductData, error)
+	FindMigratedIDs(ctx context.Context) ([]string, error)
+	// FindMappingByCodes resolves active master data codes, it returns
+	// pgx.ErrNoRows when a code does not exist or the category rules do not
+	// allow the packaging type or size unit
+	FindMappingByCodes(
+		ctx context.Context,
+		tx pgx.Tx,
+		categoryCode, packagingTypeCode, sizeUnitCode string,
+	) (*LegacyProductMappingData, error)
+	InsertMigrationTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *LegacyProductMigrationData,
+	) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type LoginAttemptScope string
+
+const (
+	LoginAttemptScopeUsername LoginAttemptScope = "USERNAME"
+	LoginAttemptScopeIP       LoginAttemptScope = "IP"
+)
+
+type LoginAttempt interface {
+	// FindActiveLockout returns the latest locked_until of the username and
+	// the IP address, it is not valid when neither is locked
+	FindActiveLockout(ctx context.Context, username, ipAddress string) (sql.NullTime, error)
+	// RecordFailure increments the failed counter and returns the new count,
+	// the counter restarts when the previous failure is older than resetAfter
+	RecordFailure(
+		ctx context.Context,
+		scope LoginAttemptScope,
+		key string,
+		resetAfter time.Duration,
+	) (int, error)
+	Lock(ctx context.Context, scope LoginAttemptScope, key string, lockedUntil time.Time) error
+	Reset(ctx context.Context, scope LoginAttemptScope, key string) error
+}
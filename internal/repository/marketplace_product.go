@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// MarketplaceProductKey is how a marketplace names a product on an order line
+type MarketplaceProductKey struct {
+	ListingName   string
+	VariationName string
+}
+
+type MarketplaceProductData struct {
+	MarketplaceProductKey
+	// VariantID is empty when the match comes from the legacy products table
+	VariantID   sql.NullString
+	ProductName string
+	// CostPrice is invalid when the matched variant has no cost price yet
+	CostPrice decimal.NullDecimal
+}
+
+type MarketplaceProduct interface {
+	// FindByKeys matches marketplace names case insensitively to active
+	// variants, keys without a match are left out of the result
+	FindByKeys(
+		ctx context.Context,
+		tx pgx.Tx,
+		channel OnlineChannel,
+		keys []MarketplaceProductKey,
+	) ([]MarketplaceProductData, error)
+}
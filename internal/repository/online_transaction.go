@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// OnlineChannel mirrors the ONLINE_CHANNEL enum
+type OnlineChannel string
+
+const (
+	OnlineChannelShopee    OnlineChannel = "SHOPEE"
+	OnlineChannelLazada    OnlineChannel = "LAZADA"
+	OnlineChannelTokopedia OnlineChannel = "TOKOPEDIA"
+	OnlineChannelTiktok    OnlineChannel = "TIKTOK"
+)
+
+type OnlineTransactionData struct {
+	ID              string
+	Channel         OnlineChannel
+	OrderNumber     string
+	CreatedDate     time.Time
+	PeriodMonth     int
+	PeriodYear      int
+	TotalBaseAmount decimal.Decimal
+	TotalSaleAmount decimal.Decimal
+	TotalFeeAmount  decimal.Decimal
+	TotalNetProfit  decimal.Decimal
+	CreatedBy       string
+	DeletedBy       sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       sql.NullTime
+	DeletedAt       sql.NullTime
+	Products        []OnlineTransactionProductData
+}
+
+type OnlineTransactionProductData struct {
+	ID                  string
+	OnlineTransactionID string
+	VariantID           sql.NullString
+	ProductName         string
+	CostPrice           decimal.Decimal
+	SalePrice           decimal.Decimal
+	Quantity            int
+	// FeeAmount is the marketplace fee charged for the whole line
+	FeeAmount decimal.Decimal
+}
+
+type OnlineTransactionFilter struct {
+	Channel     string
+	OrderNumber string
+	// StartDate and EndDate are inclusive dates in YYYY-MM-DD format
+	StartDate string
+	EndDate   string
+	Limit     int
+	Offset    int
+}
+
+type OnlineTransaction interface {
+	// InsertTransaction inserts the transaction with its products
+	InsertTransaction(ctx context.Context, tx pgx.Tx, data *OnlineTransactionData) error
+	// LockOrderNumberTransaction serializes writers of the same order number
+	// until the transaction ends, the order row may not exist yet
+	LockOrderNumberTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		channel OnlineChannel,
+		orderNumber string,
+	) error
+	// FindIDByOrderNumber looks up a non deleted transaction of the channel
+	FindIDByOrderNumber(
+		ctx context.Context,
+		tx pgx.Tx,
+		channel OnlineChannel,
+		orderNumber string,
+	) (string, error)
+	// FindByID returns a non deleted transaction with its products
+	FindByID(ctx context.Context, transactionID string) (*OnlineTransactionData, error)
+	// FindPaginated returns non deleted transactions without products
+	FindPaginated(
+		ctx context.Context,
+		filter *OnlineTransactionFilter,
+	) ([]OnlineTransactionData, int, error)
+	SoftDelete(ctx context.Context, transactionID, deletedBy string) error
+}
@@ -0,0 +1,113 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type AuthEvent struct {
+	db *pgxpool.Pool
+}
+
+func NewAuthEvent(db *pgxpool.Pool) *AuthEvent {
+	return &AuthEvent{db: db}
+}
+
+const (
+	insertAuthEventQuery = `
+		INSERT INTO auth_events (
+			id,
+			event_type,
+			username,
+			actor,
+			session_id,
+			reason,
+			ip_address,
+			user_agent,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+	`
+	findPaginatedAuthEventsQuery = `
+		SELECT
+			id,
+			event_type,
+			username,
+			actor,
+			session_id,
+			reason,
+			ip_address,
+			user_agent,
+			created_at,
+			COUNT(*) OVER () AS total_count
+		FROM auth_events
+		WHERE ($1 = '' OR username = $1)
+		AND ($2 = '' OR event_type::text = $2)
+		AND ($3 = '' OR ip_address = $3)
+		AND ($4 = '' OR created_at >= NULLIF($4, '')::date)
+		AND ($5 = '' OR created_at < NULLIF($5, '')::date + 1)
+		ORDER BY created_at DESC
+		LIMIT $6 OFFSET $7
+	`
+)
+
+func (a *AuthEvent) Insert(ctx context.Context, data *repository.AuthEventData) error {
+	_, err := a.db.Exec(
+		ctx, insertAuthEventQuery,
+		data.ID,
+		data.EventType,
+		data.Username,
+		data.Actor,
+		data.SessionID,
+		data.Reason,
+		data.IPAddress,
+		data.UserAgent,
+	)
+	return err
+}
+func (a *AuthEvent) FindPaginated(
+	ctx context.Context,
+	filter *repository.AuthEventFilter,
+) ([]repository.AuthEventData, int, error) {
+	rows, err := a.db.Query(
+		ctx, findPaginatedAuthEventsQuery,
+		filter.Username,
+		filter.EventType,
+		filter.IPAddress,
+		filter.StartDate,
+		filter.EndDate,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	events := []repository.AuthEventData{}
+	var totalCount int
+	for rows.Next() {
+		var event repository.AuthEventData
+		var eventType string
+		if err := rows.Scan(
+			&event.ID,
+			&eventType,
+			&event.Username,
+			&event.Actor,
+			&event.SessionID,
+			&event.Reason,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.CreatedAt,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		event.EventType = repository.AuthEventType(eventType)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return events, totalCount, nil
+}
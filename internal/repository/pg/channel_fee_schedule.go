@@ -0,0 +1,375 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+var (
+	ErrFeeScheduleAlreadyExists = errors.New(
+		"fee schedule with the same channel and effective date already exists")
+)
+
+type ChannelFeeSchedule struct {
+	db *pgxpool.Pool
+}
+
+func NewChannelFeeSchedule(db *pgxpool.Pool) *ChannelFeeSchedule {
+	return &ChannelFeeSchedule{db: db}
+}
+
+const (
+	insertChannelFeeScheduleQuery = `
+		INSERT INTO channel_fee_schedules (
+			id,
+			channel,
+			name,
+			effective_from,
+			free_delivery_fee_percentage,
+			free_delivery_fee_cap,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+	`
+	updateChannelFeeScheduleQuery = `
+		UPDATE channel_fee_schedules
+		SET
+			channel = $2,
+			name = $3,
+			effective_from = $4,
+			free_delivery_fee_percentage = $5,
+			free_delivery_fee_cap = $6,
+			updated_by = $7,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	insertChannelFeeRateQuery = `
+		INSERT INTO channel_fee_schedule_rates (
+			id,
+			schedule_id,
+			category,
+			admin_fee_percentage,
+			admin_fee_cap
+		) VALUES ($1, $2, $3, $4, $5)
+	`
+	deleteChannelFeeRatesQuery = `
+		DELETE FROM channel_fee_schedule_rates
+		WHERE schedule_id = $1
+	`
+	selectChannelFeeScheduleColumns = `
+		SELECT
+			id,
+			channel,
+			name,
+			effective_from,
+			free_delivery_fee_percentage,
+			free_delivery_fee_cap,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+	`
+	findChannelFeeScheduleByIDQuery = selectChannelFeeScheduleColumns + `
+		FROM channel_fee_schedules
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	findActiveChannelFeeScheduleQuery = selectChannelFeeScheduleColumns + `
+		FROM channel_fee_schedules
+		WHERE channel::text = $1
+		AND effective_from <= $2::date
+		AND deleted_at IS NULL
+		ORDER BY effective_from DESC
+		LIMIT 1
+	`
+	findChannelFeeSchedulesByChannelForUpdateQuery = selectChannelFeeScheduleColumns + `
+		FROM channel_fee_schedules
+		WHERE channel::text = $1
+		AND deleted_at IS NULL
+		ORDER BY effective_from DESC
+		FOR UPDATE
+	`
+	findPaginatedChannelFeeSchedulesQuery = selectChannelFeeScheduleColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM channel_fee_schedules
+		WHERE deleted_at IS NULL
+		AND ($1 = '' OR channel::text = $1)
+		ORDER BY channel, effective_from DESC
+		LIMIT $2 OFFSET $3
+	`
+	findChannelFeeRatesByScheduleIDsQuery = `
+		SELECT
+			schedule_id,
+			id,
+			category,
+			admin_fee_percentage,
+			admin_fee_cap
+		FROM channel_fee_schedule_rates
+		WHERE schedule_id = ANY($1::uuid[])
+		ORDER BY category
+	`
+	softDeleteChannelFeeScheduleQuery = `
+		UPDATE channel_fee_schedules
+		SET
+			deleted_at = NOW(),
+			deleted_by = $2,
+			updated_by = $2,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+func (c *ChannelFeeSchedule) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.ChannelFeeScheduleData,
+) error {
+	_, err := tx.Exec(
+		ctx, insertChannelFeeScheduleQuery,
+		data.ID,
+		data.Channel,
+		data.Name,
+		data.EffectiveFrom,
+		data.FreeDeliveryFeePercentage,
+		data.FreeDeliveryFeeCap,
+		data.CreatedBy,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return handleFeeScheduleUniqueViolation(err)
+	}
+	return c.insertRates(ctx, tx, data)
+}
+func (c *ChannelFeeSchedule) UpdateTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.ChannelFeeScheduleData,
+) error {
+	result, err := tx.Exec(
+		ctx, updateChannelFeeScheduleQuery,
+		data.ID,
+		data.Channel,
+		data.Name,
+		data.EffectiveFrom,
+		data.FreeDeliveryFeePercentage,
+		data.FreeDeliveryFeeCap,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return handleFeeScheduleUniqueViolation(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	if _, err := tx.Exec(ctx, deleteChannelFeeRatesQuery, data.ID); err != nil {
+		return err
+	}
+	return c.insertRates(ctx, tx, data)
+}
+func (c *ChannelFeeSchedule) insertRates(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.ChannelFeeScheduleData,
+) error {
+	for _, rate := range data.Rates {
+		_, err := tx.Exec(
+			ctx, insertChannelFeeRateQuery,
+			rate.ID,
+			data.ID,
+			rate.Category,
+			rate.AdminFeePercentage,
+			rate.AdminFeeCap,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (c *ChannelFeeSchedule) FindByID(
+	ctx context.Context,
+	scheduleID string,
+) (*repository.ChannelFeeScheduleData, error) {
+	schedule, err := scanChannelFeeSchedule(
+		c.db.QueryRow(ctx, findChannelFeeScheduleByIDQuery, scheduleID), nil)
+	if err != nil {
+		return nil, err
+	}
+	schedules := []repository.ChannelFeeScheduleData{*schedule}
+	if err := c.fillRates(ctx, schedules); err != nil {
+		return nil, err
+	}
+	return &schedules[0], nil
+}
+func (c *ChannelFeeSchedule) FindActive(
+	ctx context.Context,
+	channel repository.OnlineChannel,
+	date time.Time,
+) (*repository.ChannelFeeScheduleData, error) {
+	schedule, err := scanChannelFeeSchedule(
+		c.db.QueryRow(ctx, findActiveChannelFeeScheduleQuery,
+			string(channel), date.Format(time.DateOnly)), nil)
+	if err != nil {
+		return nil, err
+	}
+	schedules := []repository.ChannelFeeScheduleData{*schedule}
+	if err := c.fillRates(ctx, schedules); err != nil {
+		return nil, err
+	}
+	return &schedules[0], nil
+}
+func (c *ChannelFeeSchedule) FindPaginated(
+	ctx context.Context,
+	filter *repository.ChannelFeeScheduleFilter,
+) ([]repository.ChannelFeeScheduleData, int, error) {
+	rows, err := c.db.Query(
+		ctx, findPaginatedChannelFeeSchedulesQuery,
+		filter.Channel,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	schedules := []repository.ChannelFeeScheduleData{}
+	var totalCount int
+	for rows.Next() {
+		schedule, err := scanChannelFeeSchedule(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		schedules = append(schedules, *schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	rows.Close()
+	if err := c.fillRates(ctx, schedules); err != nil {
+		return nil, 0, err
+	}
+	return schedules, totalCount, nil
+}
+func (c *ChannelFeeSchedule) FindByChannelForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel repository.OnlineChannel,
+) ([]repository.ChannelFeeScheduleData, error) {
+	rows, err := tx.Query(
+		ctx, findChannelFeeSchedulesByChannelForUpdateQuery, string(channel))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	schedules := []repository.ChannelFeeScheduleData{}
+	for rows.Next() {
+		schedule, err := scanChannelFeeSchedule(rows, nil)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+func (c *ChannelFeeSchedule) SoftDeleteTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	scheduleID, deletedBy string,
+) error {
+	result, err := tx.Exec(ctx, softDeleteChannelFeeScheduleQuery, scheduleID, deletedBy)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// fillRates loads the rates of every schedule with a single query
+func (c *ChannelFeeSchedule) fillRates(
+	ctx context.Context,
+	schedules []repository.ChannelFeeScheduleData,
+) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	scheduleIDs := make([]string, 0, len(schedules))
+	indexByID := make(map[string]int, len(schedules))
+	for i := range schedules {
+		schedules[i].Rates = []repository.ChannelFeeRateData{}
+		scheduleIDs = append(scheduleIDs, schedules[i].ID)
+		indexByID[schedules[i].ID] = i
+	}
+	rows, err := c.db.Query(ctx, findChannelFeeRatesByScheduleIDsQuery, scheduleIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var scheduleID string
+		var rate repository.ChannelFeeRateData
+		if err := rows.Scan(
+			&scheduleID,
+			&rate.ID,
+			&rate.Category,
+			&rate.AdminFeePercentage,
+			&rate.AdminFeeCap,
+		); err != nil {
+			return err
+		}
+		schedule := &schedules[indexByID[scheduleID]]
+		schedule.Rates = append(schedule.Rates, rate)
+	}
+	return rows.Err()
+}
+
+func handleFeeScheduleUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		pgErr.Code == constants.ErrCodePostgreUniqueViolation {
+		return ErrFeeScheduleAlreadyExists
+	}
+	return err
+}
+
+// scanChannelFeeSchedule scans the schedule columns, totalCount is scanned
+// as the last column when it is not nil
+func scanChannelFeeSchedule(
+	row pgx.Row,
+	totalCount *int,
+) (*repository.ChannelFeeScheduleData, error) {
+	var schedule repository.ChannelFeeScheduleData
+	var channel string
+	dest := []any{
+		&schedule.ID,
+		&channel,
+		&schedule.Name,
+		&schedule.EffectiveFrom,
+		&schedule.FreeDeliveryFeePercentage,
+		&schedule.FreeDeliveryFeeCap,
+		&schedule.CreatedBy,
+		&schedule.UpdatedBy,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	schedule.Channel = repository.OnlineChannel(channel)
+	return &schedule, nil
+}
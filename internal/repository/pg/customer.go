@@ -0,0 +1,233 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+var (
+	ErrCustomerAlreadyExists = errors.New("customer with the same phone already exists")
+)
+
+type Customer struct {
+	db *pgxpool.Pool
+}
+
+func NewCustomer(db *pgxpool.Pool) *Customer {
+	return &Customer{db: db}
+}
+
+const (
+	insertCustomerQuery = `
+		INSERT INTO customers (
+			id,
+			name,
+			phone,
+			address,
+			price_tier,
+			note,
+			is_active,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, NOW(), NOW())
+	`
+	updateCustomerQuery = `
+		UPDATE customers
+		SET
+			name = $2,
+			phone = $3,
+			address = $4,
+			price_tier = $5,
+			note = $6,
+			is_active = $7,
+			updated_by = $8,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	selectCustomerColumns = `
+		SELECT
+			id,
+			name,
+			phone,
+			address,
+			price_tier,
+			note,
+			is_active,
+			created_by,
+			updated_by,
+			deleted_by,
+			created_at,
+			updated_at,
+			deleted_at
+	`
+	findCustomerByIDQuery = selectCustomerColumns + `
+		FROM customers
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	findPaginatedCustomersQuery = selectCustomerColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM customers
+		WHERE deleted_at IS NULL
+		AND (
+			$1 = '' OR
+			name ILIKE '%' || $1 || '%' OR
+			phone ILIKE '%' || $1 || '%'
+		)
+		AND ($2 = '' OR price_tier::text = $2)
+		AND (
+			CASE
+				WHEN $3 = 'TRUE' THEN is_active = true
+				WHEN $3 = 'FALSE' THEN is_active = false
+				ELSE true
+			END
+		)
+		ORDER BY name, id
+		LIMIT $4 OFFSET $5
+	`
+	softDeleteCustomerQuery = `
+		UPDATE customers
+		SET
+			deleted_at = NOW(),
+			deleted_by = $2,
+			updated_by = $2,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+func (c *Customer) Insert(
+	ctx context.Context,
+	data *repository.CustomerData,
+) error {
+	_, err := c.db.Exec(
+		ctx, insertCustomerQuery,
+		data.ID,
+		data.Name,
+		data.Phone,
+		data.Address,
+		data.PriceTier,
+		data.Note,
+		data.IsActive,
+		data.CreatedBy,
+	)
+	return handleCustomerUniqueViolation(err)
+}
+func (c *Customer) Update(
+	ctx context.Context,
+	data *repository.CustomerData,
+) error {
+	result, err := c.db.Exec(
+		ctx, updateCustomerQuery,
+		data.ID,
+		data.Name,
+		data.Phone,
+		data.Address,
+		data.PriceTier,
+		data.Note,
+		data.IsActive,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return handleCustomerUniqueViolation(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (c *Customer) FindByID(
+	ctx context.Context,
+	customerID string,
+) (*repository.CustomerData, error) {
+	return scanCustomer(c.db.QueryRow(ctx, findCustomerByIDQuery, customerID), nil)
+}
+func (c *Customer) FindPaginated(
+	ctx context.Context,
+	filter *repository.CustomerFilter,
+) ([]repository.CustomerData, int, error) {
+	rows, err := c.db.Query(
+		ctx, findPaginatedCustomersQuery,
+		filter.Search,
+		filter.PriceTier,
+		filter.IsActive,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	customers := []repository.CustomerData{}
+	var totalCount int
+	for rows.Next() {
+		customer, err := scanCustomer(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		customers = append(customers, *customer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return customers, totalCount, nil
+}
+func (c *Customer) SoftDelete(
+	ctx context.Context,
+	customerID, deletedBy string,
+) error {
+	result, err := c.db.Exec(ctx, softDeleteCustomerQuery, customerID, deletedBy)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func handleCustomerUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		pgErr.Code == constants.ErrCodePostgreUniqueViolation {
+		return ErrCustomerAlreadyExists
+	}
+	return err
+}
+
+// scanCustomer scans the customer columns, totalCount is scanned as the last
+// column when it is not nil
+func scanCustomer(row pgx.Row, totalCount *int) (*repository.CustomerData, error) {
+	var customer repository.CustomerData
+	var priceTier string
+	dest := []any{
+		&customer.ID,
+		&customer.Name,
+		&customer.Phone,
+		&customer.Address,
+		&priceTier,
+		&customer.Note,
+		&customer.IsActive,
+		&customer.CreatedBy,
+		&customer.UpdatedBy,
+		&customer.DeletedBy,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+		&customer.DeletedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	customer.PriceTier = repository.CustomerPriceTier(priceTier)
+	return &customer, nil
+}
@@ -0,0 +1,160 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type LegacyProduct struct {
+	db *pgxpool.Pool
+}
+
+func NewLegacyProduct(db *pgxpool.Pool) *LegacyProduct {
+	return &LegacyProduct{db: db}
+}
+
+const (
+	findAllLegacyProductsQuery = `
+		SELECT
+			id,
+			name,
+			cost_price,
+			gross_profit_percentage,
+			varian_gross_profit_percentage,
+			shopee_category,
+			shopee_name,
+			shopee_varian_name
+		FROM products_old
+		WHERE deleted_at IS NULL
+		ORDER BY name
+	`
+	findMigratedLegacyProductIDsQuery = `
+		SELECT legacy_product_id
+		FROM legacy_product_migrations
+	`
+	findLegacyProductMappingByCodesQuery = `
+		SELECT
+			pc.id,
+			pc.code,
+			pt.id,
+			pt.code,
+			su.id,
+			su.code
+		FROM product_categories pc
+		JOIN product_categories_packaging_rules pr
+			ON pr.category_id = pc.id
+			AND pr.is_active = true
+		JOIN packaging_types pt
+			ON pt.id = pr.packaging_type_id
+			AND pt.is_active = true
+		JOIN product_categories_size_unit_rules sr
+			ON sr.category_id = pc.id
+			AND sr.is_active = true
+		JOIN size_units su
+			ON su.id = sr.size_unit_id
+			AND su.is_active = true
+		WHERE UPPER(pc.code) = UPPER($1)
+		AND UPPER(pt.code) = UPPER($2)
+		AND UPPER(su.code) = UPPER($3)
+		AND pc.is_active = true
+	`
+	insertLegacyProductMigrationQuery = `
+		INSERT INTO legacy_product_migrations (
+			legacy_product_id,
+			product_id,
+			variant_id,
+			listing_id,
+			migrated_by,
+			migrated_at
+		) VALUES ($1, $2, $3, $4, $5, NOW())
+	`
+)
+
+func (l *LegacyProduct) FindAll(
+	ctx context.Context,
+) ([]repository.LegacyProductData, error) {
+	rows, err := l.db.Query(ctx, findAllLegacyProductsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	products := []repository.LegacyProductData{}
+	for rows.Next() {
+		var product repository.LegacyProductData
+		if err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.CostPrice,
+			&product.GrossProfitPercentage,
+			&product.VarianGrossProfitPercentage,
+			&product.ShopeeCategory,
+			&product.ShopeeName,
+			&product.ShopeeVarianName,
+		); err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+func (l *LegacyProduct) FindMigratedIDs(ctx context.Context) ([]string, error) {
+	rows, err := l.db.Query(ctx, findMigratedLegacyProductIDsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+func (l *LegacyProduct) FindMappingByCodes(
+	ctx context.Context,
+	tx pgx.Tx,
+	categoryCode, packagingTypeCode, sizeUnitCode string,
+) (*repository.LegacyProductMappingData, error) {
+	var mapping repository.LegacyProductMappingData
+	if err := tx.QueryRow(
+		ctx, findLegacyProductMappingByCodesQuery,
+		categoryCode, packagingTypeCode, sizeUnitCode,
+	).Scan(
+		&mapping.CategoryID,
+		&mapping.CategoryCode,
+		&mapping.PackagingTypeID,
+		&mapping.PackagingTypeCode,
+		&mapping.SizeUnitID,
+		&mapping.SizeUnitCode,
+	); err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+func (l *LegacyProduct) InsertMigrationTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.LegacyProductMigrationData,
+) error {
+	_, err := tx.Exec(
+		ctx, insertLegacyProductMigrationQuery,
+		data.LegacyProductID,
+		data.ProductID,
+		data.VariantID,
+		data.ListingID,
+		data.MigratedBy,
+	)
+	return err
+}
@@ -0,0 +1,98 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type LoginAttempt struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginAttempt(db *pgxpool.Pool) *LoginAttempt {
+	return &LoginAttempt{db: db}
+}
+
+const (
+	findActiveLoginLockoutQuery = `
+		SELECT MAX(locked_until)
+		FROM login_attempts
+		WHERE (
+			(scope = 'USERNAME' AND attempt_key = $1) OR
+			(scope = 'IP' AND attempt_key = $2)
+		)
+		AND locked_until > NOW()
+	`
+	recordLoginFailureQuery = `
+		INSERT INTO login_attempts (
+			scope,
+			attempt_key,
+			failed_count,
+			last_failed_at
+		) VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (scope, attempt_key) DO UPDATE
+		SET
+			failed_count = CASE
+				WHEN login_attempts.last_failed_at < NOW() - make_interval(secs => $3)
+					THEN 1
+				ELSE login_attempts.failed_count + 1
+			END,
+			last_failed_at = NOW()
+		RETURNING failed_count
+	`
+	lockLoginAttemptQuery = `
+		UPDATE login_attempts
+		SET locked_until = $3
+		WHERE scope = $1 AND attempt_key = $2
+	`
+	resetLoginAttemptQuery = `
+		DELETE FROM login_attempts
+		WHERE scope = $1 AND attempt_key = $2
+	`
+)
+
+func (l *LoginAttempt) FindActiveLockout(
+	ctx context.Context,
+	username, ipAddress string,
+) (sql.NullTime, error) {
+	var lockedUntil sql.NullTime
+	err := l.db.QueryRow(ctx, findActiveLoginLockoutQuery, username, ipAddress).
+		Scan(&lockedUntil)
+	return lockedUntil, err
+}
+func (l *LoginAttempt) RecordFailure(
+	ctx context.Context,
+	scope repository.LoginAttemptScope,
+	key string,
+	resetAfter time.Duration,
+) (int, error) {
+	var failedCount int
+	err := l.db.QueryRow(
+		ctx, recordLoginFailureQuery,
+		scope,
+		key,
+		resetAfter.Seconds(),
+	).Scan(&failedCount)
+	return failedCount, err
+}
+func (l *LoginAttempt) Lock(
+	ctx context.Context,
+	scope repository.LoginAttemptScope,
+	key string,
+	lockedUntil time.Time,
+) error {
+	_, err := l.db.Exec(ctx, lockLoginAttemptQuery, scope, key, lockedUntil)
+	return err
+}
+func (l *LoginAttempt) Reset(
+	ctx context.Context,
+	scope repository.LoginAttemptScope,
+	key string,
+) error {
+	_, err := l.db.Exec(ctx, resetLoginAttemptQuery, scope, key)
+	return err
+}
@@ -0,0 +1,146 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type MarketplaceProduct struct {
+	db *pgxpool.Pool
+}
+
+func NewMarketplaceProduct(db *pgxpool.Pool) *MarketplaceProduct {
+	return &MarketplaceProduct{db: db}
+}
+
+const (
+	// findListedVariantsByKeysQuery matches the variant channel listings,
+	// they take precedence over the legacy products table
+	findListedVariantsByKeysQuery = `
+		SELECT DISTINCT ON (k.listing_name, k.variation_name)
+			k.listing_name,
+			k.variation_name,
+			v.id,
+			v.full_name,
+			v.cost_price
+		FROM unnest($2::text[], $3::text[]) AS k(listing_name, variation_name)
+		JOIN variant_channel_listings l
+			ON l.channel::text = $1
+			AND LOWER(TRIM(l.listing_name)) = LOWER(TRIM(k.listing_name))
+			AND LOWER(TRIM(COALESCE(l.variation_name, ''))) =
+				LOWER(TRIM(k.variation_name))
+		JOIN product_variants v
+			ON v.id = l.variant_id
+		WHERE l.deleted_at IS NULL
+		AND l.is_active = true
+		AND v.deleted_at IS NULL
+		AND v.is_active = true
+		ORDER BY k.listing_name, k.variation_name, l.updated_at DESC
+	`
+	// findLegacyShopeeProductsByKeysQuery matches the shopee names kept on
+	// the legacy products table
+	findLegacyShopeeProductsByKeysQuery = `
+		SELECT DISTINCT ON (k.listing_name, k.variation_name)
+			k.listing_name,
+			k.variation_name,
+			p.name,
+			p.cost_price
+		FROM unnest($1::text[], $2::text[]) AS k(listing_name, variation_name)
+		JOIN products_old p
+			ON LOWER(TRIM(p.shopee_name)) = LOWER(TRIM(k.listing_name))
+			AND LOWER(TRIM(COALESCE(p.shopee_varian_name, ''))) =
+				LOWER(TRIM(k.variation_name))
+		WHERE p.deleted_at IS NULL
+		ORDER BY k.listing_name, k.variation_name, p.created_at DESC
+	`
+)
+
+func (m *MarketplaceProduct) FindByKeys(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel repository.OnlineChannel,
+	keys []repository.MarketplaceProductKey,
+) ([]repository.MarketplaceProductData, error) {
+	products := []repository.MarketplaceProductData{}
+	if len(keys) == 0 {
+		return products, nil
+	}
+	listingNames, variationNames := splitMarketplaceProductKeys(keys)
+	rows, err := tx.Query(
+		ctx, findListedVariantsByKeysQuery,
+		string(channel), listingNames, variationNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	matched := make(map[repository.MarketplaceProductKey]bool)
+	for rows.Next() {
+		var product repository.MarketplaceProductData
+		if err := rows.Scan(
+			&product.ListingName,
+			&product.VariationName,
+			&product.VariantID,
+			&product.ProductName,
+			&product.CostPrice,
+		); err != nil {
+			return nil, err
+		}
+		matched[product.MarketplaceProductKey] = true
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if channel != repository.OnlineChannelShopee {
+		return products, nil
+	}
+	// Fall back to the legacy shopee names for keys without a listing
+	remaining := make([]repository.MarketplaceProductKey, 0, len(keys))
+	for _, key := range keys {
+		if !matched[key] {
+			remaining = append(remaining, key)
+		}
+	}
+	if len(remaining) == 0 {
+		return products, nil
+	}
+	listingNames, variationNames = splitMarketplaceProductKeys(remaining)
+	legacyRows, err := tx.Query(
+		ctx, findLegacyShopeeProductsByKeysQuery, listingNames, variationNames)
+	if err != nil {
+		return nil, err
+	}
+	defer legacyRows.Close()
+	for legacyRows.Next() {
+		var product repository.MarketplaceProductData
+		if err := legacyRows.Scan(
+			&product.ListingName,
+			&product.VariationName,
+			&product.ProductName,
+			&product.CostPrice,
+		); err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	if err := legacyRows.Err(); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func splitMarketplaceProductKeys(
+	keys []repository.MarketplaceProductKey,
+) ([]string, []string) {
+	listingNames := make([]string, 0, len(keys))
+	variationNames := make([]string, 0, len(keys))
+	for _, key := range keys {
+		listingNames = append(listingNames, key.ListingName)
+		variationNames = append(variationNames, key.VariationName)
+	}
+	return listingNames, variationNames
+}
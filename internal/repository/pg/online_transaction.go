@@ -0,0 +1,302 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type OnlineTransaction struct {
+	db *pgxpool.Pool
+}
+
+func NewOnlineTransaction(db *pgxpool.Pool) *OnlineTransaction {
+	return &OnlineTransaction{db: db}
+}
+
+const (
+	insertOnlineTransactionQuery = `
+		INSERT INTO online_transactions (
+			id,
+			type,
+			order_number,
+			created_date,
+			period_month,
+			period_year,
+			total_base_amount,
+			total_sale_amount,
+			total_fee_amount,
+			total_net_profit,
+			created_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+	`
+	insertOnlineTransactionProductQuery = `
+		INSERT INTO online_transaction_products (
+			id,
+			online_transaction_id,
+			variant_id,
+			product_name,
+			cost_price,
+			sale_price,
+			quantity,
+			fee_amount
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	lockOnlineTransactionOrderNumberQuery = `
+		SELECT pg_advisory_xact_lock(hashtext('online_transactions:' || $1::text || ':' || $2::text))
+	`
+	findOnlineTransactionIDByOrderNumberQuery = `
+		SELECT id
+		FROM online_transactions
+		WHERE type = $1 AND order_number = $2 AND deleted_at IS NULL
+		LIMIT 1
+	`
+	findOnlineTransactionByIDQuery = `
+		SELECT
+			id,
+			type,
+			order_number,
+			created_date,
+			period_month,
+			period_year,
+			total_base_amount,
+			total_sale_amount,
+			total_fee_amount,
+			total_net_profit,
+			created_by,
+			deleted_by,
+			created_at,
+			updated_at,
+			deleted_at
+		FROM online_transactions
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	findOnlineTransactionProductsQuery = `
+		SELECT
+			id,
+			online_transaction_id,
+			variant_id,
+			product_name,
+			cost_price,
+			sale_price,
+			quantity,
+			fee_amount
+		FROM online_transaction_products
+		WHERE online_transaction_id = $1
+		ORDER BY product_name
+	`
+	findPaginatedOnlineTransactionsQuery = `
+		SELECT
+			id,
+			type,
+			order_number,
+			created_date,
+			period_month,
+			period_year,
+			total_base_amount,
+			total_sale_amount,
+			total_fee_amount,
+			total_net_profit,
+			created_by,
+			deleted_by,
+			created_at,
+			updated_at,
+			deleted_at,
+			COUNT(*) OVER () AS total_count
+		FROM online_transactions
+		WHERE deleted_at IS NULL
+		AND ($1 = '' OR type::text = $1)
+		AND ($2 = '' OR order_number ILIKE '%' || $2 || '%')
+		AND ($3 = '' OR created_date >= NULLIF($3, '')::date)
+		AND ($4 = '' OR created_date <= NULLIF($4, '')::date)
+		ORDER BY created_date DESC, created_at DESC
+		LIMIT $5 OFFSET $6
+	`
+	softDeleteOnlineTransactionQuery = `
+		UPDATE online_transactions
+		SET deleted_at = NOW(), deleted_by = $2, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+func (o *OnlineTransaction) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.OnlineTransactionData,
+) error {
+	_, err := tx.Exec(
+		ctx, insertOnlineTransactionQuery,
+		data.ID,
+		data.Channel,
+		data.OrderNumber,
+		data.CreatedDate,
+		data.PeriodMonth,
+		data.PeriodYear,
+		data.TotalBaseAmount,
+		data.TotalSaleAmount,
+		data.TotalFeeAmount,
+		data.TotalNetProfit,
+		data.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	for _, product := range data.Products {
+		_, err := tx.Exec(
+			ctx, insertOnlineTransactionProductQuery,
+			product.ID,
+			data.ID,
+			product.VariantID,
+			product.ProductName,
+			product.CostPrice,
+			product.SalePrice,
+			product.Quantity,
+			product.FeeAmount,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (o *OnlineTransaction) LockOrderNumberTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel repository.OnlineChannel,
+	orderNumber string,
+) error {
+	_, err := tx.Exec(ctx, lockOnlineTransactionOrderNumberQuery,
+		string(channel), orderNumber)
+	return err
+}
+func (o *OnlineTransaction) FindIDByOrderNumber(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel repository.OnlineChannel,
+	orderNumber string,
+) (string, error) {
+	var transactionID string
+	err := tx.QueryRow(
+		ctx, findOnlineTransactionIDByOrderNumberQuery, channel, orderNumber,
+	).Scan(&transactionID)
+	return transactionID, err
+}
+func (o *OnlineTransaction) FindByID(
+	ctx context.Context,
+	transactionID string,
+) (*repository.OnlineTransactionData, error) {
+	transaction, err := scanOnlineTransaction(
+		o.db.QueryRow(ctx, findOnlineTransactionByIDQuery, transactionID), nil)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := o.db.Query(ctx, findOnlineTransactionProductsQuery, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	transaction.Products = []repository.OnlineTransactionProductData{}
+	for rows.Next() {
+		var product repository.OnlineTransactionProductData
+		if err := rows.Scan(
+			&product.ID,
+			&product.OnlineTransactionID,
+			&product.VariantID,
+			&product.ProductName,
+			&product.CostPrice,
+			&product.SalePrice,
+			&product.Quantity,
+			&product.FeeAmount,
+		); err != nil {
+			return nil, err
+		}
+		transaction.Products = append(transaction.Products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+func (o *OnlineTransaction) FindPaginated(
+	ctx context.Context,
+	filter *repository.OnlineTransactionFilter,
+) ([]repository.OnlineTransactionData, int, error) {
+	rows, err := o.db.Query(
+		ctx, findPaginatedOnlineTransactionsQuery,
+		filter.Channel,
+		filter.OrderNumber,
+		filter.StartDate,
+		filter.EndDate,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	transactions := []repository.OnlineTransactionData{}
+	var totalCount int
+	for rows.Next() {
+		transaction, err := scanOnlineTransaction(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		transactions = append(transactions, *transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return transactions, totalCount, nil
+}
+func (o *OnlineTransaction) SoftDelete(
+	ctx context.Context,
+	transactionID, deletedBy string,
+) error {
+	result, err := o.db.Exec(ctx, softDeleteOnlineTransactionQuery, transactionID, deletedBy)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// scanOnlineTransaction scans the transaction columns, totalCount is
+// scanned as the last column when it is not nil
+func scanOnlineTransaction(
+	row pgx.Row,
+	totalCount *int,
+) (*repository.OnlineTransactionData, error) {
+	var transaction repository.OnlineTransactionData
+	var channel string
+	dest := []any{
+		&transaction.ID,
+		&channel,
+		&transaction.OrderNumber,
+		&transaction.CreatedDate,
+		&transaction.PeriodMonth,
+		&transaction.PeriodYear,
+		&transaction.TotalBaseAmount,
+		&transaction.TotalSaleAmount,
+		&transaction.TotalFeeAmount,
+		&transaction.TotalNetProfit,
+		&transaction.CreatedBy,
+		&transaction.DeletedBy,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+		&transaction.DeletedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	transaction.Channel = repository.OnlineChannel(channel)
+	return &transaction, nil
+}
@@ -34,6 +34,164 @@ const (
 		SET base_name = $1, category_id = $2, updated_by = $3, updated_at = NOW()
 		WHERE id = $4
 	`
+	findProductByIDQuery = `
+		SELECT
+			p.id,
+			p.base_name,
+			p.type,
+			p.category_id,
+			pc.name,
+			pc.code,
+			p.created_by,
+			p.updated_by,
+			p.created_at,
+			p.updated_at
+		FROM products p
+		JOIN product_categories pc ON pc.id = p.category_id
+		WHERE p.id = $1
+		AND p.deleted_at IS NULL
+	`
+	// productsFilterFrom is the FROM and WHERE of the product list, shared by
+	// the page and its count
+	productsFilterFrom = `
+		FROM products p
+		JOIN product_categories pc ON pc.id = p.category_id
+		WHERE (
+			CASE
+				WHEN $5 THEN p.deleted_at IS NOT NULL
+				ELSE p.deleted_at IS NULL
+			END
+		)
+		AND ($1 = '' OR p.category_id::text = $1)
+		AND ($2 = '' OR p.type::text = $2)
+		AND (
+			$3 = '' OR
+			p.base_name ILIKE '%' || $3 || '%' OR
+			EXISTS (
+				SELECT 1
+				FROM product_variants pv
+				WHERE pv.product_id = p.id
+				AND pv.deleted_at IS NULL
+				AND (
+					pv.full_name ILIKE '%' || $3 || '%' OR
+					pv.sku ILIKE '%' || $3 || '%'
+				)
+			)
+		)
+		AND (
+			CASE
+				WHEN $4 = 'TRUE' THEN EXISTS (
+					SELECT 1
+					FROM product_variants pv
+					WHERE pv.product_id = p.id
+					AND pv.deleted_at IS NULL
+					AND pv.is_active = true
+				)
+				WHEN $4 = 'FALSE' THEN NOT EXISTS (
+					SELECT 1
+					FROM product_variants pv
+					WHERE pv.product_id = p.id
+					AND pv.deleted_at IS NULL
+					AND pv.is_active = true
+				)
+				ELSE TRUE
+			END
+		)
+	`
+	findPaginatedProductsQuery = `
+		SELECT
+			p.id,
+			p.base_name,
+			p.type,
+			p.category_id,
+			pc.name,
+			pc.code,
+			p.created_by,
+			p.updated_by,
+			p.created_at,
+			p.updated_at,
+			p.deleted_by,
+			p.deleted_at,
+			COUNT(*) OVER () AS total_count
+	` + productsFilterFrom + `
+		ORDER BY COALESCE(p.deleted_at, p.created_at) DESC
+		LIMIT $6 OFFSET $7
+	`
+	countProductsQuery = `
+		SELECT COUNT(*)
+	` + productsFilterFrom + `
+	`
+	streamProductVariantsQuery = `
+		SELECT
+			p.id,
+			p.base_name,
+			p.type,
+			p.category_id,
+			pc.name,
+			pc.code,
+			pv.id,
+			COALESCE(pv.sku, ''),
+			pv.variant_name,
+			pv.full_name,
+			pt.code,
+			pt.name,
+			pv.size_value,
+			su.code,
+			su.name,
+			pv.cost_price,
+			pv.selling_price,
+			pv.is_active,
+			pv.created_at,
+			pv.updated_at
+		FROM products p
+		JOIN product_categories pc ON pc.id = p.category_id
+		JOIN product_variants pv
+			ON pv.product_id = p.id
+			AND pv.deleted_at IS NULL
+		JOIN packaging_types pt ON pt.id = pv.packaging_type_id
+		JOIN size_units su ON su.id = pv.size_unit_id
+		WHERE p.deleted_at IS NULL
+		AND ($1 = '' OR p.category_id::text = $1)
+		AND ($2 = '' OR p.type::text = $2)
+		AND (
+			$3 = '' OR
+			p.base_name ILIKE '%' || $3 || '%' OR
+			pv.full_name ILIKE '%' || $3 || '%' OR
+			pv.sku ILIKE '%' || $3 || '%'
+		)
+		AND (
+			CASE
+				WHEN $4 = 'TRUE' THEN pv.is_active = true
+				WHEN $4 = 'FALSE' THEN pv.is_active = false
+				ELSE TRUE
+			END
+		)
+		ORDER BY p.created_at DESC, p.id, pv.created_at, pv.full_name
+	`
+	findProductByIDForUpdateQuery = `
+		SELECT
+			id,
+			base_name,
+			type,
+			category_id,
+			deleted_by,
+			deleted_at
+		FROM products
+		WHERE id = $1
+		FOR UPDATE
+	`
+	softDeleteProductQuery = `
+		UPDATE products
+		SET deleted_by = $2, deleted_at = $3, updated_by = $2, updated_at = NOW()
+		WHERE id = $1
+		AND deleted_at IS NULL
+	`
+	restoreProductQuery = `
+		UPDATE products
+		SET deleted_by = NULL, deleted_at = NULL, updated_by = $2, updated_at = NOW()
+		WHERE id = $1
+		AND deleted_at IS NOT NULL
+	`
 )
 
 func (p *Product) InsertTransaction(
@@ -72,3 +230,197 @@ func (p *Product) UpdateTransaction(
 	}
 	return nil
 }
+func (p *Product) FindByID(
+	ctx context.Context,
+	productID string,
+) (*repository.ProductData, error) {
+	var product repository.ProductData
+	var productType string
+	if err := p.db.QueryRow(ctx, findProductByIDQuery, productID).Scan(
+		&product.ID,
+		&product.BaseName,
+		&productType,
+		&product.CategoryID,
+		&product.CategoryName,
+		&product.CategoryCode,
+		&product.CreatedBy,
+		&product.UpdatedBy,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	product.ProductType = repository.ProductType(productType)
+	return &product, nil
+}
+func (p *Product) FindPaginated(
+	ctx context.Context,
+	filter *repository.ProductFilter,
+) ([]repository.ProductData, int, error) {
+	rows, err := p.db.Query(
+		ctx, findPaginatedProductsQuery,
+		filter.CategoryID,
+		filter.ProductType,
+		filter.Name,
+		filter.IsActive,
+		filter.Deleted,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	products := []repository.ProductData{}
+	var totalCount int
+	for rows.Next() {
+		var product repository.ProductData
+		var productType string
+		if err := rows.Scan(
+			&product.ID,
+			&product.BaseName,
+			&productType,
+			&product.CategoryID,
+			&product.CategoryName,
+			&product.CategoryCode,
+			&product.CreatedBy,
+			&product.UpdatedBy,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.DeletedBy,
+			&product.DeletedAt,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		product.ProductType = repository.ProductType(productType)
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(products) == 0 && filter.Offset > 0 {
+		// a page past the end has no row to carry the total
+		if err := p.db.QueryRow(
+			ctx, countProductsQuery,
+			filter.CategoryID,
+			filter.ProductType,
+			filter.Name,
+			filter.IsActive,
+			filter.Deleted,
+		).Scan(&totalCount); err != nil {
+			return nil, 0, err
+		}
+	}
+	return products, totalCount, nil
+}
+func (p *Product) StreamVariants(
+	ctx context.Context,
+	filter *repository.ProductFilter,
+	fn func(data *repository.ProductVariantData) error,
+) error {
+	rows, err := p.db.Query(
+		ctx, streamProductVariantsQuery,
+		filter.CategoryID,
+		filter.ProductType,
+		filter.Name,
+		filter.IsActive,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		product := repository.ProductData{}
+		variant := repository.ProductVariantData{Parent: &product}
+		var productType string
+		if err := rows.Scan(
+			&product.ID,
+			&product.BaseName,
+			&productType,
+			&product.CategoryID,
+			&product.CategoryName,
+			&product.CategoryCode,
+			&variant.ID,
+			&variant.SKU,
+			&variant.VariantName,
+			&variant.FullName,
+			&variant.PackagingTypeCode,
+			&variant.PackagingTypeName,
+			&variant.SizeValue,
+			&variant.SizeUnitCode,
+			&variant.SizeUnitName,
+			&variant.CostPrice,
+			&variant.SellingPrice,
+			&variant.IsActive,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+		); err != nil {
+			return err
+		}
+		product.ProductType = repository.ProductType(productType)
+		variant.ProductID = product.ID
+		variant.ProductName = product.BaseName
+		if err := fn(&variant); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+func (p *Product) FindByIDForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID string,
+) (*repository.ProductData, error) {
+	var product repository.ProductData
+	var productType string
+	if err := tx.QueryRow(ctx, findProductByIDForUpdateQuery, productID).Scan(
+		&product.ID,
+		&product.BaseName,
+		&productType,
+		&product.CategoryID,
+		&product.DeletedBy,
+		&product.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+	product.ProductType = repository.ProductType(productType)
+	return &product, nil
+}
+func (p *Product) SoftDeleteTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.ProductData,
+) error {
+	result, err := tx.Exec(
+		ctx, softDeleteProductQuery,
+		data.ID,
+		data.DeletedBy.String,
+		data.DeletedAt.Time,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (p *Product) RestoreTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.ProductData,
+) error {
+	result, err := tx.Exec(
+		ctx, restoreProductQuery,
+		data.ID,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type ProductErrorLog struct {
+	db *pgxpool.Pool
+}
+
+func NewProductErrorLog(db *pgxpool.Pool) *ProductErrorLog {
+	return &ProductErrorLog{db: db}
+}
+
+const (
+	insertProductErrorLogQuery = `
+		INSERT INTO product_error_logs (
+			id,
+			order_number,
+			created_date,
+			product_name,
+			error_message,
+			created_by,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`
+	resolveProductErrorLogsByOrderNumberQuery = `
+		UPDATE product_error_logs
+		SET deleted_at = NOW()
+		WHERE order_number = $1 AND deleted_at IS NULL
+	`
+)
+
+func (p *ProductErrorLog) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.ProductErrorLogData,
+) error {
+	_, err := tx.Exec(
+		ctx, insertProductErrorLogQuery,
+		data.ID,
+		data.OrderNumber,
+		data.CreatedDate,
+		data.ProductName,
+		data.ErrorMessage,
+		data.CreatedBy,
+	)
+	return err
+}
+func (p *ProductErrorLog) ResolveByOrderNumberTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	orderNumber string,
+) error {
+	_, err := tx.Exec(ctx, resolveProductErrorLogsByOrderNumberQuery, orderNumber)
+	return err
+}
@@ -2,12 +2,25 @@ package pg
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
 	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+	"github.com/shopspring/decimal"
 )
 
+// ErrSKUAlreadyExists is returned when a concurrent request saved the same SKU
+// first
+var ErrSKUAlreadyExists = errors.New("sku is already used by another variant")
+
+// skuUniqueIndex is the unique index on product_variants.sku
+const skuUniqueIndex = "idx_unique_product_variants_sku"
+
 type ProductVariant struct {
 	db *pgxpool.Pool
 }
@@ -19,16 +32,26 @@ func NewProductVariant(db *pgxpool.Pool) *ProductVariant {
 const (
 	findActiveProductVariantByIDQuery = `
 		SELECT 
-			id
+			id,
+			full_name,
+			cost_price,
+			selling_price
 		FROM product_variants
 		WHERE id = ANY($1)
 		AND is_active = true
 		AND deleted_at IS NULL
 	`
+	findProductVariantCostPricesByIDQuery = `
+		SELECT id, cost_price
+		FROM product_variants
+		WHERE id = ANY($1)
+		AND deleted_at IS NULL
+	`
 	insertProductVariantQuery = `
 		INSERT INTO product_variants (
 			id, 
 			product_id, 
+			sku,
 			product_name,
 			variant_name, 
 			full_name, 
@@ -56,12 +79,16 @@ const (
 			$11, 
 			$12, 
 			$13, 
+			$14,
 			NOW(), 
 			NOW()
 		)`
 	findProductVariantByProductIDQuery = `
 		SELECT 
 			pv.id,
+			COALESCE(pv.sku, ''),
+			pv.cost_price,
+			pv.selling_price,
 			p.type
 		FROM product_variants pv
 		JOIN products p ON p.id = pv.product_id
@@ -81,9 +108,145 @@ const (
 		product_name = $7,
 		full_name = $8,
 		variant_name = $9,
+		sku = $11,
 		updated_at = NOW()
 		WHERE id = $10
 	`
+	findProductVariantDetailByProductIDsQuery = `
+		SELECT
+			pv.id,
+			pv.product_id,
+			COALESCE(pv.sku, ''),
+			pv.product_name,
+			pv.variant_name,
+			pv.full_name,
+			pv.packaging_type_id,
+			pt.code,
+			pt.name,
+			pv.size_value,
+			pv.size_unit_id,
+			su.code,
+			su.name,
+			pv.cost_price,
+			pv.selling_price,
+			pv.is_active,
+			pv.created_by,
+			pv.updated_by,
+			pv.created_at,
+			pv.updated_at,
+			rr.id,
+			rr.parent_variant_id,
+			parent.full_name,
+			parent.cost_price,
+			rr.quantity_ratio,
+			rr.repack_cost_per_unit,
+			rr.repack_time_minutes
+		FROM product_variants pv
+		JOIN packaging_types pt ON pt.id = pv.packaging_type_id
+		JOIN size_units su ON su.id = pv.size_unit_id
+		LEFT JOIN product_repack_recipes rr
+			ON rr.child_variant_id = pv.id
+			AND rr.deleted_at IS NULL
+		LEFT JOIN product_variants parent ON parent.id = rr.parent_variant_id
+		WHERE pv.product_id = ANY($1::uuid[])
+		AND pv.deleted_at IS NULL
+		ORDER BY pv.created_at, pv.full_name
+	`
+	findSKUsByBaseQuery = `
+		SELECT sku
+		FROM product_variants
+		WHERE sku = $1 OR sku LIKE $1 || '-%'
+	`
+	findProductVariantByIDForUpdateQuery = `
+		SELECT
+			id,
+			product_id,
+			COALESCE(sku, ''),
+			full_name,
+			is_active,
+			deleted_by,
+			deleted_at
+		FROM product_variants
+		WHERE id = $1
+		FOR UPDATE
+	`
+	findProductVariantIDsByProductIDAndDeletedAtQuery = `
+		SELECT id
+		FROM product_variants
+		WHERE product_id = $1
+		AND (
+			CASE
+				WHEN $2::timestamptz IS NULL THEN deleted_at IS NULL
+				ELSE deleted_at = $2::timestamptz
+			END
+		)
+	`
+	updateProductVariantCostPriceQuery = `
+		UPDATE product_variants
+		SET cost_price = $2, updated_by = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+	updateProductVariantPricesQuery = `
+		UPDATE product_variants
+		SET
+			cost_price = COALESCE($2, cost_price),
+			selling_price = COALESCE($3, selling_price),
+			updated_by = $4,
+			updated_at = NOW()
+		WHERE id = $1
+		AND deleted_at IS NULL
+	`
+	findProductVariantPricesForUpdateQuery = `
+		SELECT cost_price, selling_price
+		FROM product_variants
+		WHERE id = $1
+		FOR UPDATE
+	`
+	// the history row is inserted only when the update changed a price, the
+	// old prices are read before the update
+	insertVariantPriceHistoryQuery = `
+		INSERT INTO variant_price_history (
+			variant_id,
+			old_cost_price,
+			new_cost_price,
+			old_selling_price,
+			new_selling_price,
+			source,
+			reason,
+			schedule_id,
+			changed_by,
+			changed_at
+		)
+		SELECT
+			id,
+			$2::numeric,
+			cost_price,
+			$3::numeric,
+			selling_price,
+			$4::variant_price_change_source,
+			$5::varchar,
+			$6::uuid,
+			$7::varchar,
+			NOW()
+		FROM product_variants
+		WHERE id = $1
+		AND (
+			cost_price IS DISTINCT FROM $2::numeric OR
+			selling_price IS DISTINCT FROM $3::numeric
+		)
+	`
+	softDeleteProductVariantQuery = `
+		UPDATE product_variants
+		SET deleted_by = $2, deleted_at = $3, updated_by = $2, updated_at = NOW()
+		WHERE id = ANY($1::uuid[])
+		AND deleted_at IS NULL
+	`
+	restoreProductVariantQuery = `
+		UPDATE product_variants
+		SET deleted_by = NULL, deleted_at = NULL, updated_by = $2, updated_at = NOW()
+		WHERE id = ANY($1::uuid[])
+		AND deleted_at IS NOT NULL
+	`
 )
 
 func (p *ProductVariant) FindManyByID(
@@ -103,7 +266,12 @@ func (p *ProductVariant) FindManyByID(
 	variants := []repository.ProductVariantData{}
 	for rows.Next() {
 		var variant repository.ProductVariantData
-		if err := rows.Scan(&variant.ID); err != nil {
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.FullName,
+			&variant.CostPrice,
+			&variant.SellingPrice,
+		); err != nil {
 			return nil, err
 		}
 		variants = append(variants, variant)
@@ -114,6 +282,29 @@ func (p *ProductVariant) FindManyByID(
 	return variants, nil
 }
 
+func (p *ProductVariant) FindCostPricesByID(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+) ([]repository.ProductVariantData, error) {
+	rows, err := tx.Query(ctx, findProductVariantCostPricesByIDQuery, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	variants := []repository.ProductVariantData{}
+	for rows.Next() {
+		var variant repository.ProductVariantData
+		if err := rows.Scan(&variant.ID, &variant.CostPrice); err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
 func (p *ProductVariant) InsertTransaction(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -123,6 +314,7 @@ func (p *ProductVariant) InsertTransaction(
 		ctx, insertProductVariantQuery,
 		data.ID,
 		data.ProductID,
+		data.SKU,
 		data.ProductName,
 		data.VariantName,
 		data.FullName,
@@ -136,7 +328,7 @@ func (p *ProductVariant) InsertTransaction(
 		data.UpdatedBy,
 	)
 	if err != nil {
-		return err
+		return handleSKUUniqueViolation(err)
 	}
 	return nil
 }
@@ -160,6 +352,9 @@ func (p *ProductVariant) FindByProductID(
 		var productType string
 		if err := rows.Scan(
 			&variant.ID,
+			&variant.SKU,
+			&variant.CostPrice,
+			&variant.SellingPrice,
 			&productType,
 		); err != nil {
 			return nil, err
@@ -178,8 +373,14 @@ func (p *ProductVariant) UpdateVariantForProductTypeSingleTransaction(
 	ctx context.Context,
 	tx pgx.Tx,
 	data *repository.ProductVariantData,
+	change *repository.PriceChange,
 ) error {
-	_, err := tx.Exec(
+	oldCostPrice, oldSellingPrice, err := findVariantPricesForUpdate(
+		ctx, tx, data.ID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
 		ctx, updateVariantForProductTypeSingleQuery,
 		data.PackagingTypeID,
 		data.SizeValue,
@@ -191,9 +392,302 @@ func (p *ProductVariant) UpdateVariantForProductTypeSingleTransaction(
 		data.FullName,
 		data.VariantName,
 		data.ID,
+		data.SKU,
+	)
+	if err != nil {
+		return handleSKUUniqueViolation(err)
+	}
+	return insertVariantPriceHistory(
+		ctx, tx, data.ID, oldCostPrice, oldSellingPrice, data.UpdatedBy, change)
+}
+func (p *ProductVariant) FindDetailByProductIDs(
+	ctx context.Context,
+	productIDs []string,
+) ([]repository.ProductVariantData, error) {
+	rows, err := p.db.Query(
+		ctx,
+		findProductVariantDetailByProductIDsQuery,
+		productIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	variants := []repository.ProductVariantData{}
+	for rows.Next() {
+		var variant repository.ProductVariantData
+		var recipeID sql.NullString
+		var parentVariantID sql.NullString
+		var parentFullName sql.NullString
+		var parentCostPrice decimal.NullDecimal
+		var quantityRatio sql.NullFloat64
+		var repackCostPerUnit decimal.NullDecimal
+		var repackTimeMinutes sql.NullInt32
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.ProductID,
+			&variant.SKU,
+			&variant.ProductName,
+			&variant.VariantName,
+			&variant.FullName,
+			&variant.PackagingTypeID,
+			&variant.PackagingTypeCode,
+			&variant.PackagingTypeName,
+			&variant.SizeValue,
+			&variant.SizeUnitID,
+			&variant.SizeUnitCode,
+			&variant.SizeUnitName,
+			&variant.CostPrice,
+			&variant.SellingPrice,
+			&variant.IsActive,
+			&variant.CreatedBy,
+			&variant.UpdatedBy,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+			&recipeID,
+			&parentVariantID,
+			&parentFullName,
+			&parentCostPrice,
+			&quantityRatio,
+			&repackCostPerUnit,
+			&repackTimeMinutes,
+		); err != nil {
+			return nil, err
+		}
+		if recipeID.Valid {
+			variant.RepackRecipe = &repository.RepackRecipeData{
+				ID:                recipeID.String,
+				ParentVariantID:   parentVariantID.String,
+				ParentFullName:    parentFullName.String,
+				ParentCostPrice:   parentCostPrice,
+				ChildVariantID:    variant.ID,
+				QuantityRatio:     float32(quantityRatio.Float64),
+				RepackCostPerUnit: repackCostPerUnit.Decimal,
+				RepackTimeMinutes: int(repackTimeMinutes.Int32),
+			}
+		}
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+func (p *ProductVariant) FindSKUsByBase(
+	ctx context.Context,
+	tx pgx.Tx,
+	skuBase string,
+) ([]string, error) {
+	rows, err := tx.Query(ctx, findSKUsByBaseQuery, skuBase)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	skus := []string{}
+	for rows.Next() {
+		var sku string
+		if err := rows.Scan(&sku); err != nil {
+			return nil, err
+		}
+		skus = append(skus, sku)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return skus, nil
+}
+func (p *ProductVariant) FindByIDForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+) (*repository.ProductVariantData, error) {
+	var variant repository.ProductVariantData
+	if err := tx.QueryRow(ctx, findProductVariantByIDForUpdateQuery, variantID).Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.SKU,
+		&variant.FullName,
+		&variant.IsActive,
+		&variant.DeletedBy,
+		&variant.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+func (p *ProductVariant) FindIDsByProductIDAndDeletedAt(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID string,
+	deletedAt sql.NullTime,
+) ([]string, error) {
+	rows, err := tx.Query(
+		ctx,
+		findProductVariantIDsByProductIDAndDeletedAtQuery,
+		productID,
+		deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	variantIDs := []string{}
+	for rows.Next() {
+		var variantID string
+		if err := rows.Scan(&variantID); err != nil {
+			return nil, err
+		}
+		variantIDs = append(variantIDs, variantID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return variantIDs, nil
+}
+func (p *ProductVariant) SoftDeleteTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+	deletedBy string,
+	deletedAt time.Time,
+) error {
+	_, err := tx.Exec(
+		ctx, softDeleteProductVariantQuery,
+		variantIDs,
+		deletedBy,
+		deletedAt,
 	)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+func (p *ProductVariant) RestoreTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+	updatedBy string,
+) error {
+	_, err := tx.Exec(
+		ctx, restoreProductVariantQuery,
+		variantIDs,
+		updatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (p *ProductVariant) UpdateCostPriceTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+	costPrice decimal.NullDecimal,
+	updatedBy string,
+	change *repository.PriceChange,
+) error {
+	oldCostPrice, oldSellingPrice, err := findVariantPricesForUpdate(
+		ctx, tx, variantID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		ctx, updateProductVariantCostPriceQuery,
+		variantID,
+		costPrice,
+		updatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return insertVariantPriceHistory(
+		ctx, tx, variantID, oldCostPrice, oldSellingPrice, updatedBy, change)
+}
+func (p *ProductVariant) FindPricesForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+) (decimal.NullDecimal, decimal.Decimal, error) {
+	return findVariantPricesForUpdate(ctx, tx, variantID)
+}
+func (p *ProductVariant) UpdatePricesTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+	costPrice decimal.NullDecimal,
+	sellingPrice decimal.NullDecimal,
+	updatedBy string,
+	change *repository.PriceChange,
+) error {
+	oldCostPrice, oldSellingPrice, err := findVariantPricesForUpdate(
+		ctx, tx, variantID)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(
+		ctx, updateProductVariantPricesQuery,
+		variantID,
+		costPrice,
+		sellingPrice,
+		updatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return insertVariantPriceHistory(
+		ctx, tx, variantID, oldCostPrice, oldSellingPrice, updatedBy, change)
+}
+
+// findVariantPricesForUpdate locks the variant row so the old prices stay
+// the same until the price history is written
+func findVariantPricesForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+) (decimal.NullDecimal, decimal.Decimal, error) {
+	var costPrice decimal.NullDecimal
+	var sellingPrice decimal.Decimal
+	if err := tx.QueryRow(
+		ctx, findProductVariantPricesForUpdateQuery, variantID,
+	).Scan(&costPrice, &sellingPrice); err != nil {
+		return decimal.NullDecimal{}, decimal.Decimal{}, err
+	}
+	return costPrice, sellingPrice, nil
+}
+func insertVariantPriceHistory(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+	oldCostPrice decimal.NullDecimal,
+	oldSellingPrice decimal.Decimal,
+	changedBy string,
+	change *repository.PriceChange,
+) error {
+	_, err := tx.Exec(
+		ctx, insertVariantPriceHistoryQuery,
+		variantID,
+		oldCostPrice,
+		oldSellingPrice,
+		change.Source,
+		change.Reason,
+		change.ScheduleID,
+		changedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func handleSKUUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		pgErr.Code == constants.ErrCodePostgreUniqueViolation &&
+		pgErr.ConstraintName == skuUniqueIndex {
+		return ErrSKUAlreadyExists
+	}
+	return err
+}
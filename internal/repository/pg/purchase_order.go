@@ -0,0 +1,526 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type PurchaseOrder struct {
+	db *pgxpool.Pool
+}
+
+func NewPurchaseOrder(db *pgxpool.Pool) *PurchaseOrder {
+	return &PurchaseOrder{db: db}
+}
+
+const (
+	insertPurchaseOrderQuery = `
+		INSERT INTO purchase_orders (
+			id,
+			po_number,
+			supplier_id,
+			order_date,
+			expected_date,
+			status,
+			payment_term_days,
+			total_amount,
+			note,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES (
+			$1,
+			'PO-' || TO_CHAR($3::date, 'YYYYMMDD') || '-' ||
+				LPAD(nextval('purchase_order_number_seq')::text, 6, '0'),
+			$2, $3, $4, $5, $6, $7, $8, $9, $9, NOW(), NOW()
+		)
+		RETURNING po_number, created_at, updated_at
+	`
+	insertPurchaseOrderItemQuery = `
+		INSERT INTO purchase_order_items (
+			id,
+			purchase_order_id,
+			variant_id,
+			product_name,
+			quantity,
+			unit_cost,
+			received_quantity
+		) VALUES ($1, $2, $3, $4, $5, $6, 0)
+	`
+	updatePurchaseOrderQuery = `
+		UPDATE purchase_orders
+		SET
+			supplier_id = $2,
+			order_date = $3,
+			expected_date = $4,
+			payment_term_days = $5,
+			total_amount = $6,
+			note = $7,
+			updated_by = $8,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	deletePurchaseOrderItemsQuery = `
+		DELETE FROM purchase_order_items
+		WHERE purchase_order_id = $1
+	`
+	updatePurchaseOrderStatusQuery = `
+		UPDATE purchase_orders
+		SET
+			status = $2,
+			sent_at = $3,
+			closed_at = $4,
+			updated_by = $5,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	purchaseOrderColumns = `
+			po.id,
+			po.po_number,
+			po.supplier_id,
+			s.name,
+			po.order_date,
+			po.expected_date,
+			po.status,
+			po.payment_term_days,
+			po.total_amount,
+			po.note,
+			po.sent_at,
+			po.closed_at,
+			po.created_by,
+			po.updated_by,
+			po.created_at,
+			po.updated_at
+	`
+	findPurchaseOrderByIDQuery = `
+		SELECT ` + purchaseOrderColumns + `
+		FROM purchase_orders po
+		JOIN suppliers s ON s.id = po.supplier_id
+		WHERE po.id = $1
+	`
+	findPurchaseOrderByIDForUpdateQuery = findPurchaseOrderByIDQuery + `
+		FOR UPDATE OF po
+	`
+	findPaginatedPurchaseOrdersQuery = `
+		SELECT ` + purchaseOrderColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM purchase_orders po
+		JOIN suppliers s ON s.id = po.supplier_id
+		WHERE ($1 = '' OR po.po_number ILIKE '%' || $1 || '%')
+		AND ($2 = '' OR po.supplier_id::text = $2)
+		AND ($3 = '' OR po.status::text = $3)
+		AND ($4 = '' OR po.order_date >= NULLIF($4, '')::date)
+		AND ($5 = '' OR po.order_date <= NULLIF($5, '')::date)
+		ORDER BY po.order_date DESC, po.created_at DESC
+		LIMIT $6 OFFSET $7
+	`
+	findPurchaseOrderItemsQuery = `
+		SELECT
+			id,
+			purchase_order_id,
+			variant_id,
+			product_name,
+			quantity,
+			unit_cost,
+			received_quantity
+		FROM purchase_order_items
+		WHERE purchase_order_id = $1
+		ORDER BY product_name, id
+	`
+	insertGoodsReceiptQuery = `
+		INSERT INTO goods_receipts (
+			id,
+			purchase_order_id,
+			receipt_date,
+			cost_policy,
+			note,
+			created_by,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING created_at
+	`
+	insertGoodsReceiptItemQuery = `
+		INSERT INTO goods_receipt_items (
+			id,
+			receipt_id,
+			purchase_order_item_id,
+			quantity,
+			unit_cost,
+			previous_cost_price,
+			new_cost_price
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	addPurchaseOrderItemReceivedQuantityQuery = `
+		UPDATE purchase_order_items
+		SET received_quantity = received_quantity + $2
+		WHERE id = $1
+		AND received_quantity + $2 <= quantity
+	`
+	findGoodsReceiptsQuery = `
+		SELECT
+			id,
+			purchase_order_id,
+			receipt_date,
+			cost_policy,
+			note,
+			created_by,
+			created_at
+		FROM goods_receipts
+		WHERE purchase_order_id = $1
+		ORDER BY created_at, id
+	`
+	findGoodsReceiptItemsQuery = `
+		SELECT
+			gri.id,
+			gri.receipt_id,
+			gri.purchase_order_item_id,
+			poi.variant_id,
+			poi.product_name,
+			gri.quantity,
+			gri.unit_cost,
+			gri.previous_cost_price,
+			gri.new_cost_price
+		FROM goods_receipt_items gri
+		JOIN goods_receipts gr ON gr.id = gri.receipt_id
+		JOIN purchase_order_items poi ON poi.id = gri.purchase_order_item_id
+		WHERE gr.purchase_order_id = $1
+		ORDER BY poi.product_name, gri.id
+	`
+)
+
+func (p *PurchaseOrder) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.PurchaseOrderData,
+) error {
+	if err := tx.QueryRow(
+		ctx, insertPurchaseOrderQuery,
+		data.ID,
+		data.SupplierID,
+		data.OrderDate,
+		data.ExpectedDate,
+		data.Status,
+		data.PaymentTermDays,
+		data.TotalAmount,
+		data.Note,
+		data.CreatedBy,
+	).Scan(&data.PONumber, &data.CreatedAt, &data.UpdatedAt); err != nil {
+		return err
+	}
+	data.UpdatedBy = data.CreatedBy
+	return insertPurchaseOrderItems(ctx, tx, data)
+}
+func (p *PurchaseOrder) UpdateTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.PurchaseOrderData,
+) error {
+	result, err := tx.Exec(
+		ctx, updatePurchaseOrderQuery,
+		data.ID,
+		data.SupplierID,
+		data.OrderDate,
+		data.ExpectedDate,
+		data.PaymentTermDays,
+		data.TotalAmount,
+		data.Note,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	if _, err := tx.Exec(ctx, deletePurchaseOrderItemsQuery, data.ID); err != nil {
+		return err
+	}
+	return insertPurchaseOrderItems(ctx, tx, data)
+}
+func (p *PurchaseOrder) UpdateStatusTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.PurchaseOrderData,
+) error {
+	result, err := tx.Exec(
+		ctx, updatePurchaseOrderStatusQuery,
+		data.ID,
+		data.Status,
+		data.SentAt,
+		data.ClosedAt,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (p *PurchaseOrder) FindByID(
+	ctx context.Context,
+	purchaseOrderID string,
+) (*repository.PurchaseOrderData, error) {
+	order, err := scanPurchaseOrder(
+		p.db.QueryRow(ctx, findPurchaseOrderByIDQuery, purchaseOrderID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if order.Items, err = findPurchaseOrderItems(ctx, p.db, purchaseOrderID); err != nil {
+		return nil, err
+	}
+	if order.Receipts, err = p.findReceipts(ctx, purchaseOrderID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+func (p *PurchaseOrder) FindByIDForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	purchaseOrderID string,
+) (*repository.PurchaseOrderData, error) {
+	order, err := scanPurchaseOrder(
+		tx.QueryRow(ctx, findPurchaseOrderByIDForUpdateQuery, purchaseOrderID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if order.Items, err = findPurchaseOrderItems(ctx, tx, purchaseOrderID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+func (p *PurchaseOrder) FindPaginated(
+	ctx context.Context,
+	filter *repository.PurchaseOrderFilter,
+) ([]repository.PurchaseOrderData, int, error) {
+	rows, err := p.db.Query(
+		ctx, findPaginatedPurchaseOrdersQuery,
+		filter.PONumber,
+		filter.SupplierID,
+		filter.Status,
+		filter.StartDate,
+		filter.EndDate,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	orders := []repository.PurchaseOrderData{}
+	var totalCount int
+	for rows.Next() {
+		order, err := scanPurchaseOrder(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, *order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return orders, totalCount, nil
+}
+func (p *PurchaseOrder) InsertReceiptTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.GoodsReceiptData,
+) error {
+	if err := tx.QueryRow(
+		ctx, insertGoodsReceiptQuery,
+		data.ID,
+		data.PurchaseOrderID,
+		data.ReceiptDate,
+		data.CostPolicy,
+		data.Note,
+		data.CreatedBy,
+	).Scan(&data.CreatedAt); err != nil {
+		return err
+	}
+	for _, item := range data.Items {
+		_, err := tx.Exec(
+			ctx, insertGoodsReceiptItemQuery,
+			item.ID,
+			data.ID,
+			item.PurchaseOrderItemID,
+			item.Quantity,
+			item.UnitCost,
+			item.PreviousCostPrice,
+			item.NewCostPrice,
+		)
+		if err != nil {
+			return err
+		}
+		result, err := tx.Exec(
+			ctx, addPurchaseOrderItemReceivedQuantityQuery,
+			item.PurchaseOrderItemID,
+			item.Quantity,
+		)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+	}
+	return nil
+}
+
+func insertPurchaseOrderItems(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.PurchaseOrderData,
+) error {
+	for _, item := range data.Items {
+		_, err := tx.Exec(
+			ctx, insertPurchaseOrderItemQuery,
+			item.ID,
+			data.ID,
+			item.VariantID,
+			item.ProductName,
+			item.Quantity,
+			item.UnitCost,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PurchaseOrder) findReceipts(
+	ctx context.Context,
+	purchaseOrderID string,
+) ([]repository.GoodsReceiptData, error) {
+	rows, err := p.db.Query(ctx, findGoodsReceiptsQuery, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	receipts := []repository.GoodsReceiptData{}
+	for rows.Next() {
+		var receipt repository.GoodsReceiptData
+		if err := rows.Scan(
+			&receipt.ID,
+			&receipt.PurchaseOrderID,
+			&receipt.ReceiptDate,
+			&receipt.CostPolicy,
+			&receipt.Note,
+			&receipt.CreatedBy,
+			&receipt.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(receipts) == 0 {
+		return receipts, nil
+	}
+	itemRows, err := p.db.Query(ctx, findGoodsReceiptItemsQuery, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+	receiptIndexByID := make(map[string]int, len(receipts))
+	for i := range receipts {
+		receiptIndexByID[receipts[i].ID] = i
+	}
+	for itemRows.Next() {
+		var item repository.GoodsReceiptItemData
+		if err := itemRows.Scan(
+			&item.ID,
+			&item.ReceiptID,
+			&item.PurchaseOrderItemID,
+			&item.VariantID,
+			&item.ProductName,
+			&item.Quantity,
+			&item.UnitCost,
+			&item.PreviousCostPrice,
+			&item.NewCostPrice,
+		); err != nil {
+			return nil, err
+		}
+		i := receiptIndexByID[item.ReceiptID]
+		receipts[i].Items = append(receipts[i].Items, item)
+	}
+	if err := itemRows.Err(); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+func findPurchaseOrderItems(
+	ctx context.Context,
+	querier rowsQuerier,
+	purchaseOrderID string,
+) ([]repository.PurchaseOrderItemData, error) {
+	rows, err := querier.Query(ctx, findPurchaseOrderItemsQuery, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []repository.PurchaseOrderItemData{}
+	for rows.Next() {
+		var item repository.PurchaseOrderItemData
+		if err := rows.Scan(
+			&item.ID,
+			&item.PurchaseOrderID,
+			&item.VariantID,
+			&item.ProductName,
+			&item.Quantity,
+			&item.UnitCost,
+			&item.ReceivedQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// scanPurchaseOrder scans the order columns, totalCount is scanned as the
+// last column when it is not nil
+func scanPurchaseOrder(
+	row pgx.Row,
+	totalCount *int,
+) (*repository.PurchaseOrderData, error) {
+	var order repository.PurchaseOrderData
+	var status string
+	dest := []any{
+		&order.ID,
+		&order.PONumber,
+		&order.SupplierID,
+		&order.SupplierName,
+		&order.OrderDate,
+		&order.ExpectedDate,
+		&status,
+		&order.PaymentTermDays,
+		&order.TotalAmount,
+		&order.Note,
+		&order.SentAt,
+		&order.ClosedAt,
+		&order.CreatedBy,
+		&order.UpdatedBy,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	order.Status = repository.PurchaseOrderStatus(status)
+	return &order, nil
+}
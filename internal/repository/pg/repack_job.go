@@ -0,0 +1,242 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type RepackJob struct {
+	db *pgxpool.Pool
+}
+
+func NewRepackJob(db *pgxpool.Pool) *RepackJob {
+	return &RepackJob{db: db}
+}
+
+const (
+	insertRepackJobQuery = `
+		INSERT INTO repack_jobs (
+			id,
+			recipe_id,
+			parent_variant_id,
+			child_variant_id,
+			quantity_ratio,
+			quantity,
+			parent_quantity,
+			status,
+			operator,
+			note,
+			started_at,
+			completed_at,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW()
+		)
+	`
+	updateRepackJobStatusQuery = `
+		UPDATE repack_jobs
+		SET
+			status = $2,
+			started_at = $3,
+			completed_at = $4,
+			cancelled_at = $5,
+			updated_by = $6,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	selectRepackJobColumns = `
+		SELECT
+			rj.id,
+			rj.recipe_id,
+			rj.parent_variant_id,
+			parent.full_name,
+			rj.child_variant_id,
+			child.full_name,
+			rj.quantity_ratio,
+			rj.quantity,
+			rj.parent_quantity,
+			rj.status,
+			rj.operator,
+			rj.note,
+			rj.started_at,
+			rj.completed_at,
+			rj.cancelled_at,
+			rj.created_by,
+			rj.updated_by,
+			rj.created_at,
+			rj.updated_at
+	`
+	findRepackJobByIDQuery = selectRepackJobColumns + `
+		FROM repack_jobs rj
+		JOIN product_variants parent ON parent.id = rj.parent_variant_id
+		JOIN product_variants child ON child.id = rj.child_variant_id
+		WHERE rj.id = $1
+	`
+	findRepackJobByIDForUpdateQuery = findRepackJobByIDQuery + `
+		FOR UPDATE OF rj
+	`
+	findPaginatedRepackJobsQuery = selectRepackJobColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM repack_jobs rj
+		JOIN product_variants parent ON parent.id = rj.parent_variant_id
+		JOIN product_variants child ON child.id = rj.child_variant_id
+		WHERE ($1 = '' OR rj.status::text = $1)
+		AND (
+			$2 = '' OR
+			rj.parent_variant_id::text = $2 OR
+			rj.child_variant_id::text = $2
+		)
+		ORDER BY rj.created_at DESC, rj.id
+		LIMIT $3 OFFSET $4
+	`
+)
+
+func (r *RepackJob) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.RepackJobData,
+) error {
+	_, err := tx.Exec(
+		ctx, insertRepackJobQuery,
+		data.ID,
+		data.RecipeID,
+		data.ParentVariantID,
+		data.ChildVariantID,
+		data.QuantityRatio,
+		data.Quantity,
+		data.ParentQuantity,
+		data.Status,
+		data.Operator,
+		data.Note,
+		data.StartedAt,
+		data.CompletedAt,
+		data.CreatedBy,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (r *RepackJob) UpdateStatusTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.RepackJobData,
+) error {
+	result, err := tx.Exec(
+		ctx, updateRepackJobStatusQuery,
+		data.ID,
+		data.Status,
+		data.StartedAt,
+		data.CompletedAt,
+		data.CancelledAt,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (r *RepackJob) FindByIDForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	jobID string,
+) (*repository.RepackJobData, error) {
+	return scanRepackJob(tx.QueryRow(ctx, findRepackJobByIDForUpdateQuery, jobID))
+}
+func (r *RepackJob) FindByID(
+	ctx context.Context,
+	jobID string,
+) (*repository.RepackJobData, error) {
+	return scanRepackJob(r.db.QueryRow(ctx, findRepackJobByIDQuery, jobID))
+}
+func (r *RepackJob) FindPaginated(
+	ctx context.Context,
+	filter *repository.RepackJobFilter,
+) ([]repository.RepackJobData, int, error) {
+	rows, err := r.db.Query(
+		ctx, findPaginatedRepackJobsQuery,
+		filter.Status,
+		filter.VariantID,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	jobs := []repository.RepackJobData{}
+	var totalCount int
+	for rows.Next() {
+		var job repository.RepackJobData
+		var status string
+		if err := rows.Scan(
+			&job.ID,
+			&job.RecipeID,
+			&job.ParentVariantID,
+			&job.ParentFullName,
+			&job.ChildVariantID,
+			&job.ChildFullName,
+			&job.QuantityRatio,
+			&job.Quantity,
+			&job.ParentQuantity,
+			&status,
+			&job.Operator,
+			&job.Note,
+			&job.StartedAt,
+			&job.CompletedAt,
+			&job.CancelledAt,
+			&job.CreatedBy,
+			&job.UpdatedBy,
+			&job.CreatedAt,
+			&job.UpdatedAt,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		job.Status = repository.RepackJobStatus(status)
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return jobs, totalCount, nil
+}
+func scanRepackJob(row pgx.Row) (*repository.RepackJobData, error) {
+	var job repository.RepackJobData
+	var status string
+	if err := row.Scan(
+		&job.ID,
+		&job.RecipeID,
+		&job.ParentVariantID,
+		&job.ParentFullName,
+		&job.ChildVariantID,
+		&job.ChildFullName,
+		&job.QuantityRatio,
+		&job.Quantity,
+		&job.ParentQuantity,
+		&status,
+		&job.Operator,
+		&job.Note,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.CancelledAt,
+		&job.CreatedBy,
+		&job.UpdatedBy,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	job.Status = repository.RepackJobStatus(status)
+	return &job, nil
+}
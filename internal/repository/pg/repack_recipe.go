@@ -2,6 +2,7 @@ package pg
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -33,6 +34,132 @@ const (
 			$1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()
 		)
 	`
+	findActiveRepackRecipeByParentVariantIDsQuery = `
+		SELECT
+			rr.id,
+			rr.parent_variant_id,
+			rr.child_variant_id,
+			rr.quantity_ratio,
+			rr.repack_cost_per_unit,
+			rr.repack_time_minutes
+		FROM product_repack_recipes rr
+		JOIN product_variants child ON child.id = rr.child_variant_id
+		WHERE rr.parent_variant_id = ANY($1::uuid[])
+		AND rr.deleted_at IS NULL
+		AND child.deleted_at IS NULL
+	`
+	findActiveRepackRecipeByChildVariantIDQuery = `
+		SELECT
+			rr.id,
+			rr.parent_variant_id,
+			rr.child_variant_id,
+			rr.quantity_ratio,
+			rr.repack_cost_per_unit,
+			rr.repack_time_minutes
+		FROM product_repack_recipes rr
+		JOIN product_variants parent ON parent.id = rr.parent_variant_id
+		JOIN product_variants child ON child.id = rr.child_variant_id
+		WHERE rr.child_variant_id = $1
+		AND rr.deleted_at IS NULL
+		AND parent.deleted_at IS NULL
+		AND child.deleted_at IS NULL
+	`
+	findActiveRepackRecipeByChildVariantIDsQuery = `
+		SELECT
+			rr.id,
+			rr.parent_variant_id,
+			rr.child_variant_id,
+			rr.quantity_ratio,
+			rr.repack_cost_per_unit,
+			rr.repack_time_minutes
+		FROM product_repack_recipes rr
+		WHERE rr.child_variant_id = ANY($1::uuid[])
+		AND rr.deleted_at IS NULL
+	`
+	findRepackRecipeByChildVariantIDsAndDeletedAtQuery = `
+		SELECT
+			rr.id,
+			rr.parent_variant_id,
+			rr.child_variant_id,
+			rr.quantity_ratio,
+			rr.repack_cost_per_unit,
+			rr.repack_time_minutes
+		FROM product_repack_recipes rr
+		WHERE rr.child_variant_id = ANY($1::uuid[])
+		AND rr.deleted_at = $2
+	`
+	softDeleteRepackRecipeByChildVariantIDsQuery = `
+		UPDATE product_repack_recipes
+		SET deleted_by = $2, deleted_at = $3, updated_by = $2, updated_at = NOW()
+		WHERE child_variant_id = ANY($1::uuid[])
+		AND deleted_at IS NULL
+	`
+	restoreRepackRecipeQuery = `
+		UPDATE product_repack_recipes
+		SET deleted_by = NULL, deleted_at = NULL, updated_by = $2, updated_at = NOW()
+		WHERE id = ANY($1::uuid[])
+	`
+	selectRepackRecipeDetailColumns = `
+		SELECT
+			rr.id,
+			rr.parent_variant_id,
+			parent.full_name,
+			parent.cost_price,
+			rr.child_variant_id,
+			child.full_name,
+			rr.quantity_ratio,
+			rr.repack_cost_per_unit,
+			rr.repack_time_minutes,
+			rr.created_by,
+			rr.updated_by,
+			rr.deleted_by,
+			rr.created_at,
+			rr.updated_at,
+			rr.deleted_at
+	`
+	findRepackRecipeByIDQuery = selectRepackRecipeDetailColumns + `
+		FROM product_repack_recipes rr
+		JOIN product_variants parent ON parent.id = rr.parent_variant_id
+		JOIN product_variants child ON child.id = rr.child_variant_id
+		WHERE rr.id = $1
+	`
+	findRepackRecipeByIDForUpdateQuery = findRepackRecipeByIDQuery + `
+		FOR UPDATE OF rr
+	`
+	findPaginatedRepackRecipesQuery = selectRepackRecipeDetailColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM product_repack_recipes rr
+		JOIN product_variants parent ON parent.id = rr.parent_variant_id
+		JOIN product_variants child ON child.id = rr.child_variant_id
+		WHERE ($1 = '' OR rr.parent_variant_id::text = $1)
+		AND ($2 = '' OR rr.child_variant_id::text = $2)
+		AND (
+			CASE
+				WHEN $3 = 'TRUE' THEN rr.deleted_at IS NULL
+				WHEN $3 = 'FALSE' THEN rr.deleted_at IS NOT NULL
+				ELSE true
+			END
+		)
+		ORDER BY rr.created_at DESC, rr.id
+		LIMIT $4 OFFSET $5
+	`
+	updateRepackRecipeQuery = `
+		UPDATE product_repack_recipes
+		SET
+			parent_variant_id = $2,
+			quantity_ratio = $3,
+			repack_cost_per_unit = $4,
+			repack_time_minutes = $5,
+			updated_by = $6,
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	deactivateRepackRecipeQuery = `
+		UPDATE product_repack_recipes
+		SET deleted_by = $2, deleted_at = $3, updated_by = $2, updated_at = NOW()
+		WHERE id = $1
+		AND deleted_at IS NULL
+	`
 )
 
 func (r *RepackRecipe) InsertTransaction(
@@ -56,3 +183,243 @@ func (r *RepackRecipe) InsertTransaction(
 	}
 	return nil
 }
+func (r *RepackRecipe) findMany(
+	ctx context.Context,
+	tx pgx.Tx,
+	query string,
+	args ...any,
+) ([]repository.RepackRecipeData, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	recipes := []repository.RepackRecipeData{}
+	for rows.Next() {
+		var recipe repository.RepackRecipeData
+		if err := rows.Scan(
+			&recipe.ID,
+			&recipe.ParentVariantID,
+			&recipe.ChildVariantID,
+			&recipe.QuantityRatio,
+			&recipe.RepackCostPerUnit,
+			&recipe.RepackTimeMinutes,
+		); err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, recipe)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+func (r *RepackRecipe) FindActiveByParentVariantIDs(
+	ctx context.Context,
+	tx pgx.Tx,
+	parentVariantIDs []string,
+) ([]repository.RepackRecipeData, error) {
+	return r.findMany(ctx, tx,
+		findActiveRepackRecipeByParentVariantIDsQuery,
+		parentVariantIDs,
+	)
+}
+func (r *RepackRecipe) FindActiveByChildVariantID(
+	ctx context.Context,
+	tx pgx.Tx,
+	childVariantID string,
+) (*repository.RepackRecipeData, error) {
+	recipes, err := r.findMany(ctx, tx,
+		findActiveRepackRecipeByChildVariantIDQuery,
+		childVariantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return &recipes[0], nil
+}
+func (r *RepackRecipe) FindActiveByChildVariantIDs(
+	ctx context.Context,
+	tx pgx.Tx,
+	childVariantIDs []string,
+) ([]repository.RepackRecipeData, error) {
+	return r.findMany(ctx, tx,
+		findActiveRepackRecipeByChildVariantIDsQuery,
+		childVariantIDs,
+	)
+}
+func (r *RepackRecipe) FindByChildVariantIDsAndDeletedAt(
+	ctx context.Context,
+	tx pgx.Tx,
+	childVariantIDs []string,
+	deletedAt time.Time,
+) ([]repository.RepackRecipeData, error) {
+	return r.findMany(ctx, tx,
+		findRepackRecipeByChildVariantIDsAndDeletedAtQuery,
+		childVariantIDs,
+		deletedAt,
+	)
+}
+func (r *RepackRecipe) SoftDeleteByChildVariantIDsTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	childVariantIDs []string,
+	deletedBy string,
+	deletedAt time.Time,
+) error {
+	_, err := tx.Exec(
+		ctx, softDeleteRepackRecipeByChildVariantIDsQuery,
+		childVariantIDs,
+		deletedBy,
+		deletedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (r *RepackRecipe) RestoreTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	recipeIDs []string,
+	updatedBy string,
+) error {
+	_, err := tx.Exec(
+		ctx, restoreRepackRecipeQuery,
+		recipeIDs,
+		updatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (r *RepackRecipe) FindByID(
+	ctx context.Context,
+	recipeID string,
+) (*repository.RepackRecipeData, error) {
+	return scanRepackRecipeDetail(r.db.QueryRow(ctx, findRepackRecipeByIDQuery, recipeID))
+}
+func (r *RepackRecipe) FindByIDForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	recipeID string,
+) (*repository.RepackRecipeData, error) {
+	return scanRepackRecipeDetail(tx.QueryRow(ctx, findRepackRecipeByIDForUpdateQuery, recipeID))
+}
+func (r *RepackRecipe) FindPaginated(
+	ctx context.Context,
+	filter *repository.RepackRecipeFilter,
+) ([]repository.RepackRecipeData, int, error) {
+	rows, err := r.db.Query(
+		ctx, findPaginatedRepackRecipesQuery,
+		filter.ParentVariantID,
+		filter.ChildVariantID,
+		filter.IsActive,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	recipes := []repository.RepackRecipeData{}
+	var totalCount int
+	for rows.Next() {
+		var recipe repository.RepackRecipeData
+		if err := rows.Scan(
+			&recipe.ID,
+			&recipe.ParentVariantID,
+			&recipe.ParentFullName,
+			&recipe.ParentCostPrice,
+			&recipe.ChildVariantID,
+			&recipe.ChildFullName,
+			&recipe.QuantityRatio,
+			&recipe.RepackCostPerUnit,
+			&recipe.RepackTimeMinutes,
+			&recipe.CreatedBy,
+			&recipe.UpdatedBy,
+			&recipe.DeletedBy,
+			&recipe.CreatedAt,
+			&recipe.UpdatedAt,
+			&recipe.DeletedAt,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		recipes = append(recipes, recipe)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return recipes, totalCount, nil
+}
+func (r *RepackRecipe) UpdateTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.RepackRecipeData,
+) error {
+	result, err := tx.Exec(
+		ctx, updateRepackRecipeQuery,
+		data.ID,
+		data.ParentVariantID,
+		data.QuantityRatio,
+		data.RepackCostPerUnit,
+		data.RepackTimeMinutes,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (r *RepackRecipe) DeactivateTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	recipeID string,
+	deletedBy string,
+	deletedAt time.Time,
+) error {
+	result, err := tx.Exec(
+		ctx, deactivateRepackRecipeQuery,
+		recipeID,
+		deletedBy,
+		deletedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func scanRepackRecipeDetail(row pgx.Row) (*repository.RepackRecipeData, error) {
+	var recipe repository.RepackRecipeData
+	if err := row.Scan(
+		&recipe.ID,
+		&recipe.ParentVariantID,
+		&recipe.ParentFullName,
+		&recipe.ParentCostPrice,
+		&recipe.ChildVariantID,
+		&recipe.ChildFullName,
+		&recipe.QuantityRatio,
+		&recipe.RepackCostPerUnit,
+		&recipe.RepackTimeMinutes,
+		&recipe.CreatedBy,
+		&recipe.UpdatedBy,
+		&recipe.DeletedBy,
+		&recipe.CreatedAt,
+		&recipe.UpdatedAt,
+		&recipe.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &recipe, nil
+}
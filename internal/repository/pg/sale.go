@@ -0,0 +1,526 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type Sale struct {
+	db *pgxpool.Pool
+}
+
+func NewSale(db *pgxpool.Pool) *Sale {
+	return &Sale{db: db}
+}
+
+const (
+	insertSaleQuery = `
+		INSERT INTO sales (
+			id,
+			sale_number,
+			sale_date,
+			payment_method,
+			status,
+			subtotal_amount,
+			discount_amount,
+			total_amount,
+			total_cost_amount,
+			total_net_profit,
+			paid_amount,
+			change_amount,
+			note,
+			customer_id,
+			price_tier,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES (
+			$1,
+			'POS-' || TO_CHAR($2::date, 'YYYYMMDD') || '-' ||
+				LPAD(nextval('sale_number_seq')::text, 6, '0'),
+			$2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $15,
+			NOW(), NOW()
+		)
+		RETURNING sale_number, created_at, updated_at
+	`
+	insertSaleItemQuery = `
+		INSERT INTO sale_items (
+			id,
+			sale_id,
+			variant_id,
+			product_name,
+			quantity,
+			unit_price,
+			discount_amount,
+			net_amount,
+			cost_price,
+			returned_quantity
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 0)
+	`
+	saleColumns = `
+			s.id,
+			s.sale_number,
+			s.sale_date,
+			s.payment_method,
+			s.status,
+			s.subtotal_amount,
+			s.discount_amount,
+			s.total_amount,
+			s.total_cost_amount,
+			s.total_net_profit,
+			s.paid_amount,
+			s.change_amount,
+			COALESCE((
+				SELECT SUM(r.refund_amount)
+				FROM sale_returns r
+				WHERE r.sale_id = s.id
+			), 0),
+			s.note,
+			s.customer_id,
+			(SELECT c.name FROM customers c WHERE c.id = s.customer_id),
+			s.price_tier,
+			s.void_reason,
+			s.voided_by,
+			s.voided_at,
+			s.created_by,
+			s.updated_by,
+			s.created_at,
+			s.updated_at
+	`
+	findSaleByIDQuery = `
+		SELECT ` + saleColumns + `
+		FROM sales s
+		WHERE s.id = $1
+	`
+	findSaleByIDForUpdateQuery = `
+		SELECT ` + saleColumns + `
+		FROM sales s
+		WHERE s.id = $1
+		FOR UPDATE
+	`
+	findSaleItemsQuery = `
+		SELECT
+			i.id,
+			i.sale_id,
+			i.variant_id,
+			i.product_name,
+			i.quantity,
+			i.unit_price,
+			i.discount_amount,
+			i.net_amount,
+			i.cost_price,
+			i.returned_quantity,
+			pv.size_value,
+			su.code
+		FROM sale_items i
+		JOIN product_variants pv ON pv.id = i.variant_id
+		JOIN size_units su ON su.id = pv.size_unit_id
+		WHERE i.sale_id = $1
+		ORDER BY i.product_name, i.id
+	`
+	findSaleReturnsQuery = `
+		SELECT
+			id,
+			sale_id,
+			return_date,
+			refund_amount,
+			cost_amount,
+			reason,
+			created_by,
+			created_at
+		FROM sale_returns
+		WHERE sale_id = $1
+		ORDER BY created_at
+	`
+	findSaleReturnItemsQuery = `
+		SELECT
+			ri.id,
+			ri.return_id,
+			ri.sale_item_id,
+			ri.quantity,
+			ri.refund_amount,
+			ri.cost_amount
+		FROM sale_return_items ri
+		JOIN sale_returns r ON r.id = ri.return_id
+		WHERE r.sale_id = $1
+		ORDER BY ri.return_id, ri.id
+	`
+	findPaginatedSalesQuery = `
+		SELECT ` + saleColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM sales s
+		WHERE ($1 = '' OR s.sale_number ILIKE '%' || $1 || '%')
+		AND ($2 = '' OR s.payment_method::text = $2)
+		AND ($3 = '' OR s.status::text = $3)
+		AND ($4 = '' OR s.sale_date >= NULLIF($4, '')::date)
+		AND ($5 = '' OR s.sale_date <= NULLIF($5, '')::date)
+		AND ($6 = '' OR s.customer_id = NULLIF($6, '')::uuid)
+		ORDER BY s.sale_date DESC, s.created_at DESC
+		LIMIT $7 OFFSET $8
+	`
+	voidSaleQuery = `
+		UPDATE sales
+		SET
+			status = $2,
+			void_reason = $3,
+			voided_by = $4,
+			voided_at = NOW(),
+			updated_by = $4,
+			updated_at = NOW()
+		WHERE id = $1
+		AND status = 'COMPLETED'
+	`
+	insertSaleReturnQuery = `
+		INSERT INTO sale_returns (
+			id,
+			sale_id,
+			return_date,
+			refund_amount,
+			cost_amount,
+			reason,
+			created_by,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`
+	insertSaleReturnItemQuery = `
+		INSERT INTO sale_return_items (
+			id,
+			return_id,
+			sale_item_id,
+			quantity,
+			refund_amount,
+			cost_amount
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	addSaleItemReturnedQuantityQuery = `
+		UPDATE sale_items
+		SET returned_quantity = returned_quantity + $2
+		WHERE id = $1
+		AND returned_quantity + $2 <= quantity
+	`
+)
+
+func (s *Sale) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.SaleData,
+) error {
+	if err := tx.QueryRow(
+		ctx, insertSaleQuery,
+		data.ID,
+		data.SaleDate,
+		data.PaymentMethod,
+		data.Status,
+		data.SubtotalAmount,
+		data.DiscountAmount,
+		data.TotalAmount,
+		data.TotalCostAmount,
+		data.TotalNetProfit,
+		data.PaidAmount,
+		data.ChangeAmount,
+		data.Note,
+		data.CustomerID,
+		data.PriceTier,
+		data.CreatedBy,
+	).Scan(&data.SaleNumber, &data.CreatedAt, &data.UpdatedAt); err != nil {
+		return err
+	}
+	data.UpdatedBy = data.CreatedBy
+	for _, item := range data.Items {
+		_, err := tx.Exec(
+			ctx, insertSaleItemQuery,
+			item.ID,
+			data.ID,
+			item.VariantID,
+			item.ProductName,
+			item.Quantity,
+			item.UnitPrice,
+			item.DiscountAmount,
+			item.NetAmount,
+			item.CostPrice,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (s *Sale) FindByID(
+	ctx context.Context,
+	saleID string,
+) (*repository.SaleData, error) {
+	sale, err := scanSale(s.db.QueryRow(ctx, findSaleByIDQuery, saleID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Items, err = findSaleItems(ctx, s.db, saleID); err != nil {
+		return nil, err
+	}
+	if sale.Returns, err = s.findReturns(ctx, saleID); err != nil {
+		return nil, err
+	}
+	return sale, nil
+}
+func (s *Sale) FindByIDForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	saleID string,
+) (*repository.SaleData, error) {
+	sale, err := scanSale(tx.QueryRow(ctx, findSaleByIDForUpdateQuery, saleID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if sale.Items, err = findSaleItems(ctx, tx, saleID); err != nil {
+		return nil, err
+	}
+	return sale, nil
+}
+func (s *Sale) FindPaginated(
+	ctx context.Context,
+	filter *repository.SaleFilter,
+) ([]repository.SaleData, int, error) {
+	rows, err := s.db.Query(
+		ctx, findPaginatedSalesQuery,
+		filter.SaleNumber,
+		filter.PaymentMethod,
+		filter.Status,
+		filter.StartDate,
+		filter.EndDate,
+		filter.CustomerID,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	sales := []repository.SaleData{}
+	var totalCount int
+	for rows.Next() {
+		sale, err := scanSale(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		sales = append(sales, *sale)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return sales, totalCount, nil
+}
+func (s *Sale) VoidTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.SaleData,
+) error {
+	result, err := tx.Exec(
+		ctx, voidSaleQuery,
+		data.ID,
+		repository.SaleStatusVoided,
+		data.VoidReason,
+		data.VoidedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (s *Sale) InsertReturnTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.SaleReturnData,
+) error {
+	if err := tx.QueryRow(
+		ctx, insertSaleReturnQuery,
+		data.ID,
+		data.SaleID,
+		data.ReturnDate,
+		data.RefundAmount,
+		data.CostAmount,
+		data.Reason,
+		data.CreatedBy,
+	).Scan(&data.CreatedAt); err != nil {
+		return err
+	}
+	for _, item := range data.Items {
+		_, err := tx.Exec(
+			ctx, insertSaleReturnItemQuery,
+			item.ID,
+			data.ID,
+			item.SaleItemID,
+			item.Quantity,
+			item.RefundAmount,
+			item.CostAmount,
+		)
+		if err != nil {
+			return err
+		}
+		result, err := tx.Exec(
+			ctx, addSaleItemReturnedQuantityQuery,
+			item.SaleItemID,
+			item.Quantity,
+		)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+	}
+	return nil
+}
+
+func (s *Sale) findReturns(
+	ctx context.Context,
+	saleID string,
+) ([]repository.SaleReturnData, error) {
+	rows, err := s.db.Query(ctx, findSaleReturnsQuery, saleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	returns := []repository.SaleReturnData{}
+	for rows.Next() {
+		var saleReturn repository.SaleReturnData
+		if err := rows.Scan(
+			&saleReturn.ID,
+			&saleReturn.SaleID,
+			&saleReturn.ReturnDate,
+			&saleReturn.RefundAmount,
+			&saleReturn.CostAmount,
+			&saleReturn.Reason,
+			&saleReturn.CreatedBy,
+			&saleReturn.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		returns = append(returns, saleReturn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return returns, nil
+	}
+	itemRows, err := s.db.Query(ctx, findSaleReturnItemsQuery, saleID)
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+	returnIndexByID := make(map[string]int, len(returns))
+	for i := range returns {
+		returnIndexByID[returns[i].ID] = i
+	}
+	for itemRows.Next() {
+		var item repository.SaleReturnItemData
+		if err := itemRows.Scan(
+			&item.ID,
+			&item.ReturnID,
+			&item.SaleItemID,
+			&item.Quantity,
+			&item.RefundAmount,
+			&item.CostAmount,
+		); err != nil {
+			return nil, err
+		}
+		i := returnIndexByID[item.ReturnID]
+		returns[i].Items = append(returns[i].Items, item)
+	}
+	if err := itemRows.Err(); err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+// rowsQuerier is satisfied by both the pool and a transaction
+type rowsQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func findSaleItems(
+	ctx context.Context,
+	querier rowsQuerier,
+	saleID string,
+) ([]repository.SaleItemData, error) {
+	rows, err := querier.Query(ctx, findSaleItemsQuery, saleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []repository.SaleItemData{}
+	for rows.Next() {
+		var item repository.SaleItemData
+		if err := rows.Scan(
+			&item.ID,
+			&item.SaleID,
+			&item.VariantID,
+			&item.ProductName,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.DiscountAmount,
+			&item.NetAmount,
+			&item.CostPrice,
+			&item.ReturnedQuantity,
+			&item.SizeValue,
+			&item.SizeUnitCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// scanSale scans the sale columns, totalCount is scanned as the last column
+// when it is not nil
+func scanSale(row pgx.Row, totalCount *int) (*repository.SaleData, error) {
+	var sale repository.SaleData
+	var paymentMethod, status, priceTier string
+	dest := []any{
+		&sale.ID,
+		&sale.SaleNumber,
+		&sale.SaleDate,
+		&paymentMethod,
+		&status,
+		&sale.SubtotalAmount,
+		&sale.DiscountAmount,
+		&sale.TotalAmount,
+		&sale.TotalCostAmount,
+		&sale.TotalNetProfit,
+		&sale.PaidAmount,
+		&sale.ChangeAmount,
+		&sale.TotalRefundAmount,
+		&sale.Note,
+		&sale.CustomerID,
+		&sale.CustomerName,
+		&priceTier,
+		&sale.VoidReason,
+		&sale.VoidedBy,
+		&sale.VoidedAt,
+		&sale.CreatedBy,
+		&sale.UpdatedBy,
+		&sale.CreatedAt,
+		&sale.UpdatedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	sale.PaymentMethod = repository.SalePaymentMethod(paymentMethod)
+	sale.Status = repository.SaleStatus(status)
+	sale.PriceTier = repository.CustomerPriceTier(priceTier)
+	return &sale, nil
+}
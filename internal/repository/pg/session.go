@@ -0,0 +1,168 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type Session struct {
+	db *pgxpool.Pool
+}
+
+func NewSession(db *pgxpool.Pool) *Session {
+	return &Session{db: db}
+}
+
+const (
+	insertSessionQuery = `
+		INSERT INTO user_sessions (
+			id,
+			username,
+			refresh_token_hash,
+			device_name,
+			user_agent,
+			ip_address,
+			expires_at,
+			last_used_at,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW(), NOW())
+	`
+	findSessionByRefreshTokenHashForUpdateQuery = `
+		SELECT
+			id,
+			username,
+			refresh_token_hash,
+			previous_refresh_token_hash,
+			device_name,
+			user_agent,
+			ip_address,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at,
+			updated_at
+		FROM user_sessions
+		WHERE refresh_token_hash = $1 OR previous_refresh_token_hash = $1
+		LIMIT 1
+		FOR UPDATE
+	`
+	rotateSessionQuery = `
+		UPDATE user_sessions
+		SET
+			previous_refresh_token_hash = refresh_token_hash,
+			refresh_token_hash = $2,
+			user_agent = $3,
+			ip_address = $4,
+			expires_at = $5,
+			last_used_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	revokeSessionQuery = `
+		UPDATE user_sessions
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	revokeSessionByUsernameQuery = `
+		UPDATE user_sessions
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND username = $2 AND revoked_at IS NULL
+	`
+	// revokeSessionsByUsernameQuery keeps the session with id $2, an empty
+	// id revokes every session
+	revokeSessionsByUsernameQuery = `
+		UPDATE user_sessions
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE username = $1 AND revoked_at IS NULL AND id::text <> $2
+	`
+)
+
+func (s *Session) Insert(ctx context.Context, data *repository.SessionData) error {
+	_, err := s.db.Exec(
+		ctx, insertSessionQuery,
+		data.ID,
+		data.Username,
+		data.RefreshTokenHash,
+		data.DeviceName,
+		data.UserAgent,
+		data.IPAddress,
+		data.ExpiresAt,
+	)
+	return err
+}
+func (s *Session) FindByRefreshTokenHashForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	refreshTokenHash string,
+) (*repository.SessionData, error) {
+	var session repository.SessionData
+	if err := tx.QueryRow(
+		ctx, findSessionByRefreshTokenHashForUpdateQuery, refreshTokenHash,
+	).Scan(
+		&session.ID,
+		&session.Username,
+		&session.RefreshTokenHash,
+		&session.PreviousRefreshTokenHash,
+		&session.DeviceName,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.ExpiresAt,
+		&session.LastUsedAt,
+		&session.RevokedAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+func (s *Session) RotateTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.SessionData,
+) error {
+	result, err := tx.Exec(
+		ctx, rotateSessionQuery,
+		data.ID,
+		data.RefreshTokenHash,
+		data.UserAgent,
+		data.IPAddress,
+		data.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (s *Session) RevokeTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	sessionID string,
+) error {
+	_, err := tx.Exec(ctx, revokeSessionQuery, sessionID)
+	return err
+}
+func (s *Session) Revoke(ctx context.Context, sessionID, username string) error {
+	result, err := s.db.Exec(ctx, revokeSessionByUsernameQuery, sessionID, username)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (s *Session) RevokeAllByUsername(ctx context.Context, username string) (int64, error) {
+	result, err := s.db.Exec(ctx, revokeSessionsByUsernameQuery, username, "")
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
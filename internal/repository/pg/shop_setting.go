@@ -0,0 +1,87 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type ShopSetting struct {
+	db *pgxpool.Pool
+}
+
+func NewShopSetting(db *pgxpool.Pool) *ShopSetting {
+	return &ShopSetting{db: db}
+}
+
+const (
+	findShopSettingQuery = `
+		SELECT
+			shop_name,
+			address,
+			phone,
+			receipt_header,
+			receipt_footer,
+			invoice_footer,
+			updated_by,
+			updated_at
+		FROM shop_settings
+		WHERE id = 1
+	`
+	upsertShopSettingQuery = `
+		INSERT INTO shop_settings (
+			id,
+			shop_name,
+			address,
+			phone,
+			receipt_header,
+			receipt_footer,
+			invoice_footer,
+			updated_by,
+			updated_at
+		) VALUES (1, $1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			shop_name = EXCLUDED.shop_name,
+			address = EXCLUDED.address,
+			phone = EXCLUDED.phone,
+			receipt_header = EXCLUDED.receipt_header,
+			receipt_footer = EXCLUDED.receipt_footer,
+			invoice_footer = EXCLUDED.invoice_footer,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+)
+
+func (s *ShopSetting) Find(ctx context.Context) (*repository.ShopSettingData, error) {
+	var setting repository.ShopSettingData
+	if err := s.db.QueryRow(ctx, findShopSettingQuery).Scan(
+		&setting.ShopName,
+		&setting.Address,
+		&setting.Phone,
+		&setting.ReceiptHeader,
+		&setting.ReceiptFooter,
+		&setting.InvoiceFooter,
+		&setting.UpdatedBy,
+		&setting.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+func (s *ShopSetting) Update(
+	ctx context.Context,
+	data *repository.ShopSettingData,
+) error {
+	return s.db.QueryRow(
+		ctx, upsertShopSettingQuery,
+		data.ShopName,
+		data.Address,
+		data.Phone,
+		data.ReceiptHeader,
+		data.ReceiptFooter,
+		data.InvoiceFooter,
+		data.UpdatedBy,
+	).Scan(&data.UpdatedAt)
+}
@@ -0,0 +1,178 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+var (
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+type StockLedger struct {
+	db *pgxpool.Pool
+}
+
+func NewStockLedger(db *pgxpool.Pool) *StockLedger {
+	return &StockLedger{db: db}
+}
+
+const (
+	upsertVariantStockQuery = `
+		INSERT INTO variant_stocks (
+			variant_id,
+			quantity,
+			updated_by,
+			updated_at
+		) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (variant_id) DO UPDATE
+		SET quantity = variant_stocks.quantity + EXCLUDED.quantity,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING quantity
+	`
+	insertStockMovementQuery = `
+		INSERT INTO stock_movements (
+			id,
+			variant_id,
+			movement_type,
+			quantity,
+			balance_after,
+			reference_type,
+			reference_id,
+			note,
+			created_by,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+	`
+	findStockBalanceByVariantIDQuery = `
+		SELECT
+			pv.id,
+			COALESCE(pv.sku, ''),
+			pv.full_name,
+			COALESCE(vs.quantity, 0),
+			vs.updated_by,
+			vs.updated_at
+		FROM product_variants pv
+		LEFT JOIN variant_stocks vs ON vs.variant_id = pv.id
+		WHERE pv.id = $1
+		AND pv.deleted_at IS NULL
+	`
+	findPaginatedStockMovementsQuery = `
+		SELECT
+			id,
+			variant_id,
+			movement_type,
+			quantity,
+			balance_after,
+			reference_type,
+			reference_id,
+			note,
+			created_by,
+			created_at,
+			COUNT(*) OVER () AS total_count
+		FROM stock_movements
+		WHERE variant_id = $1
+		AND ($2 = '' OR movement_type::text = $2)
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+)
+
+func (s *StockLedger) InsertMovementTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.StockMovementData,
+) error {
+	if err := tx.QueryRow(
+		ctx, upsertVariantStockQuery,
+		data.VariantID,
+		data.Quantity,
+		data.CreatedBy,
+	).Scan(&data.BalanceAfter); err != nil {
+		return err
+	}
+	if data.Quantity.IsNegative() && data.BalanceAfter.IsNegative() {
+		return ErrInsufficientStock
+	}
+	_, err := tx.Exec(
+		ctx, insertStockMovementQuery,
+		data.ID,
+		data.VariantID,
+		data.MovementType,
+		data.Quantity,
+		data.BalanceAfter,
+		data.ReferenceType,
+		data.ReferenceID,
+		data.Note,
+		data.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (s *StockLedger) FindBalanceByVariantID(
+	ctx context.Context,
+	variantID string,
+) (*repository.StockBalanceData, error) {
+	var balance repository.StockBalanceData
+	if err := s.db.QueryRow(ctx, findStockBalanceByVariantIDQuery, variantID).Scan(
+		&balance.VariantID,
+		&balance.SKU,
+		&balance.FullName,
+		&balance.Quantity,
+		&balance.UpdatedBy,
+		&balance.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+func (s *StockLedger) FindPaginatedMovements(
+	ctx context.Context,
+	filter *repository.StockMovementFilter,
+) ([]repository.StockMovementData, int, error) {
+	rows, err := s.db.Query(
+		ctx, findPaginatedStockMovementsQuery,
+		filter.VariantID,
+		filter.MovementType,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	movements := []repository.StockMovementData{}
+	var totalCount int
+	for rows.Next() {
+		var movement repository.StockMovementData
+		var movementType string
+		if err := rows.Scan(
+			&movement.ID,
+			&movement.VariantID,
+			&movementType,
+			&movement.Quantity,
+			&movement.BalanceAfter,
+			&movement.ReferenceType,
+			&movement.ReferenceID,
+			&movement.Note,
+			&movement.CreatedBy,
+			&movement.CreatedAt,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		movement.MovementType = repository.StockMovementType(movementType)
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return movements, totalCount, nil
+}
@@ -0,0 +1,242 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+var (
+	ErrSupplierAlreadyExists = errors.New("supplier with the same name already exists")
+)
+
+type Supplier struct {
+	db *pgxpool.Pool
+}
+
+func NewSupplier(db *pgxpool.Pool) *Supplier {
+	return &Supplier{db: db}
+}
+
+const (
+	insertSupplierQuery = `
+		INSERT INTO suppliers (
+			id,
+			name,
+			contact_name,
+			phone,
+			email,
+			address,
+			payment_term_days,
+			note,
+			is_active,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10, NOW(), NOW())
+	`
+	updateSupplierQuery = `
+		UPDATE suppliers
+		SET
+			name = $2,
+			contact_name = $3,
+			phone = $4,
+			email = $5,
+			address = $6,
+			payment_term_days = $7,
+			note = $8,
+			is_active = $9,
+			updated_by = $10,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	selectSupplierColumns = `
+		SELECT
+			id,
+			name,
+			contact_name,
+			phone,
+			email,
+			address,
+			payment_term_days,
+			note,
+			is_active,
+			created_by,
+			updated_by,
+			deleted_by,
+			created_at,
+			updated_at,
+			deleted_at
+	`
+	findSupplierByIDQuery = selectSupplierColumns + `
+		FROM suppliers
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	findPaginatedSuppliersQuery = selectSupplierColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM suppliers
+		WHERE deleted_at IS NULL
+		AND (
+			$1 = '' OR
+			name ILIKE '%' || $1 || '%' OR
+			contact_name ILIKE '%' || $1 || '%' OR
+			phone ILIKE '%' || $1 || '%'
+		)
+		AND (
+			CASE
+				WHEN $2 = 'TRUE' THEN is_active = true
+				WHEN $2 = 'FALSE' THEN is_active = false
+				ELSE true
+			END
+		)
+		ORDER BY name, id
+		LIMIT $3 OFFSET $4
+	`
+	softDeleteSupplierQuery = `
+		UPDATE suppliers
+		SET
+			deleted_at = NOW(),
+			deleted_by = $2,
+			updated_by = $2,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+func (s *Supplier) Insert(
+	ctx context.Context,
+	data *repository.SupplierData,
+) error {
+	_, err := s.db.Exec(
+		ctx, insertSupplierQuery,
+		data.ID,
+		data.Name,
+		data.ContactName,
+		data.Phone,
+		data.Email,
+		data.Address,
+		data.PaymentTermDays,
+		data.Note,
+		data.IsActive,
+		data.CreatedBy,
+	)
+	return handleSupplierUniqueViolation(err)
+}
+func (s *Supplier) Update(
+	ctx context.Context,
+	data *repository.SupplierData,
+) error {
+	result, err := s.db.Exec(
+		ctx, updateSupplierQuery,
+		data.ID,
+		data.Name,
+		data.ContactName,
+		data.Phone,
+		data.Email,
+		data.Address,
+		data.PaymentTermDays,
+		data.Note,
+		data.IsActive,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return handleSupplierUniqueViolation(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (s *Supplier) FindByID(
+	ctx context.Context,
+	supplierID string,
+) (*repository.SupplierData, error) {
+	return scanSupplier(s.db.QueryRow(ctx, findSupplierByIDQuery, supplierID), nil)
+}
+func (s *Supplier) FindPaginated(
+	ctx context.Context,
+	filter *repository.SupplierFilter,
+) ([]repository.SupplierData, int, error) {
+	rows, err := s.db.Query(
+		ctx, findPaginatedSuppliersQuery,
+		filter.Search,
+		filter.IsActive,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	suppliers := []repository.SupplierData{}
+	var totalCount int
+	for rows.Next() {
+		supplier, err := scanSupplier(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		suppliers = append(suppliers, *supplier)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return suppliers, totalCount, nil
+}
+func (s *Supplier) SoftDelete(
+	ctx context.Context,
+	supplierID, deletedBy string,
+) error {
+	result, err := s.db.Exec(ctx, softDeleteSupplierQuery, supplierID, deletedBy)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func handleSupplierUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		pgErr.Code == constants.ErrCodePostgreUniqueViolation {
+		return ErrSupplierAlreadyExists
+	}
+	return err
+}
+
+// scanSupplier scans the supplier columns, totalCount is scanned as the last
+// column when it is not nil
+func scanSupplier(row pgx.Row, totalCount *int) (*repository.SupplierData, error) {
+	var supplier repository.SupplierData
+	dest := []any{
+		&supplier.ID,
+		&supplier.Name,
+		&supplier.ContactName,
+		&supplier.Phone,
+		&supplier.Email,
+		&supplier.Address,
+		&supplier.PaymentTermDays,
+		&supplier.Note,
+		&supplier.IsActive,
+		&supplier.CreatedBy,
+		&supplier.UpdatedBy,
+		&supplier.DeletedBy,
+		&supplier.CreatedAt,
+		&supplier.UpdatedAt,
+		&supplier.DeletedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
@@ -0,0 +1,235 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+var (
+	ErrUserAlreadyExists = errors.New("user already exists")
+)
+
+type User struct {
+	db *pgxpool.Pool
+}
+
+func NewUser(db *pgxpool.Pool) *User {
+	return &User{db: db}
+}
+
+const (
+	insertUserQuery = `
+		INSERT INTO users (
+			username,
+			hash_password,
+			role,
+			is_active,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+	`
+	findUserByUsernameQuery = `
+		SELECT
+			username,
+			hash_password,
+			role,
+			is_active,
+			last_login,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		FROM users
+		WHERE username = $1
+	`
+	findPaginatedUsersQuery = `
+		SELECT
+			username,
+			role,
+			is_active,
+			last_login,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at,
+			COUNT(*) OVER () AS total_count
+		FROM users
+		WHERE ($1 = '' OR username ILIKE '%' || $1 || '%')
+		AND ($2 = '' OR role::text = $2)
+		AND (
+			CASE
+				WHEN $3 = 'TRUE' THEN is_active = true
+				WHEN $3 = 'FALSE' THEN is_active = false
+				ELSE true
+			END
+		)
+		ORDER BY username
+		LIMIT $4 OFFSET $5
+	`
+	updateUserRoleQuery = `
+		UPDATE users
+		SET role = $2, updated_by = $3, updated_at = NOW()
+		WHERE username = $1
+	`
+	updateUserPasswordQuery = `
+		UPDATE users
+		SET
+			hash_password = $2,
+			updated_by = $3,
+			updated_at = NOW()
+		WHERE username = $1
+	`
+	updateUserStatusQuery = `
+		UPDATE users
+		SET
+			is_active = $2,
+			updated_by = $3,
+			updated_at = NOW()
+		WHERE username = $1
+	`
+)
+
+func (u *User) Insert(ctx context.Context, data *repository.UserData) error {
+	_, err := u.db.Exec(
+		ctx, insertUserQuery,
+		data.Username,
+		data.HashPassword,
+		data.Role,
+		data.IsActive,
+		data.CreatedBy,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == constants.ErrCodePostgreUniqueViolation {
+			return ErrUserAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+func (u *User) FindByUsername(
+	ctx context.Context,
+	username string,
+) (*repository.UserData, error) {
+	var user repository.UserData
+	if err := u.db.QueryRow(ctx, findUserByUsernameQuery, username).Scan(
+		&user.Username,
+		&user.HashPassword,
+		&user.Role,
+		&user.IsActive,
+		&user.LastLogin,
+		&user.CreatedBy,
+		&user.UpdatedBy,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+func (u *User) FindPaginated(
+	ctx context.Context,
+	filter *repository.UserFilter,
+) ([]repository.UserData, int, error) {
+	rows, err := u.db.Query(
+		ctx, findPaginatedUsersQuery,
+		filter.Username,
+		filter.Role,
+		filter.IsActive,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	users := []repository.UserData{}
+	var totalCount int
+	for rows.Next() {
+		var user repository.UserData
+		if err := rows.Scan(
+			&user.Username,
+			&user.Role,
+			&user.IsActive,
+			&user.LastLogin,
+			&user.CreatedBy,
+			&user.UpdatedBy,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&totalCount,
+		); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return users, totalCount, nil
+}
+func (u *User) UpdateRole(
+	ctx context.Context,
+	username, role, updatedBy string,
+) error {
+	return u.updateAndRevokeSessions(ctx, true, "",
+		updateUserRoleQuery, username, role, updatedBy)
+}
+func (u *User) UpdatePassword(
+	ctx context.Context,
+	username, hashPassword, updatedBy string,
+	keepSessionID string,
+) error {
+	return u.updateAndRevokeSessions(ctx, true, keepSessionID,
+		updateUserPasswordQuery, username, hashPassword, updatedBy)
+}
+func (u *User) UpdateStatus(
+	ctx context.Context,
+	username string,
+	isActive bool,
+	updatedBy string,
+) error {
+	return u.updateAndRevokeSessions(ctx, !isActive, "",
+		updateUserStatusQuery, username, isActive, updatedBy)
+}
+
+// updateAndRevokeSessions runs an update on a single user, the first query
+// argument must be the username. When revokeSessions is true every active
+// session of the user except keepSessionID is revoked in the same
+// transaction.
+func (u *User) updateAndRevokeSessions(
+	ctx context.Context,
+	revokeSessions bool,
+	keepSessionID string,
+	query string,
+	args ...any,
+) error {
+	tx, err := u.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	if revokeSessions {
+		if _, err := tx.Exec(
+			ctx, revokeSessionsByUsernameQuery, args[0], keepSessionID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,297 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/constants"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+var (
+	ErrChannelListingAlreadyExists = errors.New(
+		"channel listing with the same name or channel sku already exists")
+)
+
+type VariantChannelListing struct {
+	db *pgxpool.Pool
+}
+
+func NewVariantChannelListing(db *pgxpool.Pool) *VariantChannelListing {
+	return &VariantChannelListing{db: db}
+}
+
+const (
+	insertVariantChannelListingQuery = `
+		INSERT INTO variant_channel_listings (
+			id,
+			variant_id,
+			channel,
+			listing_name,
+			variation_name,
+			channel_sku,
+			listed_price,
+			fee_category,
+			is_active,
+			created_by,
+			updated_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+	`
+	updateVariantChannelListingQuery = `
+		UPDATE variant_channel_listings
+		SET
+			variant_id = $2,
+			channel = $3,
+			listing_name = $4,
+			variation_name = $5,
+			channel_sku = $6,
+			listed_price = $7,
+			fee_category = $8,
+			is_active = $9,
+			updated_by = $10,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	selectVariantChannelListingColumns = `
+		SELECT
+			l.id,
+			l.variant_id,
+			v.full_name,
+			v.sku,
+			l.channel,
+			l.listing_name,
+			l.variation_name,
+			l.channel_sku,
+			l.listed_price,
+			l.fee_category,
+			l.is_active,
+			l.created_by,
+			l.updated_by,
+			l.deleted_by,
+			l.created_at,
+			l.updated_at,
+			l.deleted_at
+	`
+	findVariantChannelListingByIDQuery = selectVariantChannelListingColumns + `
+		FROM variant_channel_listings l
+		JOIN product_variants v
+			ON v.id = l.variant_id
+		WHERE l.id = $1 AND l.deleted_at IS NULL
+	`
+	findPaginatedVariantChannelListingsQuery = selectVariantChannelListingColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM variant_channel_listings l
+		JOIN product_variants v
+			ON v.id = l.variant_id
+		WHERE l.deleted_at IS NULL
+		AND ($1 = '' OR l.variant_id::text = $1)
+		AND ($2 = '' OR l.channel::text = $2)
+		AND (
+			$3 = '' OR
+			l.listing_name ILIKE '%' || $3 || '%' OR
+			l.variation_name ILIKE '%' || $3 || '%' OR
+			l.channel_sku ILIKE '%' || $3 || '%'
+		)
+		AND (
+			CASE
+				WHEN $4 = 'TRUE' THEN l.is_active = true
+				WHEN $4 = 'FALSE' THEN l.is_active = false
+				ELSE true
+			END
+		)
+		ORDER BY l.channel, l.listing_name, l.variation_name
+		LIMIT $5 OFFSET $6
+	`
+	findActiveVariantChannelListingsByVariantIDsQuery = selectVariantChannelListingColumns + `
+		FROM variant_channel_listings l
+		JOIN product_variants v
+			ON v.id = l.variant_id
+		WHERE l.variant_id = ANY($1::uuid[])
+		AND l.deleted_at IS NULL
+		AND l.is_active = true
+		ORDER BY l.variant_id, l.channel, l.updated_at DESC
+	`
+	softDeleteVariantChannelListingQuery = `
+		UPDATE variant_channel_listings
+		SET
+			deleted_at = NOW(),
+			deleted_by = $2,
+			updated_by = $2,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+)
+
+func (v *VariantChannelListing) InsertTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.VariantChannelListingData,
+) error {
+	_, err := tx.Exec(
+		ctx, insertVariantChannelListingQuery,
+		data.ID,
+		data.VariantID,
+		data.Channel,
+		data.ListingName,
+		data.VariationName,
+		data.ChannelSKU,
+		data.ListedPrice,
+		data.FeeCategory,
+		data.IsActive,
+		data.CreatedBy,
+		data.UpdatedBy,
+	)
+	return handleChannelListingUniqueViolation(err)
+}
+func (v *VariantChannelListing) UpdateTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	data *repository.VariantChannelListingData,
+) error {
+	result, err := tx.Exec(
+		ctx, updateVariantChannelListingQuery,
+		data.ID,
+		data.VariantID,
+		data.Channel,
+		data.ListingName,
+		data.VariationName,
+		data.ChannelSKU,
+		data.ListedPrice,
+		data.FeeCategory,
+		data.IsActive,
+		data.UpdatedBy,
+	)
+	if err != nil {
+		return handleChannelListingUniqueViolation(err)
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (v *VariantChannelListing) FindByID(
+	ctx context.Context,
+	listingID string,
+) (*repository.VariantChannelListingData, error) {
+	return scanVariantChannelListing(
+		v.db.QueryRow(ctx, findVariantChannelListingByIDQuery, listingID), nil)
+}
+func (v *VariantChannelListing) FindPaginated(
+	ctx context.Context,
+	filter *repository.VariantChannelListingFilter,
+) ([]repository.VariantChannelListingData, int, error) {
+	rows, err := v.db.Query(
+		ctx, findPaginatedVariantChannelListingsQuery,
+		filter.VariantID,
+		filter.Channel,
+		filter.Search,
+		filter.IsActive,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	listings := []repository.VariantChannelListingData{}
+	var totalCount int
+	for rows.Next() {
+		listing, err := scanVariantChannelListing(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		listings = append(listings, *listing)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return listings, totalCount, nil
+}
+func (v *VariantChannelListing) SoftDelete(
+	ctx context.Context,
+	listingID, deletedBy string,
+) error {
+	result, err := v.db.Exec(ctx, softDeleteVariantChannelListingQuery, listingID, deletedBy)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (v *VariantChannelListing) FindActiveByVariantIDs(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantIDs []string,
+) ([]repository.VariantChannelListingData, error) {
+	rows, err := tx.Query(
+		ctx, findActiveVariantChannelListingsByVariantIDsQuery, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	listings := []repository.VariantChannelListingData{}
+	for rows.Next() {
+		listing, err := scanVariantChannelListing(rows, nil)
+		if err != nil {
+			return nil, err
+		}
+		listings = append(listings, *listing)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return listings, nil
+}
+
+func handleChannelListingUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		pgErr.Code == constants.ErrCodePostgreUniqueViolation {
+		return ErrChannelListingAlreadyExists
+	}
+	return err
+}
+
+// scanVariantChannelListing scans the listing columns, totalCount is
+// scanned as the last column when it is not nil
+func scanVariantChannelListing(
+	row pgx.Row,
+	totalCount *int,
+) (*repository.VariantChannelListingData, error) {
+	var listing repository.VariantChannelListingData
+	var channel string
+	dest := []any{
+		&listing.ID,
+		&listing.VariantID,
+		&listing.VariantFullName,
+		&listing.VariantSKU,
+		&channel,
+		&listing.ListingName,
+		&listing.VariationName,
+		&listing.ChannelSKU,
+		&listing.ListedPrice,
+		&listing.FeeCategory,
+		&listing.IsActive,
+		&listing.CreatedBy,
+		&listing.UpdatedBy,
+		&listing.DeletedBy,
+		&listing.CreatedAt,
+		&listing.UpdatedAt,
+		&listing.DeletedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	listing.Channel = repository.OnlineChannel(channel)
+	return &listing, nil
+}
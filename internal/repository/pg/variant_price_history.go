@@ -0,0 +1,105 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type VariantPriceHistory struct {
+	db *pgxpool.Pool
+}
+
+func NewVariantPriceHistory(db *pgxpool.Pool) *VariantPriceHistory {
+	return &VariantPriceHistory{db: db}
+}
+
+const (
+	findPaginatedVariantPriceHistoryQuery = `
+		SELECT
+			id,
+			variant_id,
+			old_cost_price,
+			new_cost_price,
+			old_selling_price,
+			new_selling_price,
+			source,
+			reason,
+			schedule_id,
+			changed_by,
+			changed_at,
+			COUNT(*) OVER () AS total_count
+		FROM variant_price_history
+		WHERE variant_id = $1
+		AND ($2 = '' OR source::text = $2)
+		AND ($3 = '' OR changed_at >= NULLIF($3, '')::date)
+		AND ($4 = '' OR changed_at < NULLIF($4, '')::date + 1)
+		ORDER BY changed_at DESC, id
+		LIMIT $5 OFFSET $6
+	`
+)
+
+func (v *VariantPriceHistory) FindPaginated(
+	ctx context.Context,
+	filter *repository.VariantPriceHistoryFilter,
+) ([]repository.VariantPriceHistoryData, int, error) {
+	rows, err := v.db.Query(
+		ctx, findPaginatedVariantPriceHistoryQuery,
+		filter.VariantID,
+		filter.Source,
+		filter.StartDate,
+		filter.EndDate,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	history := []repository.VariantPriceHistoryData{}
+	var totalCount int
+	for rows.Next() {
+		change, err := scanVariantPriceHistory(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		history = append(history, *change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return history, totalCount, nil
+}
+
+// scanVariantPriceHistory scans the price history columns, totalCount is
+// scanned as the last column when it is not nil
+func scanVariantPriceHistory(
+	row pgx.Row,
+	totalCount *int,
+) (*repository.VariantPriceHistoryData, error) {
+	var change repository.VariantPriceHistoryData
+	var source string
+	dest := []any{
+		&change.ID,
+		&change.VariantID,
+		&change.OldCostPrice,
+		&change.NewCostPrice,
+		&change.OldSellingPrice,
+		&change.NewSellingPrice,
+		&source,
+		&change.Reason,
+		&change.ScheduleID,
+		&change.ChangedBy,
+		&change.ChangedAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	change.Source = repository.VariantPriceChangeSource(source)
+	return &change, nil
+}
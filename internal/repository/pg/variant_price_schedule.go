@@ -0,0 +1,228 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type VariantPriceSchedule struct {
+	db *pgxpool.Pool
+}
+
+func NewVariantPriceSchedule(db *pgxpool.Pool) *VariantPriceSchedule {
+	return &VariantPriceSchedule{db: db}
+}
+
+const (
+	insertVariantPriceScheduleQuery = `
+		INSERT INTO variant_price_schedules (
+			id,
+			variant_id,
+			cost_price,
+			selling_price,
+			effective_at,
+			reason,
+			status,
+			created_by,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		RETURNING created_at, updated_at
+	`
+	selectVariantPriceScheduleColumns = `
+		SELECT
+			id,
+			variant_id,
+			cost_price,
+			selling_price,
+			effective_at,
+			reason,
+			status,
+			applied_at,
+			created_by,
+			cancelled_by,
+			created_at,
+			updated_at,
+			cancelled_at
+	`
+	findPaginatedVariantPriceSchedulesQuery = selectVariantPriceScheduleColumns + `,
+			COUNT(*) OVER () AS total_count
+		FROM variant_price_schedules
+		WHERE variant_id = $1
+		AND ($2 = '' OR status::text = $2)
+		ORDER BY effective_at, id
+		LIMIT $3 OFFSET $4
+	`
+	cancelVariantPriceScheduleQuery = `
+		UPDATE variant_price_schedules
+		SET
+			status = 'CANCELLED',
+			cancelled_by = $3,
+			cancelled_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1
+		AND variant_id = $2
+		AND status = 'PENDING'
+	`
+	findDueVariantPriceSchedulesForUpdateQuery = selectVariantPriceScheduleColumns + `
+		FROM variant_price_schedules
+		WHERE status = 'PENDING'
+		AND effective_at <= $1
+		ORDER BY effective_at, created_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+	markVariantPriceScheduleAppliedQuery = `
+		UPDATE variant_price_schedules
+		SET
+			status = 'APPLIED',
+			applied_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1
+		AND status = 'PENDING'
+	`
+)
+
+func (v *VariantPriceSchedule) Insert(
+	ctx context.Context,
+	data *repository.VariantPriceScheduleData,
+) error {
+	return v.db.QueryRow(
+		ctx, insertVariantPriceScheduleQuery,
+		data.ID,
+		data.VariantID,
+		data.CostPrice,
+		data.SellingPrice,
+		data.EffectiveAt,
+		data.Reason,
+		data.Status,
+		data.CreatedBy,
+	).Scan(&data.CreatedAt, &data.UpdatedAt)
+}
+func (v *VariantPriceSchedule) FindPaginated(
+	ctx context.Context,
+	filter *repository.VariantPriceScheduleFilter,
+) ([]repository.VariantPriceScheduleData, int, error) {
+	rows, err := v.db.Query(
+		ctx, findPaginatedVariantPriceSchedulesQuery,
+		filter.VariantID,
+		filter.Status,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	schedules := []repository.VariantPriceScheduleData{}
+	var totalCount int
+	for rows.Next() {
+		schedule, err := scanVariantPriceSchedule(rows, &totalCount)
+		if err != nil {
+			return nil, 0, err
+		}
+		schedules = append(schedules, *schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return schedules, totalCount, nil
+}
+func (v *VariantPriceSchedule) CancelTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+	scheduleID string,
+	cancelledBy sql.NullString,
+) error {
+	result, err := tx.Exec(
+		ctx, cancelVariantPriceScheduleQuery,
+		scheduleID,
+		variantID,
+		cancelledBy,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+func (v *VariantPriceSchedule) FindDueForUpdate(
+	ctx context.Context,
+	tx pgx.Tx,
+	now time.Time,
+	limit int,
+) ([]repository.VariantPriceScheduleData, error) {
+	rows, err := tx.Query(
+		ctx, findDueVariantPriceSchedulesForUpdateQuery, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	schedules := []repository.VariantPriceScheduleData{}
+	for rows.Next() {
+		schedule, err := scanVariantPriceSchedule(rows, nil)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+func (v *VariantPriceSchedule) MarkAppliedTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	scheduleID string,
+) error {
+	result, err := tx.Exec(ctx, markVariantPriceScheduleAppliedQuery, scheduleID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// scanVariantPriceSchedule scans the schedule columns, totalCount is scanned
+// as the last column when it is not nil
+func scanVariantPriceSchedule(
+	row pgx.Row,
+	totalCount *int,
+) (*repository.VariantPriceScheduleData, error) {
+	var schedule repository.VariantPriceScheduleData
+	var status string
+	dest := []any{
+		&schedule.ID,
+		&schedule.VariantID,
+		&schedule.CostPrice,
+		&schedule.SellingPrice,
+		&schedule.EffectiveAt,
+		&schedule.Reason,
+		&status,
+		&schedule.AppliedAt,
+		&schedule.CreatedBy,
+		&schedule.CancelledBy,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+		&schedule.CancelledAt,
+	}
+	if totalCount != nil {
+		dest = append(dest, totalCount)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	schedule.Status = repository.VariantPriceScheduleStatus(status)
+	return &schedule, nil
+}
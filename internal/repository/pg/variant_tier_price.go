@@ -0,0 +1,106 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+type VariantTierPrice struct {
+	db *pgxpool.Pool
+}
+
+func NewVariantTierPrice(db *pgxpool.Pool) *VariantTierPrice {
+	return &VariantTierPrice{db: db}
+}
+
+const (
+	deleteVariantTierPricesQuery = `
+		DELETE FROM variant_tier_prices
+		WHERE variant_id = $1
+	`
+	insertVariantTierPriceQuery = `
+		INSERT INTO variant_tier_prices (
+			id,
+			variant_id,
+			price_tier,
+			min_quantity,
+			unit_price,
+			created_by,
+			created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`
+	findVariantTierPricesByVariantIDsQuery = `
+		SELECT
+			id,
+			variant_id,
+			price_tier,
+			min_quantity,
+			unit_price,
+			created_by,
+			created_at
+		FROM variant_tier_prices
+		WHERE variant_id = ANY($1::uuid[])
+		ORDER BY variant_id, price_tier, min_quantity
+	`
+)
+
+func (v *VariantTierPrice) ReplaceByVariantIDTransaction(
+	ctx context.Context,
+	tx pgx.Tx,
+	variantID string,
+	data []repository.VariantTierPriceData,
+) error {
+	if _, err := tx.Exec(ctx, deleteVariantTierPricesQuery, variantID); err != nil {
+		return err
+	}
+	for _, price := range data {
+		_, err := tx.Exec(
+			ctx, insertVariantTierPriceQuery,
+			price.ID,
+			variantID,
+			price.PriceTier,
+			price.MinQuantity,
+			price.UnitPrice,
+			price.CreatedBy,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (v *VariantTierPrice) FindByVariantIDs(
+	ctx context.Context,
+	variantIDs []string,
+) ([]repository.VariantTierPriceData, error) {
+	rows, err := v.db.Query(ctx, findVariantTierPricesByVariantIDsQuery, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	prices := []repository.VariantTierPriceData{}
+	for rows.Next() {
+		var price repository.VariantTierPriceData
+		var priceTier string
+		if err := rows.Scan(
+			&price.ID,
+			&price.VariantID,
+			&priceTier,
+			&price.MinQuantity,
+			&price.UnitPrice,
+			&price.CreatedBy,
+			&price.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		price.PriceTier = repository.CustomerPriceTier(priceTier)
+		prices = append(prices, price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -15,12 +17,28 @@ const (
 )
 
 type ProductData struct {
-	ID          string
-	BaseName    string
+	ID           string
+	BaseName     string
+	CategoryID   string
+	CategoryName string
+	CategoryCode string
+	ProductType  ProductType
+	CreatedBy    string
+	UpdatedBy    string
+	DeletedBy    sql.NullString
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    sql.NullTime
+}
+
+type ProductFilter struct {
 	CategoryID  string
-	ProductType ProductType
-	CreatedBy   string
-	UpdatedBy   string
+	ProductType string
+	IsActive    string
+	Name        string
+	Deleted     bool
+	Limit       int
+	Offset      int
 }
 
 type ProductRepository interface {
@@ -34,4 +52,36 @@ type ProductRepository interface {
 		tx pgx.Tx,
 		data *ProductData,
 	) error
+	FindByID(
+		ctx context.Context,
+		productID string,
+	) (*ProductData, error)
+	FindPaginated(
+		ctx context.Context,
+		filter *ProductFilter,
+	) ([]ProductData, int, error)
+	// StreamVariants calls fn for every variant of the products matching the
+	// filter without loading them all at once. Limit, Offset and Deleted are
+	// ignored, only products and variants that are not deleted are read.
+	StreamVariants(
+		ctx context.Context,
+		filter *ProductFilter,
+		fn func(data *ProductVariantData) error,
+	) error
+	// FindByIDForUpdate locks the product row, deleted or not
+	FindByIDForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		productID string,
+	) (*ProductData, error)
+	SoftDeleteTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *ProductData,
+	) error
+	RestoreTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *ProductData,
+	) error
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ProductErrorLogData struct {
+	ID           string
+	OrderNumber  string
+	CreatedDate  time.Time
+	ProductName  string
+	ErrorMessage string
+	CreatedBy    string
+}
+
+type ProductErrorLog interface {
+	InsertTransaction(ctx context.Context, tx pgx.Tx, data *ProductErrorLogData) error
+	// ResolveByOrderNumberTransaction soft deletes the open logs of an order,
+	// it runs before an order is imported again
+	ResolveByOrderNumberTransaction(ctx context.Context, tx pgx.Tx, orderNumber string) error
+}
@@ -3,48 +3,137 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 )
 
 type ProductVariantData struct {
-	ID              string
-	ProductID       string
-	ProductName     string
-	VariantName     sql.NullString
-	FullName        string
-	PackagingTypeID string
-	SizeValue       float32
-	SizeUnitID      string
-	CostPrice       decimal.NullDecimal
-	SellingPrice    decimal.Decimal
-	IsActive        bool
-	CreatedBy       string
-	UpdatedBy       string
-	RepackRecipe    *RepackRecipeData
-	Parent          *ProductData
+	ID                string
+	ProductID         string
+	SKU               string
+	ProductName       string
+	VariantName       sql.NullString
+	FullName          string
+	PackagingTypeID   string
+	PackagingTypeCode string
+	PackagingTypeName string
+	SizeValue         float32
+	SizeUnitID        string
+	SizeUnitCode      string
+	SizeUnitName      string
+	CostPrice         decimal.NullDecimal
+	SellingPrice      decimal.Decimal
+	IsActive          bool
+	CreatedBy         string
+	UpdatedBy         string
+	DeletedBy         sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         sql.NullTime
+	RepackRecipe      *RepackRecipeData
+	Parent            *ProductData
 }
 
 type ProductVariant interface {
+	// FindManyByID returns only id, full name, cost price and selling price of
+	// active variants
 	FindManyByID(
 		ctx context.Context,
 		tx pgx.Tx,
 		variantIDs []string,
 	) ([]ProductVariantData, error)
+	// FindCostPricesByID returns only id and cost price of non deleted
+	// variants, active or not
+	FindCostPricesByID(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantIDs []string,
+	) ([]ProductVariantData, error)
 	InsertTransaction(
 		ctx context.Context,
 		tx pgx.Tx,
 		data *ProductVariantData,
 	) error
+	// FindByProductID returns id, sku, cost price, selling price and product
+	// type of the active variants
 	FindByProductID(
 		ctx context.Context,
 		tx pgx.Tx,
 		productID string,
 	) ([]ProductVariantData, error)
+	// UpdateVariantForProductTypeSingleTransaction records the price change
+	// in the price history when the cost or selling price changes
 	UpdateVariantForProductTypeSingleTransaction(
 		ctx context.Context,
 		tx pgx.Tx,
 		data *ProductVariantData,
+		change *PriceChange,
+	) error
+	FindDetailByProductIDs(
+		ctx context.Context,
+		productIDs []string,
+	) ([]ProductVariantData, error)
+	FindSKUsByBase(
+		ctx context.Context,
+		tx pgx.Tx,
+		skuBase string,
+	) ([]string, error)
+	// FindByIDForUpdate locks the variant row, deleted or not
+	FindByIDForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantID string,
+	) (*ProductVariantData, error)
+	// FindIDsByProductIDAndDeletedAt returns non deleted variants when
+	// deletedAt is invalid, otherwise variants deleted at exactly deletedAt
+	FindIDsByProductIDAndDeletedAt(
+		ctx context.Context,
+		tx pgx.Tx,
+		productID string,
+		deletedAt sql.NullTime,
+	) ([]string, error)
+	// UpdateCostPriceTransaction records the price change in the price
+	// history when the cost price changes
+	UpdateCostPriceTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantID string,
+		costPrice decimal.NullDecimal,
+		updatedBy string,
+		change *PriceChange,
+	) error
+	// FindPricesForUpdate locks the variant row, deleted or not, and returns
+	// its cost and selling price
+	FindPricesForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantID string,
+	) (decimal.NullDecimal, decimal.Decimal, error)
+	// UpdatePricesTransaction keeps the current price for an invalid cost or
+	// selling price and records the price change in the price history, it
+	// returns pgx.ErrNoRows when the variant is deleted
+	UpdatePricesTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantID string,
+		costPrice decimal.NullDecimal,
+		sellingPrice decimal.NullDecimal,
+		updatedBy string,
+		change *PriceChange,
+	) error
+	SoftDeleteTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantIDs []string,
+		deletedBy string,
+		deletedAt time.Time,
+	) error
+	RestoreTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantIDs []string,
+		updatedBy string,
 	) error
 }
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// PurchaseOrderStatus mirrors the purchase_order_status enum
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusSent              PurchaseOrderStatus = "SENT"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "CLOSED"
+)
+
+// GoodsReceiptCostPolicy mirrors the goods_receipt_cost_policy enum
+type GoodsReceiptCostPolicy string
+
+const (
+	// GoodsReceiptCostPolicyLastCost sets the cost price to the received
+	// unit cost
+	GoodsReceiptCostPolicyLastCost GoodsReceiptCostPolicy = "LAST_COST"
+	// GoodsReceiptCostPolicyWeightedAverage averages the cost price of the
+	// stock on hand with the received unit cost
+	GoodsReceiptCostPolicyWeightedAverage GoodsReceiptCostPolicy = "WEIGHTED_AVERAGE"
+)
+
+type PurchaseOrderData struct {
+	ID string
+	// PONumber is generated by the database on insert
+	PONumber        string
+	SupplierID      string
+	SupplierName    string
+	OrderDate       time.Time
+	ExpectedDate    sql.NullTime
+	Status          PurchaseOrderStatus
+	PaymentTermDays int
+	TotalAmount     decimal.Decimal
+	Note            sql.NullString
+	SentAt          sql.NullTime
+	ClosedAt        sql.NullTime
+	CreatedBy       string
+	UpdatedBy       string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Items           []PurchaseOrderItemData
+	Receipts        []GoodsReceiptData
+}
+
+type PurchaseOrderItemData struct {
+	ID               string
+	PurchaseOrderID  string
+	VariantID        string
+	ProductName      string
+	Quantity         decimal.Decimal
+	UnitCost         decimal.Decimal
+	ReceivedQuantity decimal.Decimal
+}
+
+type GoodsReceiptData struct {
+	ID              string
+	PurchaseOrderID string
+	ReceiptDate     time.Time
+	// CostPolicy is invalid when the receipt kept the cost price unchanged
+	CostPolicy sql.NullString
+	Note       sql.NullString
+	CreatedBy  string
+	CreatedAt  time.Time
+	Items      []GoodsReceiptItemData
+}
+
+type GoodsReceiptItemData struct {
+	ID                  string
+	ReceiptID           string
+	PurchaseOrderItemID string
+	// VariantID and ProductName are read from the purchase order item
+	VariantID   string
+	ProductName string
+	Quantity    decimal.Decimal
+	UnitCost    decimal.Decimal
+	// PreviousCostPrice and NewCostPrice are only set when a cost policy
+	// updated the variant cost price
+	PreviousCostPrice decimal.NullDecimal
+	NewCostPrice      decimal.NullDecimal
+}
+
+type PurchaseOrderFilter struct {
+	PONumber   string
+	SupplierID string
+	Status     string
+	// StartDate and EndDate are inclusive order dates in YYYY-MM-DD format
+	StartDate string
+	EndDate   string
+	Limit     int
+	Offset    int
+}
+
+type PurchaseOrder interface {
+	// InsertTransaction inserts the order with its items and fills
+	// data.PONumber
+	InsertTransaction(ctx context.Context, tx pgx.Tx, data *PurchaseOrderData) error
+	// UpdateTransaction replaces the header fields and the items of the order
+	UpdateTransaction(ctx context.Context, tx pgx.Tx, data *PurchaseOrderData) error
+	UpdateStatusTransaction(ctx context.Context, tx pgx.Tx, data *PurchaseOrderData) error
+	// FindByID returns the order with its items and goods receipts
+	FindByID(ctx context.Context, purchaseOrderID string) (*PurchaseOrderData, error)
+	// FindByIDForUpdate locks the order row and returns it with its items
+	FindByIDForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		purchaseOrderID string,
+	) (*PurchaseOrderData, error)
+	// FindPaginated returns orders without items and goods receipts
+	FindPaginated(
+		ctx context.Context,
+		filter *PurchaseOrderFilter,
+	) ([]PurchaseOrderData, int, error)
+	// InsertReceiptTransaction inserts the goods receipt with its items and
+	// adds the received quantities to the order items
+	InsertReceiptTransaction(ctx context.Context, tx pgx.Tx, data *GoodsReceiptData) error
+}
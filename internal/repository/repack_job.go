@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type RepackJobStatus string
+
+const (
+	RepackJobStatusPlanned    RepackJobStatus = "PLANNED"
+	RepackJobStatusInProgress RepackJobStatus = "IN_PROGRESS"
+	RepackJobStatusDone       RepackJobStatus = "DONE"
+	RepackJobStatusCancelled  RepackJobStatus = "CANCELLED"
+)
+
+type RepackJobData struct {
+	ID              string
+	RecipeID        string
+	ParentVariantID string
+	ParentFullName  string
+	ChildVariantID  string
+	ChildFullName   string
+	QuantityRatio   float32
+	// Quantity is the amount of child produced
+	Quantity decimal.Decimal
+	// ParentQuantity is the amount of parent consumed
+	ParentQuantity decimal.Decimal
+	Status         RepackJobStatus
+	Operator       string
+	Note           sql.NullString
+	StartedAt      sql.NullTime
+	CompletedAt    sql.NullTime
+	CancelledAt    sql.NullTime
+	CreatedBy      string
+	UpdatedBy      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type RepackJobFilter struct {
+	Status    string
+	VariantID string
+	Limit     int
+	Offset    int
+}
+
+type RepackJob interface {
+	InsertTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *RepackJobData,
+	) error
+	UpdateStatusTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *RepackJobData,
+	) error
+	FindByIDForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		jobID string,
+	) (*RepackJobData, error)
+	FindByID(
+		ctx context.Context,
+		jobID string,
+	) (*RepackJobData, error)
+	FindPaginated(
+		ctx context.Context,
+		filter *RepackJobFilter,
+	) ([]RepackJobData, int, error)
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
@@ -11,11 +13,26 @@ type RepackRecipeData struct {
 	ID                string
 	ParentVariantID   string
 	ChildVariantID    string
+	ParentFullName    string
+	ParentCostPrice   decimal.NullDecimal
+	ChildFullName     string
 	QuantityRatio     float32
 	RepackCostPerUnit decimal.Decimal
 	RepackTimeMinutes int
 	CreatedBy         string
 	UpdatedBy         string
+	DeletedBy         sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         sql.NullTime
+}
+
+type RepackRecipeFilter struct {
+	ParentVariantID string
+	ChildVariantID  string
+	IsActive        string
+	Limit           int
+	Offset          int
 }
 
 type RepackRecipe interface {
@@ -24,4 +41,64 @@ type RepackRecipe interface {
 		tx pgx.Tx,
 		data *RepackRecipeData,
 	) error
+	FindActiveByParentVariantIDs(
+		ctx context.Context,
+		tx pgx.Tx,
+		parentVariantIDs []string,
+	) ([]RepackRecipeData, error)
+	FindActiveByChildVariantID(
+		ctx context.Context,
+		tx pgx.Tx,
+		childVariantID string,
+	) (*RepackRecipeData, error)
+	FindActiveByChildVariantIDs(
+		ctx context.Context,
+		tx pgx.Tx,
+		childVariantIDs []string,
+	) ([]RepackRecipeData, error)
+	FindByChildVariantIDsAndDeletedAt(
+		ctx context.Context,
+		tx pgx.Tx,
+		childVariantIDs []string,
+		deletedAt time.Time,
+	) ([]RepackRecipeData, error)
+	SoftDeleteByChildVariantIDsTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		childVariantIDs []string,
+		deletedBy string,
+		deletedAt time.Time,
+	) error
+	RestoreTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		recipeIDs []string,
+		updatedBy string,
+	) error
+	FindByID(
+		ctx context.Context,
+		recipeID string,
+	) (*RepackRecipeData, error)
+	// FindByIDForUpdate locks the recipe row, deactivated or not
+	FindByIDForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		recipeID string,
+	) (*RepackRecipeData, error)
+	FindPaginated(
+		ctx context.Context,
+		filter *RepackRecipeFilter,
+	) ([]RepackRecipeData, int, error)
+	UpdateTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *RepackRecipeData,
+	) error
+	DeactivateTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		recipeID string,
+		deletedBy string,
+		deletedAt time.Time,
+	) error
 }
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// SalePaymentMethod mirrors the sale_payment_method enum
+type SalePaymentMethod string
+
+const (
+	SalePaymentMethodCash     SalePaymentMethod = "CASH"
+	SalePaymentMethodQRIS     SalePaymentMethod = "QRIS"
+	SalePaymentMethodTransfer SalePaymentMethod = "TRANSFER"
+)
+
+// SaleStatus mirrors the sale_status enum
+type SaleStatus string
+
+const (
+	SaleStatusCompleted SaleStatus = "COMPLETED"
+	SaleStatusVoided    SaleStatus = "VOIDED"
+)
+
+type SaleData struct {
+	ID string
+	// SaleNumber is generated by the database on insert
+	SaleNumber      string
+	SaleDate        time.Time
+	PaymentMethod   SalePaymentMethod
+	Status          SaleStatus
+	SubtotalAmount  decimal.Decimal
+	DiscountAmount  decimal.Decimal
+	TotalAmount     decimal.Decimal
+	TotalCostAmount decimal.Decimal
+	TotalNetProfit  decimal.Decimal
+	PaidAmount      decimal.Decimal
+	ChangeAmount    decimal.Decimal
+	// TotalRefundAmount is the sum of the returns of the sale
+	TotalRefundAmount decimal.Decimal
+	Note              sql.NullString
+	// CustomerID is invalid for walk-in sales, PriceTier is the tier of the
+	// customer at sale time
+	CustomerID   sql.NullString
+	CustomerName sql.NullString
+	PriceTier    CustomerPriceTier
+	VoidReason   sql.NullString
+	VoidedBy     sql.NullString
+	VoidedAt     sql.NullTime
+	CreatedBy    string
+	UpdatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Items        []SaleItemData
+	Returns      []SaleReturnData
+}
+
+type SaleItemData struct {
+	ID          string
+	SaleID      string
+	VariantID   string
+	ProductName string
+	Quantity    int
+	UnitPrice   decimal.Decimal
+	// DiscountAmount is the discount given on this line only
+	DiscountAmount decimal.Decimal
+	// NetAmount is paid for the line after the line and sale discounts
+	NetAmount decimal.Decimal
+	// CostPrice is the variant cost price per unit at sale time
+	CostPrice        decimal.Decimal
+	ReturnedQuantity int
+	// SizeValue and SizeUnitCode are read from the variant
+	SizeValue    float32
+	SizeUnitCode string
+}
+
+type SaleReturnData struct {
+	ID           string
+	SaleID       string
+	ReturnDate   time.Time
+	RefundAmount decimal.Decimal
+	CostAmount   decimal.Decimal
+	Reason       sql.NullString
+	CreatedBy    string
+	CreatedAt    time.Time
+	Items        []SaleReturnItemData
+}
+
+type SaleReturnItemData struct {
+	ID           string
+	ReturnID     string
+	SaleItemID   string
+	Quantity     int
+	RefundAmount decimal.Decimal
+	CostAmount   decimal.Decimal
+}
+
+type SaleFilter struct {
+	SaleNumber    string
+	PaymentMethod string
+	CustomerID    string
+	Status        string
+	// StartDate and EndDate are inclusive dates in YYYY-MM-DD format
+	StartDate string
+	EndDate   string
+	Limit     int
+	Offset    int
+}
+
+type Sale interface {
+	// InsertTransaction inserts the sale with its items and fills
+	// data.SaleNumber
+	InsertTransaction(ctx context.Context, tx pgx.Tx, data *SaleData) error
+	// FindByID returns the sale with its items and returns
+	FindByID(ctx context.Context, saleID string) (*SaleData, error)
+	// FindByIDForUpdate locks the sale row and returns it with its items
+	FindByIDForUpdate(ctx context.Context, tx pgx.Tx, saleID string) (*SaleData, error)
+	// FindPaginated returns sales without items and returns
+	FindPaginated(ctx context.Context, filter *SaleFilter) ([]SaleData, int, error)
+	VoidTransaction(ctx context.Context, tx pgx.Tx, data *SaleData) error
+	// InsertReturnTransaction inserts the return with its items and adds the
+	// returned quantities to the sale items
+	InsertReturnTransaction(ctx context.Context, tx pgx.Tx, data *SaleReturnData) error
+}
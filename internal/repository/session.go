@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type SessionData struct {
+	ID                       string
+	Username                 string
+	RefreshTokenHash         string
+	PreviousRefreshTokenHash sql.NullString
+	DeviceName               sql.NullString
+	UserAgent                sql.NullString
+	IPAddress                sql.NullString
+	ExpiresAt                time.Time
+	LastUsedAt               time.Time
+	RevokedAt                sql.NullTime
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+}
+
+type Session interface {
+	Insert(ctx context.Context, data *SessionData) error
+	// FindByRefreshTokenHashForUpdate matches both the current and the
+	// previous refresh token hash so the caller can detect token reuse
+	FindByRefreshTokenHashForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		refreshTokenHash string,
+	) (*SessionData, error)
+	RotateTransaction(ctx context.Context, tx pgx.Tx, data *SessionData) error
+	RevokeTransaction(ctx context.Context, tx pgx.Tx, sessionID string) error
+	Revoke(ctx context.Context, sessionID, username string) error
+	RevokeAllByUsername(ctx context.Context, username string) (int64, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type ShopSettingData struct {
+	ShopName      string
+	Address       string
+	Phone         string
+	ReceiptHeader string
+	ReceiptFooter string
+	InvoiceFooter string
+	UpdatedBy     string
+	UpdatedAt     time.Time
+}
+
+type ShopSetting interface {
+	// Find returns the single shop settings row
+	Find(ctx context.Context) (*ShopSettingData, error)
+	Update(ctx context.Context, data *ShopSettingData) error
+}
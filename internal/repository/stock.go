@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type StockMovementType string
+
+const (
+	StockMovementTypePurchaseReceipt StockMovementType = "PURCHASE_RECEIPT"
+	StockMovementTypeSale            StockMovementType = "SALE"
+	StockMovementTypeAdjustment      StockMovementType = "ADJUSTMENT"
+	StockMovementTypeRepackConsume   StockMovementType = "REPACK_CONSUME"
+	StockMovementTypeRepackProduce   StockMovementType = "REPACK_PRODUCE"
+)
+
+type StockMovementData struct {
+	ID           string
+	VariantID    string
+	MovementType StockMovementType
+	// Quantity is signed, negative values remove stock
+	Quantity      decimal.Decimal
+	BalanceAfter  decimal.Decimal
+	ReferenceType sql.NullString
+	ReferenceID   sql.NullString
+	Note          sql.NullString
+	CreatedBy     string
+	CreatedAt     time.Time
+}
+
+type StockBalanceData struct {
+	VariantID string
+	SKU       string
+	FullName  string
+	Quantity  decimal.Decimal
+	UpdatedBy sql.NullString
+	UpdatedAt sql.NullTime
+}
+
+type StockMovementFilter struct {
+	VariantID    string
+	MovementType string
+	Limit        int
+	Offset       int
+}
+
+type StockLedger interface {
+	// InsertMovementTransaction appends the movement and applies it to the
+	// running balance of the variant, filling data.BalanceAfter.
+	InsertMovementTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		data *StockMovementData,
+	) error
+	FindBalanceByVariantID(
+		ctx context.Context,
+		variantID string,
+	) (*StockBalanceData, error)
+	FindPaginatedMovements(
+		ctx context.Context,
+		filter *StockMovementFilter,
+	) ([]StockMovementData, int, error)
+}
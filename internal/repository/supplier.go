@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type SupplierData struct {
+	ID          string
+	Name        string
+	ContactName sql.NullString
+	Phone       sql.NullString
+	Email       sql.NullString
+	Address     sql.NullString
+	// PaymentTermDays is the number of days until an order is due, 0 is cash
+	// on delivery
+	PaymentTermDays int
+	Note            sql.NullString
+	IsActive        bool
+	CreatedBy       string
+	UpdatedBy       string
+	DeletedBy       sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       sql.NullTime
+}
+
+type SupplierFilter struct {
+	// Search matches the supplier name, contact name or phone
+	Search   string
+	IsActive string
+	Limit    int
+	Offset   int
+}
+
+type Supplier interface {
+	Insert(ctx context.Context, data *SupplierData) error
+	Update(ctx context.Context, data *SupplierData) error
+	// FindByID returns a non deleted supplier
+	FindByID(ctx context.Context, supplierID string) (*SupplierData, error)
+	FindPaginated(ctx context.Context, filter *SupplierFilter) ([]SupplierData, int, error)
+	SoftDelete(ctx context.Context, supplierID, deletedBy string) error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type UserData struct {
+	Username     string
+	HashPassword string
+	Role         string
+	IsActive     bool
+	LastLogin    sql.NullTime
+	CreatedBy    sql.NullString
+	UpdatedBy    sql.NullString
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type UserFilter struct {
+	Username string
+	Role     string
+	IsActive string
+	Limit    int
+	Offset   int
+}
+
+type User interface {
+	Insert(ctx context.Context, data *UserData) error
+	FindByUsername(ctx context.Context, username string) (*UserData, error)
+	FindPaginated(ctx context.Context, filter *UserFilter) ([]UserData, int, error)
+	// UpdateRole also revokes every session of the user so the old role
+	// claim is rejected
+	UpdateRole(ctx context.Context, username, role, updatedBy string) error
+	// UpdatePassword revokes every session of the user except keepSessionID,
+	// an empty keepSessionID revokes them all
+	UpdatePassword(
+		ctx context.Context,
+		username, hashPassword, updatedBy string,
+		keepSessionID string,
+	) error
+	// UpdateStatus revokes every session of the user when the user is disabled
+	UpdateStatus(ctx context.Context, username string, isActive bool, updatedBy string) error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type VariantChannelListingData struct {
+	ID              string
+	VariantID       string
+	VariantFullName string
+	VariantSKU      sql.NullString
+	Channel         OnlineChannel
+	ListingName     string
+	VariationName   sql.NullString
+	ChannelSKU      sql.NullString
+	ListedPrice     decimal.NullDecimal
+	FeeCategory     sql.NullString
+	IsActive        bool
+	CreatedBy       string
+	UpdatedBy       string
+	DeletedBy       sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       sql.NullTime
+}
+
+type VariantChannelListingFilter struct {
+	VariantID string
+	Channel   string
+	// Search matches the listing name, variation name or channel sku
+	Search   string
+	IsActive string
+	Limit    int
+	Offset   int
+}
+
+type VariantChannelListing interface {
+	InsertTransaction(ctx context.Context, tx pgx.Tx, data *VariantChannelListingData) error
+	UpdateTransaction(ctx context.Context, tx pgx.Tx, data *VariantChannelListingData) error
+	// FindByID returns a non deleted listing
+	FindByID(ctx context.Context, listingID string) (*VariantChannelListingData, error)
+	FindPaginated(
+		ctx context.Context,
+		filter *VariantChannelListingFilter,
+	) ([]VariantChannelListingData, int, error)
+	SoftDelete(ctx context.Context, listingID, deletedBy string) error
+	// FindActiveByVariantIDs returns the active listings of the variants
+	FindActiveByVariantIDs(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantIDs []string,
+	) ([]VariantChannelListingData, error)
+}
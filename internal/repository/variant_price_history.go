@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// VariantPriceChangeSource mirrors the variant_price_change_source enum
+type VariantPriceChangeSource string
+
+const (
+	VariantPriceChangeSourceManual       VariantPriceChangeSource = "MANUAL"
+	VariantPriceChangeSourceRepackCost   VariantPriceChangeSource = "REPACK_COST"
+	VariantPriceChangeSourceGoodsReceipt VariantPriceChangeSource = "GOODS_RECEIPT"
+	VariantPriceChangeSourceScheduled    VariantPriceChangeSource = "SCHEDULED"
+)
+
+// PriceChange describes why the prices of a variant are written, it is
+// recorded in the price history when the cost or selling price changes
+type PriceChange struct {
+	Source VariantPriceChangeSource
+	Reason sql.NullString
+	// ScheduleID is set when a scheduled price change is applied
+	ScheduleID sql.NullString
+}
+
+type VariantPriceHistoryData struct {
+	ID              string
+	VariantID       string
+	OldCostPrice    decimal.NullDecimal
+	NewCostPrice    decimal.NullDecimal
+	OldSellingPrice decimal.Decimal
+	NewSellingPrice decimal.Decimal
+	Source          VariantPriceChangeSource
+	Reason          sql.NullString
+	ScheduleID      sql.NullString
+	ChangedBy       string
+	ChangedAt       time.Time
+}
+
+type VariantPriceHistoryFilter struct {
+	VariantID string
+	Source    string
+	// StartDate and EndDate are inclusive dates in YYYY-MM-DD format
+	StartDate string
+	EndDate   string
+	Limit     int
+	Offset    int
+}
+
+// VariantPriceHistory reads the price history, rows are written by the
+// price updates of ProductVariant
+type VariantPriceHistory interface {
+	// FindPaginated returns the newest changes first
+	FindPaginated(
+		ctx context.Context,
+		filter *VariantPriceHistoryFilter,
+	) ([]VariantPriceHistoryData, int, error)
+}
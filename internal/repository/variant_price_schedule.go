@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// VariantPriceScheduleStatus mirrors the variant_price_schedule_status enum
+type VariantPriceScheduleStatus string
+
+const (
+	VariantPriceScheduleStatusPending   VariantPriceScheduleStatus = "PENDING"
+	VariantPriceScheduleStatusApplied   VariantPriceScheduleStatus = "APPLIED"
+	VariantPriceScheduleStatusCancelled VariantPriceScheduleStatus = "CANCELLED"
+)
+
+type VariantPriceScheduleData struct {
+	ID        string
+	VariantID string
+	// CostPrice and SellingPrice are invalid when the current price is kept
+	CostPrice    decimal.NullDecimal
+	SellingPrice decimal.NullDecimal
+	EffectiveAt  time.Time
+	Reason       sql.NullString
+	Status       VariantPriceScheduleStatus
+	AppliedAt    sql.NullTime
+	CreatedBy    string
+	CancelledBy  sql.NullString
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CancelledAt  sql.NullTime
+}
+
+type VariantPriceScheduleFilter struct {
+	VariantID string
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+type VariantPriceSchedule interface {
+	Insert(ctx context.Context, data *VariantPriceScheduleData) error
+	// FindPaginated returns the schedules ordered by effective time
+	FindPaginated(
+		ctx context.Context,
+		filter *VariantPriceScheduleFilter,
+	) ([]VariantPriceScheduleData, int, error)
+	// CancelTransaction cancels a pending schedule of the variant,
+	// cancelledBy is invalid when the schedule is cancelled by the system
+	CancelTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantID string,
+		scheduleID string,
+		cancelledBy sql.NullString,
+	) error
+	// FindDueForUpdate locks the pending schedules effective at or before
+	// now, schedules locked by another transaction are skipped
+	FindDueForUpdate(
+		ctx context.Context,
+		tx pgx.Tx,
+		now time.Time,
+		limit int,
+	) ([]VariantPriceScheduleData, error)
+	MarkAppliedTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		scheduleID string,
+	) error
+}
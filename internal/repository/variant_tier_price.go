@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type VariantTierPriceData struct {
+	ID        string
+	VariantID string
+	PriceTier CustomerPriceTier
+	// MinQuantity is 1 for the price of the tier, higher values are quantity
+	// breaks
+	MinQuantity int
+	UnitPrice   decimal.Decimal
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+type VariantTierPrice interface {
+	// ReplaceByVariantIDTransaction deletes the tier prices of the variant and
+	// inserts data in their place
+	ReplaceByVariantIDTransaction(
+		ctx context.Context,
+		tx pgx.Tx,
+		variantID string,
+		data []VariantTierPriceData,
+	) error
+	// FindByVariantIDs returns the tier prices ordered by variant, tier and
+	// minimum quantity
+	FindByVariantIDs(ctx context.Context, variantIDs []string) ([]VariantTierPriceData, error)
+}
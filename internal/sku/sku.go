@@ -0,0 +1,112 @@
+// Package sku builds the variant SKUs shared by the product service and the
+// tools that insert variants directly
+package sku
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rizkysr90/rizkiplastik-be/internal/repository"
+)
+
+// UpdatePolicy decides what happens to an existing SKU when the attributes it
+// was built from are changed by an update.
+type UpdatePolicy string
+
+const (
+	// UpdatePolicyRegenerate builds a new SKU from the updated attributes
+	UpdatePolicyRegenerate UpdatePolicy = "REGENERATE"
+	// UpdatePolicyFreeze keeps the SKU assigned at creation time
+	UpdatePolicyFreeze UpdatePolicy = "FREEZE"
+)
+
+// Base composes the human readable part of a variant SKU,
+// e.g. category PLS, packaging B, 500 GR becomes "PLS-B-500GR".
+func Base(
+	categoryCode string,
+	packagingTypeCode string,
+	sizeValue float32,
+	sizeUnitCode string,
+) string {
+	return strings.ToUpper(categoryCode + "-" +
+		packagingTypeCode + "-" +
+		strconv.FormatFloat(float64(sizeValue), 'f', -1, 32) +
+		sizeUnitCode)
+}
+
+// Builder hands out unique SKUs. SKUs reserved by earlier calls are treated
+// as taken, so variants inserted in the same request never collide with
+// each other.
+type Builder struct {
+	productVariantRepository repository.ProductVariant
+	reserved                 map[string]bool
+}
+
+func NewBuilder(productVariantRepository repository.ProductVariant) *Builder {
+	return &Builder{
+		productVariantRepository: productVariantRepository,
+		reserved:                 make(map[string]bool),
+	}
+}
+
+// Build returns skuBase when it is free, otherwise skuBase with the first
+// free collision suffix ("-2", "-3", ...).
+func (b *Builder) Build(
+	ctx context.Context,
+	tx pgx.Tx,
+	skuBase string,
+) (string, error) {
+	existingSKUs, err := b.productVariantRepository.FindSKUsByBase(ctx, tx, skuBase)
+	if err != nil {
+		return "", err
+	}
+	taken := make(map[string]bool, len(existingSKUs))
+	for _, sku := range existingSKUs {
+		taken[sku] = true
+	}
+	candidate := skuBase
+	for suffix := 2; taken[candidate] || b.reserved[candidate]; suffix++ {
+		candidate = skuBase + "-" + strconv.Itoa(suffix)
+	}
+	b.reserved[candidate] = true
+	return candidate, nil
+}
+
+// Resolve returns the SKU a variant should have after an update. The current
+// SKU is kept when the policy is freeze or when it was already built from
+// the same attributes.
+func (b *Builder) Resolve(
+	ctx context.Context,
+	tx pgx.Tx,
+	policy UpdatePolicy,
+	currentSKU string,
+	skuBase string,
+) (string, error) {
+	if currentSKU != "" {
+		if policy == UpdatePolicyFreeze || isFromBase(currentSKU, skuBase) {
+			b.reserved[currentSKU] = true
+			return currentSKU, nil
+		}
+	}
+	return b.Build(ctx, tx, skuBase)
+}
+
+// Release hands a reserved SKU back when the variant it was built for is not
+// inserted after all
+func (b *Builder) Release(sku string) {
+	delete(b.reserved, sku)
+}
+
+func isFromBase(sku, skuBase string) bool {
+	if sku == skuBase {
+		return true
+	}
+	suffix, found := strings.CutPrefix(sku, skuBase+"-")
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
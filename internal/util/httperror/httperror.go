@@ -56,3 +56,36 @@ func NewInternalServer(ctx context.Context, opts ...Option) *HTTPError {
 	}
 	return httpError
 }
+func NewUnauthorized(ctx context.Context, opts ...Option) *HTTPError {
+	httpError := &HTTPError{
+		Code:    http.StatusUnauthorized,
+		Info:    "UNAUTHORIZED",
+		Message: "",
+	}
+	for _, opt := range opts {
+		opt(httpError)
+	}
+	return httpError
+}
+func NewForbidden(ctx context.Context, opts ...Option) *HTTPError {
+	httpError := &HTTPError{
+		Code:    http.StatusForbidden,
+		Info:    "FORBIDDEN",
+		Message: "",
+	}
+	for _, opt := range opts {
+		opt(httpError)
+	}
+	return httpError
+}
+func NewConflict(ctx context.Context, opts ...Option) *HTTPError {
+	httpError := &HTTPError{
+		Code:    http.StatusConflict,
+		Info:    "CONFLICT",
+		Message: "",
+	}
+	for _, opt := range opts {
+		opt(httpError)
+	}
+	return httpError
+}
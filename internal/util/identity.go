@@ -0,0 +1,45 @@
+package util
+
+import (
+	"context"
+
+	"github.com/rizkysr90/rizkiplastik-be/internal/util/httperror"
+)
+
+// ContextKeyIdentity is the key RequireAuth uses to store the caller identity
+const ContextKeyIdentity = "identity"
+
+// Identity is the authenticated caller taken from the JWT claims
+type Identity struct {
+	Username string
+	Role     string
+	// SessionID is the user_sessions row the access token was issued for
+	SessionID string
+	// IPAddress and UserAgent describe where the request came from, they are
+	// recorded in the auth audit trail
+	IPAddress string
+	UserAgent string
+}
+
+// GetIdentity extracts the caller identity from the provided context.
+// Handlers pass *gin.Context to services, so values stored with c.Set are
+// visible here. It returns an unauthorized error when no identity is set.
+func GetIdentity(ctx context.Context) (*Identity, error) {
+	identity, ok := ctx.Value(ContextKeyIdentity).(*Identity)
+	if !ok || identity == nil || identity.Username == "" {
+		return nil, httperror.NewUnauthorized(ctx, httperror.WithMessage(
+			"user identity not found in request context",
+		))
+	}
+	return identity, nil
+}
+
+// GetUserID returns the username of the caller, used for created_by and
+// updated_by columns.
+func GetUserID(ctx context.Context) (string, error) {
+	identity, err := GetIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+	return identity.Username, nil
+}
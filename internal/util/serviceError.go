@@ -19,6 +19,8 @@ func HandleServiceError(c *gin.Context, err error) {
 			c.JSON(http.StatusUnauthorized, httpError)
 		case http.StatusForbidden:
 			c.JSON(http.StatusForbidden, httpError)
+		case http.StatusConflict:
+			c.JSON(http.StatusConflict, httpError)
 		default:
 			c.JSON(http.StatusInternalServerError, httpError)
 		}
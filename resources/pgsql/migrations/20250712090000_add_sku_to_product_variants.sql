@@ -0,0 +1,40 @@
+-- migrate:up
+ALTER TABLE product_variants ADD COLUMN sku VARCHAR(50) NULL;
+
+-- Backfill existing variants using the same format as the application:
+-- <category code>-<packaging code>-<size value><size unit code>[-<n>]
+UPDATE product_variants target
+SET sku = generated.sku
+FROM (
+    SELECT
+        base.id,
+        base.sku_base || CASE
+            WHEN base.rn > 1 THEN '-' || base.rn
+            ELSE ''
+        END AS sku
+    FROM (
+        SELECT
+            pv.id,
+            pc.code || '-' || pt.code || '-' ||
+            TRIM(TRAILING '.' FROM TRIM(TRAILING '0' FROM pv.size_value::text)) ||
+            su.code AS sku_base,
+            ROW_NUMBER() OVER (
+                PARTITION BY pc.code, pt.code, pv.size_value, su.code
+                ORDER BY pv.created_at, pv.id
+            ) AS rn
+        FROM product_variants pv
+        JOIN products p ON p.id = pv.product_id
+        JOIN product_categories pc ON pc.id = p.category_id
+        JOIN packaging_types pt ON pt.id = pv.packaging_type_id
+        JOIN size_units su ON su.id = pv.size_unit_id
+    ) base
+) generated
+WHERE target.id = generated.id;
+
+CREATE UNIQUE INDEX idx_unique_product_variants_sku
+ON product_variants (sku)
+WHERE sku IS NOT NULL;
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_unique_product_variants_sku;
+ALTER TABLE product_variants DROP COLUMN sku;
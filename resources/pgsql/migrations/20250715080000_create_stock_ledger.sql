@@ -0,0 +1,40 @@
+-- migrate:up
+CREATE TYPE stock_movement_type AS ENUM (
+    'PURCHASE_RECEIPT',
+    'SALE',
+    'ADJUSTMENT',
+    'REPACK_CONSUME',
+    'REPACK_PRODUCE'
+);
+
+-- Running balance per variant, updated in the same transaction as the movement
+CREATE TABLE IF NOT EXISTS variant_stocks (
+    variant_id UUID PRIMARY KEY REFERENCES product_variants(id),
+    quantity DECIMAL(14, 4) NOT NULL DEFAULT 0,
+    updated_by VARCHAR(30) NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY,
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    movement_type stock_movement_type NOT NULL,
+    -- positive adds stock, negative removes stock
+    quantity DECIMAL(14, 4) NOT NULL,
+    balance_after DECIMAL(14, 4) NOT NULL,
+    reference_type VARCHAR(30) NULL,
+    reference_id UUID NULL,
+    note VARCHAR(255) NULL,
+    created_by VARCHAR(30) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_variant_created_at
+ON stock_movements (variant_id, created_at DESC);
+CREATE INDEX idx_stock_movements_reference
+ON stock_movements (reference_type, reference_id);
+
+-- migrate:down
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS variant_stocks;
+DROP TYPE IF EXISTS stock_movement_type;
@@ -0,0 +1,36 @@
+-- migrate:up
+CREATE TYPE repack_job_status AS ENUM (
+    'PLANNED',
+    'IN_PROGRESS',
+    'DONE',
+    'CANCELLED'
+);
+
+CREATE TABLE IF NOT EXISTS repack_jobs (
+    id UUID PRIMARY KEY,
+    recipe_id UUID NOT NULL REFERENCES product_repack_recipes(id),
+    parent_variant_id UUID NOT NULL REFERENCES product_variants(id),
+    child_variant_id UUID NOT NULL REFERENCES product_variants(id),
+    -- ratio is copied from the recipe so later recipe edits keep job history intact
+    quantity_ratio DECIMAL(10, 2) NOT NULL,
+    -- quantity of child produced and quantity of parent consumed
+    quantity DECIMAL(14, 4) NOT NULL,
+    parent_quantity DECIMAL(14, 4) NOT NULL,
+    status repack_job_status NOT NULL DEFAULT 'PLANNED',
+    operator VARCHAR(30) NOT NULL,
+    note VARCHAR(255) NULL,
+    started_at timestamptz NULL,
+    completed_at timestamptz NULL,
+    cancelled_at timestamptz NULL,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_repack_jobs_status_created_at
+ON repack_jobs (status, created_at DESC);
+
+-- migrate:down
+DROP TABLE IF EXISTS repack_jobs;
+DROP TYPE IF EXISTS repack_job_status;
@@ -0,0 +1,15 @@
+-- migrate:up
+ALTER TABLE users
+ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN created_by VARCHAR(30) NULL,
+ADD COLUMN updated_by VARCHAR(30) NULL,
+ADD COLUMN created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+ADD COLUMN updated_at timestamptz DEFAULT CURRENT_TIMESTAMP;
+
+-- migrate:down
+ALTER TABLE users
+DROP COLUMN IF EXISTS updated_at,
+DROP COLUMN IF EXISTS created_at,
+DROP COLUMN IF EXISTS updated_by,
+DROP COLUMN IF EXISTS created_by,
+DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1,35 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id UUID PRIMARY KEY,
+    username VARCHAR(30) NOT NULL REFERENCES users(username),
+    -- only the sha256 hex digest of the refresh token is stored
+    refresh_token_hash VARCHAR(64) NOT NULL,
+    -- the digest that was rotated out, presenting it again means the refresh
+    -- token was stolen and the whole session is revoked
+    previous_refresh_token_hash VARCHAR(64) NULL,
+    device_name VARCHAR(100) NULL,
+    user_agent VARCHAR(255) NULL,
+    ip_address VARCHAR(45) NULL,
+    expires_at timestamptz NOT NULL,
+    last_used_at timestamptz NOT NULL,
+    revoked_at timestamptz NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_user_sessions_refresh_token_hash
+ON user_sessions (refresh_token_hash);
+
+CREATE INDEX idx_user_sessions_previous_refresh_token_hash
+ON user_sessions (previous_refresh_token_hash);
+
+CREATE INDEX idx_user_sessions_username
+ON user_sessions (username)
+WHERE revoked_at IS NULL;
+
+-- sessions replace the single token column
+ALTER TABLE users DROP COLUMN IF EXISTS token;
+
+-- migrate:down
+ALTER TABLE users ADD COLUMN IF NOT EXISTS token TEXT;
+DROP TABLE IF EXISTS user_sessions;
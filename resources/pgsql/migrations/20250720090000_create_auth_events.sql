@@ -0,0 +1,44 @@
+-- migrate:up
+CREATE TYPE auth_event_type AS ENUM (
+    'LOGIN_SUCCESS',
+    'LOGIN_FAILURE',
+    'LOGIN_LOCKED',
+    'TOKEN_REVOKED',
+    'PASSWORD_CHANGED'
+);
+
+CREATE TABLE IF NOT EXISTS auth_events (
+    id UUID PRIMARY KEY,
+    event_type auth_event_type NOT NULL,
+    -- no foreign key, failed logins record unknown usernames too
+    username VARCHAR(30) NULL,
+    -- actor is the user that triggered the event when it is not the user
+    -- itself, e.g. an admin resetting a password
+    actor VARCHAR(30) NULL,
+    session_id UUID NULL,
+    reason VARCHAR(50) NULL,
+    ip_address VARCHAR(45) NULL,
+    user_agent VARCHAR(255) NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auth_events_created_at
+ON auth_events (created_at DESC);
+
+CREATE INDEX idx_auth_events_username_created_at
+ON auth_events (username, created_at DESC);
+
+-- failed login counters, scope is USERNAME or IP
+CREATE TABLE IF NOT EXISTS login_attempts (
+    scope VARCHAR(10) NOT NULL,
+    attempt_key VARCHAR(64) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failed_at timestamptz NOT NULL,
+    locked_until timestamptz NULL,
+    PRIMARY KEY (scope, attempt_key)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS login_attempts;
+DROP TABLE IF EXISTS auth_events;
+DROP TYPE IF EXISTS auth_event_type;
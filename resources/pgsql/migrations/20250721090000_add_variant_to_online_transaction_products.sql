@@ -0,0 +1,11 @@
+-- migrate:up
+ALTER TABLE online_transaction_products
+ADD COLUMN variant_id UUID NULL REFERENCES product_variants(id);
+
+CREATE INDEX idx_online_transactions_type_order_number
+ON online_transactions (type, order_number)
+WHERE deleted_at IS NULL;
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_online_transactions_type_order_number;
+ALTER TABLE online_transaction_products DROP COLUMN IF EXISTS variant_id;
@@ -0,0 +1,38 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS variant_channel_listings (
+    id UUID PRIMARY KEY,
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    channel ONLINE_CHANNEL NOT NULL,
+    -- listing and variation name exactly as the marketplace shows them on
+    -- orders, importers match order lines by these names
+    listing_name VARCHAR(250) NOT NULL,
+    variation_name VARCHAR(100) NULL,
+    channel_sku VARCHAR(100) NULL,
+    listed_price DECIMAL(12, 2) NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    deleted_by VARCHAR(30) NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz NULL
+);
+
+CREATE UNIQUE INDEX idx_variant_channel_listings_channel_name
+ON variant_channel_listings (
+    channel,
+    LOWER(listing_name),
+    LOWER(COALESCE(variation_name, ''))
+)
+WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX idx_variant_channel_listings_channel_sku
+ON variant_channel_listings (channel, channel_sku)
+WHERE deleted_at IS NULL AND channel_sku IS NOT NULL;
+
+CREATE INDEX idx_variant_channel_listings_variant_id
+ON variant_channel_listings (variant_id)
+WHERE deleted_at IS NULL;
+
+-- migrate:down
+DROP TABLE IF EXISTS variant_channel_listings;
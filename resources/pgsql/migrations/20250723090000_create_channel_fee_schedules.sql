@@ -0,0 +1,75 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS channel_fee_schedules (
+    id UUID PRIMARY KEY,
+    channel ONLINE_CHANNEL NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    -- a schedule applies from effective_from until the next schedule of the
+    -- same channel starts
+    effective_from DATE NOT NULL,
+    -- free delivery program fee, charged on top of the category admin fee
+    free_delivery_fee_percentage DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    free_delivery_fee_cap DECIMAL(12, 2) NULL,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    deleted_by VARCHAR(30) NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz NULL
+);
+
+CREATE UNIQUE INDEX idx_channel_fee_schedules_channel_effective_from
+ON channel_fee_schedules (channel, effective_from)
+WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS channel_fee_schedule_rates (
+    id UUID PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES channel_fee_schedules(id) ON DELETE CASCADE,
+    -- seller category of the channel, e.g. A to E on shopee
+    category VARCHAR(30) NOT NULL,
+    admin_fee_percentage DECIMAL(5, 2) NOT NULL,
+    admin_fee_cap DECIMAL(12, 2) NULL,
+    UNIQUE (schedule_id, category)
+);
+
+-- Seed the shopee rates that used to be hard coded in the pricing code
+WITH schedule AS (
+    INSERT INTO channel_fee_schedules (
+        id,
+        channel,
+        name,
+        effective_from,
+        free_delivery_fee_percentage,
+        free_delivery_fee_cap,
+        created_by,
+        updated_by
+    ) VALUES (
+        gen_random_uuid(),
+        'SHOPEE',
+        'Shopee initial rates',
+        '2025-01-01',
+        4.00,
+        20000,
+        'system',
+        'system'
+    )
+    RETURNING id
+)
+INSERT INTO channel_fee_schedule_rates (
+    id,
+    schedule_id,
+    category,
+    admin_fee_percentage
+)
+SELECT gen_random_uuid(), schedule.id, rates.category, rates.admin_fee_percentage
+FROM schedule
+CROSS JOIN (VALUES
+    ('A', 10.00),
+    ('B', 7.50),
+    ('C', 5.75),
+    ('D', 5.75),
+    ('E', 5.75)
+) AS rates(category, admin_fee_percentage);
+
+-- migrate:down
+DROP TABLE IF EXISTS channel_fee_schedule_rates;
+DROP TABLE IF EXISTS channel_fee_schedules;
@@ -0,0 +1,7 @@
+-- migrate:up
+-- fee_category is the seller category of the listing on the channel, it
+-- selects the admin fee rate of the channel fee schedule
+ALTER TABLE variant_channel_listings ADD COLUMN fee_category VARCHAR(30) NULL;
+
+-- migrate:down
+ALTER TABLE variant_channel_listings DROP COLUMN fee_category;
@@ -0,0 +1,14 @@
+-- migrate:up
+-- legacy_product_migrations records which products_old rows were moved to
+-- the product and variant model, so the migration can run again safely
+CREATE TABLE IF NOT EXISTS legacy_product_migrations (
+    legacy_product_id UUID PRIMARY KEY REFERENCES products_old(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    listing_id UUID NULL REFERENCES variant_channel_listings(id),
+    migrated_by VARCHAR(30) NOT NULL,
+    migrated_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS legacy_product_migrations;
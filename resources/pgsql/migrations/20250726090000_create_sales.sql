@@ -0,0 +1,173 @@
+-- migrate:up
+CREATE TYPE sale_payment_method AS ENUM ('CASH', 'QRIS', 'TRANSFER');
+CREATE TYPE sale_status AS ENUM ('COMPLETED', 'VOIDED');
+
+CREATE SEQUENCE IF NOT EXISTS sale_number_seq;
+
+-- In-store sales, amounts are computed by the server from the items
+CREATE TABLE IF NOT EXISTS sales (
+    id UUID PRIMARY KEY,
+    sale_number VARCHAR(30) NOT NULL,
+    sale_date DATE NOT NULL,
+    payment_method sale_payment_method NOT NULL,
+    status sale_status NOT NULL DEFAULT 'COMPLETED',
+    -- sum of the item amounts after item discounts
+    subtotal_amount DECIMAL(12, 2) NOT NULL,
+    -- discount on the whole sale, spread over the item net amounts
+    discount_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    total_amount DECIMAL(12, 2) NOT NULL,
+    total_cost_amount DECIMAL(12, 2) NOT NULL,
+    total_net_profit DECIMAL(12, 2) NOT NULL,
+    paid_amount DECIMAL(12, 2) NOT NULL,
+    change_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    note VARCHAR(255) NULL,
+    void_reason VARCHAR(255) NULL,
+    voided_by VARCHAR(30) NULL,
+    voided_at timestamptz NULL,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_unique_sales_sale_number ON sales (sale_number);
+CREATE INDEX idx_sales_sale_date ON sales (sale_date DESC, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS sale_items (
+    id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES sales(id),
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    -- variant full name at sale time
+    product_name VARCHAR(100) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(12, 2) NOT NULL,
+    discount_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    -- amount paid for the line after item and sale discounts
+    net_amount DECIMAL(12, 2) NOT NULL,
+    -- variant cost price per unit at sale time
+    cost_price DECIMAL(12, 2) NOT NULL,
+    returned_quantity INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT chk_sale_items_returned_quantity
+        CHECK (returned_quantity >= 0 AND returned_quantity <= quantity)
+);
+
+CREATE INDEX idx_sale_items_sale_id ON sale_items (sale_id);
+
+CREATE TABLE IF NOT EXISTS sale_returns (
+    id UUID PRIMARY KEY,
+    sale_id UUID NOT NULL REFERENCES sales(id),
+    return_date DATE NOT NULL,
+    refund_amount DECIMAL(12, 2) NOT NULL,
+    cost_amount DECIMAL(12, 2) NOT NULL,
+    reason VARCHAR(255) NULL,
+    created_by VARCHAR(30) NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sale_returns_sale_id ON sale_returns (sale_id);
+CREATE INDEX idx_sale_returns_return_date ON sale_returns (return_date);
+
+CREATE TABLE IF NOT EXISTS sale_return_items (
+    id UUID PRIMARY KEY,
+    return_id UUID NOT NULL REFERENCES sale_returns(id),
+    sale_item_id UUID NOT NULL REFERENCES sale_items(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    refund_amount DECIMAL(12, 2) NOT NULL,
+    cost_amount DECIMAL(12, 2) NOT NULL
+);
+
+CREATE INDEX idx_sale_return_items_return_id ON sale_return_items (return_id);
+
+-- Orders of every channel for the profit summary. Voided sales are left out
+-- and returns count negatively on the day they are made.
+CREATE VIEW summary_transactions AS
+SELECT
+    id,
+    type::text AS channel,
+    created_date,
+    total_sale_amount,
+    total_fee_amount,
+    total_base_amount,
+    total_net_profit,
+    1 AS order_count
+FROM online_transactions
+WHERE deleted_at IS NULL
+UNION ALL
+SELECT
+    id,
+    'OFFLINE',
+    sale_date,
+    total_amount,
+    0,
+    total_cost_amount,
+    total_net_profit,
+    1
+FROM sales
+WHERE status = 'COMPLETED'
+UNION ALL
+SELECT
+    r.id,
+    'OFFLINE',
+    r.return_date,
+    -r.refund_amount,
+    0,
+    -r.cost_amount,
+    r.cost_amount - r.refund_amount,
+    0
+FROM sale_returns r
+JOIN sales s ON s.id = r.sale_id
+WHERE s.status = 'COMPLETED';
+
+-- Order lines of every channel for the product breakdown of the summary
+CREATE VIEW summary_transaction_lines AS
+SELECT
+    p.online_transaction_id AS transaction_id,
+    t.created_date,
+    p.variant_id,
+    p.product_name::text AS product_name,
+    p.quantity,
+    p.sale_price * p.quantity AS revenue,
+    p.fee_amount AS fees,
+    p.cost_price * p.quantity AS cost
+FROM online_transaction_products p
+JOIN online_transactions t ON t.id = p.online_transaction_id
+WHERE t.deleted_at IS NULL
+UNION ALL
+SELECT
+    i.sale_id,
+    s.sale_date,
+    i.variant_id,
+    i.product_name::text,
+    i.quantity,
+    i.net_amount,
+    0,
+    i.cost_price * i.quantity
+FROM sale_items i
+JOIN sales s ON s.id = i.sale_id
+WHERE s.status = 'COMPLETED'
+UNION ALL
+SELECT
+    r.sale_id,
+    r.return_date,
+    i.variant_id,
+    i.product_name::text,
+    -ri.quantity,
+    -ri.refund_amount,
+    0,
+    -ri.cost_amount
+FROM sale_return_items ri
+JOIN sale_returns r ON r.id = ri.return_id
+JOIN sale_items i ON i.id = ri.sale_item_id
+JOIN sales s ON s.id = r.sale_id
+WHERE s.status = 'COMPLETED';
+
+-- migrate:down
+DROP VIEW IF EXISTS summary_transaction_lines;
+DROP VIEW IF EXISTS summary_transactions;
+DROP TABLE IF EXISTS sale_return_items;
+DROP TABLE IF EXISTS sale_returns;
+DROP TABLE IF EXISTS sale_items;
+DROP TABLE IF EXISTS sales;
+DROP SEQUENCE IF EXISTS sale_number_seq;
+DROP TYPE IF EXISTS sale_status;
+DROP TYPE IF EXISTS sale_payment_method;
@@ -0,0 +1,21 @@
+-- migrate:up
+-- Single row with the shop details printed on receipts and invoices
+CREATE TABLE IF NOT EXISTS shop_settings (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    shop_name VARCHAR(100) NOT NULL,
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    phone VARCHAR(30) NOT NULL DEFAULT '',
+    -- free text lines printed above and below the receipt items
+    receipt_header VARCHAR(500) NOT NULL DEFAULT '',
+    receipt_footer VARCHAR(500) NOT NULL DEFAULT '',
+    invoice_footer VARCHAR(500) NOT NULL DEFAULT '',
+    updated_by VARCHAR(30) NOT NULL,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO shop_settings (id, shop_name, receipt_footer, updated_by)
+VALUES (1, 'Rizki Plastik', 'Terima kasih atas kunjungan Anda', 'system')
+ON CONFLICT (id) DO NOTHING;
+
+-- migrate:down
+DROP TABLE IF EXISTS shop_settings;
@@ -0,0 +1,110 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS suppliers (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    contact_name VARCHAR(100) NULL,
+    phone VARCHAR(30) NULL,
+    email VARCHAR(100) NULL,
+    address VARCHAR(255) NULL,
+    -- days until a purchase order is due, 0 is cash on delivery
+    payment_term_days INTEGER NOT NULL DEFAULT 0 CHECK (payment_term_days >= 0),
+    note VARCHAR(255) NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    deleted_by VARCHAR(30) NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz NULL
+);
+
+CREATE UNIQUE INDEX idx_unique_suppliers_name
+ON suppliers (LOWER(name))
+WHERE deleted_at IS NULL;
+
+CREATE TYPE purchase_order_status AS ENUM (
+    'DRAFT',
+    'SENT',
+    'PARTIALLY_RECEIVED',
+    'RECEIVED',
+    'CLOSED'
+);
+
+CREATE TYPE goods_receipt_cost_policy AS ENUM ('LAST_COST', 'WEIGHTED_AVERAGE');
+
+CREATE SEQUENCE IF NOT EXISTS purchase_order_number_seq;
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id UUID PRIMARY KEY,
+    po_number VARCHAR(30) NOT NULL,
+    supplier_id UUID NOT NULL REFERENCES suppliers(id),
+    order_date DATE NOT NULL,
+    expected_date DATE NULL,
+    status purchase_order_status NOT NULL DEFAULT 'DRAFT',
+    -- payment terms are copied from the supplier when the order is created
+    payment_term_days INTEGER NOT NULL DEFAULT 0,
+    total_amount DECIMAL(14, 2) NOT NULL,
+    note VARCHAR(255) NULL,
+    sent_at timestamptz NULL,
+    closed_at timestamptz NULL,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_unique_purchase_orders_po_number ON purchase_orders (po_number);
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX idx_purchase_orders_order_date ON purchase_orders (order_date DESC, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id),
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    -- variant full name at order time
+    product_name VARCHAR(100) NOT NULL,
+    quantity DECIMAL(14, 4) NOT NULL CHECK (quantity > 0),
+    unit_cost DECIMAL(12, 2) NOT NULL CHECK (unit_cost >= 0),
+    received_quantity DECIMAL(14, 4) NOT NULL DEFAULT 0,
+    CONSTRAINT chk_purchase_order_items_received_quantity
+        CHECK (received_quantity >= 0 AND received_quantity <= quantity)
+);
+
+CREATE UNIQUE INDEX idx_unique_purchase_order_items_variant
+ON purchase_order_items (purchase_order_id, variant_id);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id UUID PRIMARY KEY,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id),
+    receipt_date DATE NOT NULL,
+    -- policy used to update the variant cost price, NULL keeps it unchanged
+    cost_policy goods_receipt_cost_policy NULL,
+    note VARCHAR(255) NULL,
+    created_by VARCHAR(30) NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_goods_receipts_purchase_order_id ON goods_receipts (purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_items (
+    id UUID PRIMARY KEY,
+    receipt_id UUID NOT NULL REFERENCES goods_receipts(id),
+    purchase_order_item_id UUID NOT NULL REFERENCES purchase_order_items(id),
+    quantity DECIMAL(14, 4) NOT NULL CHECK (quantity > 0),
+    unit_cost DECIMAL(12, 2) NOT NULL,
+    -- variant cost price before and after the receipt when a policy is used
+    previous_cost_price DECIMAL(10, 2) NULL,
+    new_cost_price DECIMAL(10, 2) NULL
+);
+
+CREATE INDEX idx_goods_receipt_items_receipt_id ON goods_receipt_items (receipt_id);
+
+-- migrate:down
+DROP TABLE IF EXISTS goods_receipt_items;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP SEQUENCE IF EXISTS purchase_order_number_seq;
+DROP TYPE IF EXISTS goods_receipt_cost_policy;
+DROP TYPE IF EXISTS purchase_order_status;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,53 @@
+-- migrate:up
+CREATE TYPE customer_price_tier AS ENUM ('RETAIL', 'RESELLER', 'WHOLESALE');
+
+CREATE TABLE IF NOT EXISTS customers (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    phone VARCHAR(30) NULL,
+    address VARCHAR(255) NULL,
+    price_tier customer_price_tier NOT NULL DEFAULT 'RETAIL',
+    note VARCHAR(255) NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(30) NOT NULL,
+    updated_by VARCHAR(30) NOT NULL,
+    deleted_by VARCHAR(30) NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz NULL
+);
+
+CREATE UNIQUE INDEX idx_unique_customers_phone
+ON customers (phone)
+WHERE deleted_at IS NULL AND phone IS NOT NULL;
+
+-- a row with min_quantity 1 is the price of the tier, higher minimums are
+-- quantity breaks of that tier
+CREATE TABLE IF NOT EXISTS variant_tier_prices (
+    id UUID PRIMARY KEY,
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    price_tier customer_price_tier NOT NULL,
+    min_quantity INTEGER NOT NULL CHECK (min_quantity >= 1),
+    unit_price DECIMAL(10, 2) NOT NULL CHECK (unit_price >= 0),
+    created_by VARCHAR(30) NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_unique_variant_tier_prices
+ON variant_tier_prices (variant_id, price_tier, min_quantity);
+
+-- the customer and its tier at sale time, walk-in sales have no customer
+ALTER TABLE sales
+ADD COLUMN customer_id UUID NULL REFERENCES customers(id),
+ADD COLUMN price_tier customer_price_tier NOT NULL DEFAULT 'RETAIL';
+
+CREATE INDEX idx_sales_customer_id ON sales (customer_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_sales_customer_id;
+ALTER TABLE sales
+DROP COLUMN IF EXISTS price_tier,
+DROP COLUMN IF EXISTS customer_id;
+DROP TABLE IF EXISTS variant_tier_prices;
+DROP TABLE IF EXISTS customers;
+DROP TYPE IF EXISTS customer_price_tier;
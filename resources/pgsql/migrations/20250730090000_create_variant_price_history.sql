@@ -0,0 +1,63 @@
+-- migrate:up
+CREATE TYPE variant_price_change_source AS ENUM (
+    'MANUAL',
+    'REPACK_COST',
+    'GOODS_RECEIPT',
+    'SCHEDULED'
+);
+
+CREATE TYPE variant_price_schedule_status AS ENUM (
+    'PENDING',
+    'APPLIED',
+    'CANCELLED'
+);
+
+-- a null price keeps the current price of the variant when the schedule is
+-- applied
+CREATE TABLE IF NOT EXISTS variant_price_schedules (
+    id UUID PRIMARY KEY,
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    cost_price DECIMAL(10, 2) NULL CHECK (cost_price >= 0),
+    selling_price DECIMAL(10, 2) NULL CHECK (selling_price >= 0),
+    effective_at timestamptz NOT NULL,
+    reason VARCHAR(255) NULL,
+    status variant_price_schedule_status NOT NULL DEFAULT 'PENDING',
+    applied_at timestamptz NULL,
+    created_by VARCHAR(30) NOT NULL,
+    cancelled_by VARCHAR(30) NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at timestamptz NULL,
+    CHECK (cost_price IS NOT NULL OR selling_price IS NOT NULL)
+);
+
+CREATE INDEX idx_variant_price_schedules_variant_id
+ON variant_price_schedules (variant_id, effective_at);
+
+CREATE INDEX idx_variant_price_schedules_pending
+ON variant_price_schedules (effective_at)
+WHERE status = 'PENDING';
+
+-- one row for every change of the cost or selling price of a variant
+CREATE TABLE IF NOT EXISTS variant_price_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    variant_id UUID NOT NULL REFERENCES product_variants(id),
+    old_cost_price DECIMAL(10, 2) NULL,
+    new_cost_price DECIMAL(10, 2) NULL,
+    old_selling_price DECIMAL(10, 2) NOT NULL,
+    new_selling_price DECIMAL(10, 2) NOT NULL,
+    source variant_price_change_source NOT NULL,
+    reason VARCHAR(255) NULL,
+    schedule_id UUID NULL REFERENCES variant_price_schedules(id),
+    changed_by VARCHAR(30) NOT NULL,
+    changed_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_variant_price_history_variant_id
+ON variant_price_history (variant_id, changed_at DESC);
+
+-- migrate:down
+DROP TABLE IF EXISTS variant_price_history;
+DROP TABLE IF EXISTS variant_price_schedules;
+DROP TYPE IF EXISTS variant_price_schedule_status;
+DROP TYPE IF EXISTS variant_price_change_source;